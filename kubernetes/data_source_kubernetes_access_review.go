@@ -0,0 +1,233 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesAccessReview() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesAccessReviewRead,
+		Schema: map[string]*schema.Schema{
+			"verb": {
+				Type:        schema.TypeString,
+				Description: "Kubernetes resource API verb, like: get, list, watch, create, update, delete, proxy. \"*\" means all.",
+				Required:    true,
+			},
+			"resource_attributes": {
+				Type:         schema.TypeList,
+				Description:  "Describes the resource being accessed.",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"resource_attributes", "non_resource_path"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the action being requested. An empty namespace means all namespaces for namespaced resources, and is required for cluster-scoped resources.",
+							Optional:    true,
+						},
+						"group": {
+							Type:        schema.TypeString,
+							Description: "API group of the resource. \"*\" means all.",
+							Optional:    true,
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Description: "API version of the resource. \"*\" means all.",
+							Optional:    true,
+						},
+						"resource": {
+							Type:        schema.TypeString,
+							Description: "One of the existing resource types. \"*\" means all.",
+							Required:    true,
+						},
+						"subresource": {
+							Type:        schema.TypeString,
+							Description: "One of the existing subresources.",
+							Optional:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the resource being requested. An empty name means all.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"non_resource_path": {
+				Type:        schema.TypeString,
+				Description: "URL path of a non-resource request, like /healthz.",
+				Optional:    true,
+			},
+			"user": {
+				Type:          schema.TypeString,
+				Description:   "The user to check access for. When neither user, groups nor service_account are set, access is checked for the credentials used by the provider.",
+				Optional:      true,
+				ConflictsWith: []string{"service_account"},
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Description: "The groups the user to check access for belongs to.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"service_account": {
+				Type:          schema.TypeList,
+				Description:   "The service account to check access for.",
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"user"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the service account.",
+							Required:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the service account.",
+							Optional:    true,
+							Default:     "default",
+						},
+					},
+				},
+			},
+			"allowed": {
+				Type:        schema.TypeBool,
+				Description: "Whether the action would be allowed.",
+				Computed:    true,
+			},
+			"denied": {
+				Type:        schema.TypeBool,
+				Description: "Whether the action was explicitly denied by an authorizer. An action may be neither allowed nor denied.",
+				Computed:    true,
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Description: "The reason why the action was allowed or denied, as reported by the authorizer.",
+				Computed:    true,
+			},
+			"evaluation_error": {
+				Type:        schema.TypeString,
+				Description: "An indication that some error occurred during the authorization check.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesAccessReviewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceAttributes := expandAccessReviewResourceAttributes(d.Get("verb").(string), d.Get("resource_attributes").([]interface{}))
+	nonResourceAttributes := expandAccessReviewNonResourceAttributes(d.Get("verb").(string), d.Get("non_resource_path").(string))
+	user, groups := expandAccessReviewSubject(
+		d.Get("user").(string),
+		d.Get("groups").([]interface{}),
+		d.Get("service_account").([]interface{}))
+
+	var status authorizationv1.SubjectAccessReviewStatus
+	if user == "" && len(groups) == 0 {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes:    resourceAttributes,
+				NonResourceAttributes: nonResourceAttributes,
+			},
+		}
+		log.Printf("[INFO] Creating self subject access review: %#v", review)
+		out, err := conn.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		status = out.Status
+	} else {
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes:    resourceAttributes,
+				NonResourceAttributes: nonResourceAttributes,
+				User:                  user,
+				Groups:                groups,
+			},
+		}
+		log.Printf("[INFO] Creating subject access review: %#v", review)
+		out, err := conn.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		status = out.Status
+	}
+	log.Printf("[INFO] Received access review status: %#v", status)
+
+	attrs := map[string]interface{}{
+		"allowed":          status.Allowed,
+		"denied":           status.Denied,
+		"reason":           status.Reason,
+		"evaluation_error": status.EvaluationError,
+	}
+	for k, v := range attrs {
+		err = d.Set(k, v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	id, err := json.Marshal([]interface{}{resourceAttributes, nonResourceAttributes, user, groups})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(id)))
+	return nil
+}
+
+func expandAccessReviewResourceAttributes(verb string, l []interface{}) *authorizationv1.ResourceAttributes {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	return &authorizationv1.ResourceAttributes{
+		Verb:        verb,
+		Namespace:   in["namespace"].(string),
+		Group:       in["group"].(string),
+		Version:     in["version"].(string),
+		Resource:    in["resource"].(string),
+		Subresource: in["subresource"].(string),
+		Name:        in["name"].(string),
+	}
+}
+
+func expandAccessReviewNonResourceAttributes(verb, path string) *authorizationv1.NonResourceAttributes {
+	if path == "" {
+		return nil
+	}
+	return &authorizationv1.NonResourceAttributes{
+		Verb: verb,
+		Path: path,
+	}
+}
+
+// expandAccessReviewSubject returns the user and groups a SubjectAccessReview
+// should be evaluated for. Service accounts are translated into the user and
+// groups the API server assigns to their tokens.
+func expandAccessReviewSubject(user string, groups []interface{}, serviceAccount []interface{}) (string, []string) {
+	g := expandStringSlice(groups)
+	if len(serviceAccount) > 0 && serviceAccount[0] != nil {
+		sa := serviceAccount[0].(map[string]interface{})
+		namespace := sa["namespace"].(string)
+		user = fmt.Sprintf("system:serviceaccount:%s:%s", namespace, sa["name"].(string))
+		g = append(g, "system:serviceaccounts", fmt.Sprintf("system:serviceaccounts:%s", namespace), "system:authenticated")
+	}
+	return user, g
+}
@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceAccessReview_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceAccessReviewConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_access_review.self", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.kubernetes_access_review.list_pods", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.kubernetes_access_review.list_secrets", "allowed", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandAccessReviewSubject(t *testing.T) {
+	cases := []struct {
+		user           string
+		groups         []interface{}
+		serviceAccount []interface{}
+		expectedUser   string
+		expectedGroups []string
+	}{
+		{
+			expectedUser:   "",
+			expectedGroups: []string{},
+		},
+		{
+			user:           "jane",
+			groups:         []interface{}{"developers"},
+			expectedUser:   "jane",
+			expectedGroups: []string{"developers"},
+		},
+		{
+			serviceAccount: []interface{}{map[string]interface{}{"name": "ci", "namespace": "build"}},
+			expectedUser:   "system:serviceaccount:build:ci",
+			expectedGroups: []string{"system:serviceaccounts", "system:serviceaccounts:build", "system:authenticated"},
+		},
+	}
+
+	for _, tc := range cases {
+		user, groups := expandAccessReviewSubject(tc.user, tc.groups, tc.serviceAccount)
+		if user != tc.expectedUser {
+			t.Fatalf("expected user %q, got %q", tc.expectedUser, user)
+		}
+		if !reflect.DeepEqual(groups, tc.expectedGroups) {
+			t.Fatalf("expected groups %#v, got %#v", tc.expectedGroups, groups)
+		}
+	}
+}
+
+func testAccKubernetesDataSourceAccessReviewConfig_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_service_account_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+}
+
+resource "kubernetes_role_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  rule {
+    api_groups = [""]
+    resources  = ["pods"]
+    verbs      = ["list"]
+  }
+}
+
+resource "kubernetes_role_binding_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  role_ref {
+    api_group = "rbac.authorization.k8s.io"
+    kind      = "Role"
+    name      = kubernetes_role_v1.test.metadata.0.name
+  }
+  subject {
+    kind      = "ServiceAccount"
+    name      = kubernetes_service_account_v1.test.metadata.0.name
+    namespace = "default"
+  }
+}
+
+data "kubernetes_access_review" "self" {
+  verb = "get"
+  resource_attributes {
+    resource  = "pods"
+    namespace = "default"
+  }
+}
+
+data "kubernetes_access_review" "list_pods" {
+  verb = "list"
+  resource_attributes {
+    resource  = "pods"
+    namespace = kubernetes_role_binding_v1.test.metadata.0.namespace
+  }
+  service_account {
+    name = kubernetes_service_account_v1.test.metadata.0.name
+  }
+}
+
+data "kubernetes_access_review" "list_secrets" {
+  verb = "list"
+  resource_attributes {
+    resource  = "secrets"
+    namespace = kubernetes_role_binding_v1.test.metadata.0.namespace
+  }
+  service_account {
+    name = kubernetes_service_account_v1.test.metadata.0.name
+  }
+}
+`, name, name, name)
+}
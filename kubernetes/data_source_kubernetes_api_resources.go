@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+func dataSourceKubernetesAPIResources() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesAPIResourcesRead,
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:        schema.TypeString,
+				Description: "Only list resources of this API group. Use an empty string for the core group. When omitted, resources of all groups are listed.",
+				Optional:    true,
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Description: "List of all API groups served by the cluster, the core group being represented by an empty string.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Description: "List of API resources served by the cluster.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Type:        schema.TypeString,
+							Description: "API group of the resource.",
+							Computed:    true,
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Description: "API version of the resource within its group.",
+							Computed:    true,
+						},
+						"api_version": {
+							Type:        schema.TypeString,
+							Description: "Group and version of the resource, as used in the `apiVersion` field of manifests.",
+							Computed:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "Kind of the resource.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Plural name of the resource, as used in API paths.",
+							Computed:    true,
+						},
+						"namespaced": {
+							Type:        schema.TypeBool,
+							Description: "Whether the resource is namespaced.",
+							Computed:    true,
+						},
+						"verbs": {
+							Type:        schema.TypeList,
+							Description: "List of verbs supported by the resource.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesAPIResourcesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dc, err := meta.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Listing API resources")
+	groups, resourceLists, err := dc.ServerGroupsAndResources()
+	if err != nil {
+		// Aggregated APIs that are temporarily unavailable should not prevent
+		// the rest of the discovery data from being used.
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			log.Printf("[DEBUG] Received error: %#v", err)
+			return diag.FromErr(err)
+		}
+		log.Printf("[WARN] Partial API discovery failure: %s", err)
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for _, g := range groups {
+		groupNames = append(groupNames, g.Name)
+	}
+	sort.Strings(groupNames)
+	err = d.Set("groups", groupNames)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group, filterGroup := d.GetOkExists("group")
+	resources, err := flattenAPIResourceLists(resourceLists, group.(string), filterGroup)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received %d API resources", len(resources))
+	err = d.Set("resources", resources)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	idsum := sha256.New()
+	for _, r := range resources {
+		_, err := idsum.Write([]byte(fmt.Sprintf("%s/%s", r["api_version"], r["name"])))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(fmt.Sprintf("%x", idsum.Sum(nil)))
+	return nil
+}
+
+func flattenAPIResourceLists(in []*metav1.APIResourceList, group string, filterGroup bool) ([]map[string]interface{}, error) {
+	att := []map[string]interface{}{}
+	for _, rl := range in {
+		gv, err := k8sschema.ParseGroupVersion(rl.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		if filterGroup && gv.Group != group {
+			continue
+		}
+		for _, r := range rl.APIResources {
+			// skip subresources such as deployments/scale
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			att = append(att, map[string]interface{}{
+				"group":       gv.Group,
+				"version":     gv.Version,
+				"api_version": gv.String(),
+				"kind":        r.Kind,
+				"name":        r.Name,
+				"namespaced":  r.Namespaced,
+				"verbs":       []string(r.Verbs),
+			})
+		}
+	}
+	sort.SliceStable(att, func(i, j int) bool {
+		if att[i]["api_version"] != att[j]["api_version"] {
+			return att[i]["api_version"].(string) < att[j]["api_version"].(string)
+		}
+		return att[i]["name"].(string) < att[j]["name"].(string)
+	})
+	return att, nil
+}
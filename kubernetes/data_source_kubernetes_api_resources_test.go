@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesDataSourceAPIResources_basic(t *testing.T) {
+	rxPosNum := regexp.MustCompile("^[1-9][0-9]*$")
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceAPIResourcesConfig_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.kubernetes_api_resources.all", "resources.#", rxPosNum),
+					resource.TestMatchResourceAttr("data.kubernetes_api_resources.all", "groups.#", rxPosNum),
+					resource.TestCheckTypeSetElemAttr("data.kubernetes_api_resources.all", "groups.*", "apps"),
+					resource.TestMatchResourceAttr("data.kubernetes_api_resources.apps", "resources.#", rxPosNum),
+					resource.TestCheckTypeSetElemNestedAttrs("data.kubernetes_api_resources.apps", "resources.*", map[string]string{
+						"group":       "apps",
+						"version":     "v1",
+						"api_version": "apps/v1",
+						"kind":        "Deployment",
+						"name":        "deployments",
+						"namespaced":  "true",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenAPIResourceLists(t *testing.T) {
+	in := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"get", "list"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}},
+				{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: []string{"create"}},
+				{Name: "deployments/scale", Kind: "Scale", Namespaced: true, Verbs: []string{"get"}},
+			},
+		},
+	}
+
+	cases := []struct {
+		group       string
+		filterGroup bool
+		expected    []string
+	}{
+		{"", false, []string{"apps/v1/deployments", "v1/namespaces", "v1/pods"}},
+		{"", true, []string{"v1/namespaces", "v1/pods"}},
+		{"apps", true, []string{"apps/v1/deployments"}},
+		{"monitoring.coreos.com", true, []string{}},
+	}
+
+	for _, tc := range cases {
+		out, err := flattenAPIResourceLists(in, tc.group, tc.filterGroup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, r := range out {
+			got = append(got, r["api_version"].(string)+"/"+r["name"].(string))
+		}
+		if len(got) != len(tc.expected) {
+			t.Fatalf("group %q: expected %v, got %v", tc.group, tc.expected, got)
+		}
+		for i := range got {
+			if got[i] != tc.expected[i] {
+				t.Fatalf("group %q: expected %v, got %v", tc.group, tc.expected, got)
+			}
+		}
+	}
+}
+
+func testAccKubernetesDataSourceAPIResourcesConfig_basic() string {
+	return `
+data "kubernetes_api_resources" "all" {}
+
+data "kubernetes_api_resources" "apps" {
+  group = "apps"
+}
+`
+}
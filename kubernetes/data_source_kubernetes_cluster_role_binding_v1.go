@@ -0,0 +1,25 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKubernetesClusterRoleBindingV1() *schema.Resource {
+	ds := datasourceSchemaFromResourceSchema(resourceKubernetesClusterRoleBinding().Schema)
+	ds["metadata"] = metadataSchema("cluster role binding", false)
+
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesClusterRoleBindingV1Read,
+		Schema:      ds,
+	}
+}
+
+func dataSourceKubernetesClusterRoleBindingV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+
+	return resourceKubernetesClusterRoleBindingRead(ctx, d, meta)
+}
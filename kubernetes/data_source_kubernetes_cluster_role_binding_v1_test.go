@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceClusterRoleBindingV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceClusterRoleBindingV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_binding_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_binding_v1.test", "role_ref.0.name", "cluster-admin"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_binding_v1.test", "subject.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_binding_v1.test", "subject.0.name", "notauser"),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceClusterRoleBindingV1Config_basic(name) +
+					testAccKubernetesDataSourceClusterRoleBindingV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cluster_role_binding_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding_v1.test", "role_ref.0.name", "cluster-admin"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding_v1.test", "subject.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding_v1.test", "subject.0.name", "notauser"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceClusterRoleBindingV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_cluster_role_binding_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  role_ref {
+    api_group = "rbac.authorization.k8s.io"
+    kind      = "ClusterRole"
+    name      = "cluster-admin"
+  }
+  subject {
+    kind      = "User"
+    name      = "notauser"
+    api_group = "rbac.authorization.k8s.io"
+  }
+}
+`, name)
+}
+
+func testAccKubernetesDataSourceClusterRoleBindingV1Config_read() string {
+	return `data "kubernetes_cluster_role_binding_v1" "test" {
+  metadata {
+    name = "${kubernetes_cluster_role_binding_v1.test.metadata.0.name}"
+  }
+}
+`
+}
@@ -0,0 +1,25 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKubernetesClusterRoleV1() *schema.Resource {
+	ds := datasourceSchemaFromResourceSchema(resourceKubernetesClusterRole().Schema)
+	ds["metadata"] = metadataSchema("cluster role", false)
+
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesClusterRoleV1Read,
+		Schema:      ds,
+	}
+}
+
+func dataSourceKubernetesClusterRoleV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+
+	return resourceKubernetesClusterRoleRead(ctx, d, meta)
+}
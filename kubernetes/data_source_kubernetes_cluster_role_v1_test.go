@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceClusterRoleV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceClusterRoleV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_v1.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_v1.test", "rule.0.resources.0", "secrets"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role_v1.test", "rule.0.verbs.0", "list"),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceClusterRoleV1Config_basic(name) +
+					testAccKubernetesDataSourceClusterRoleV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cluster_role_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_v1.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_v1.test", "rule.0.resources.0", "secrets"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_v1.test", "rule.0.verbs.0", "list"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceClusterRoleV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_cluster_role_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  rule {
+    api_groups = [""]
+    resources  = ["secrets"]
+    verbs      = ["list"]
+  }
+}
+`, name)
+}
+
+func testAccKubernetesDataSourceClusterRoleV1Config_read() string {
+	return `data "kubernetes_cluster_role_v1" "test" {
+  metadata {
+    name = "${kubernetes_cluster_role_v1.test.metadata.0.name}"
+  }
+}
+`
+}
@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesCronJobV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesCronJobV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("cron job", false),
+			"spec":     datasourceSchemaFromSchema(resourceKubernetesCronJobV1().Schema["spec"]),
+			"status":   cronJobStatusSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesCronJobV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+
+	om := metav1.ObjectMeta{
+		Namespace: metadata.Namespace,
+		Name:      metadata.Name,
+	}
+	d.SetId(buildId(om))
+
+	log.Printf("[INFO] Reading cron job %s", metadata.Name)
+	cronJob, err := conn.BatchV1().CronJobs(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received cron job: %#v", cronJob)
+
+	err = d.Set("metadata", flattenMetadata(cronJob.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec, err := flattenCronJobSpecV1(cronJob.Spec, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("spec", spec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("status", flattenCronJobStatus(cronJob.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceCronJobV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImageVersion
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t); skipIfClusterVersionLessThan(t, "1.21.0") },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceCronJobV1Config_basic(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_cron_job_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_cron_job_v1.test", "spec.0.schedule", "1 0 * * *"),
+					resource.TestCheckResourceAttr("kubernetes_cron_job_v1.test", "spec.0.job_template.0.spec.0.template.0.spec.0.container.0.image", imageName),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceCronJobV1Config_basic(name, imageName) +
+					testAccKubernetesDataSourceCronJobV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_cron_job_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cron_job_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_cron_job_v1.test", "spec.0.schedule", "1 0 * * *"),
+					resource.TestCheckResourceAttr("data.kubernetes_cron_job_v1.test", "spec.0.job_template.0.spec.0.template.0.spec.0.container.0.image", imageName),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceCronJobV1Config_basic(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_cron_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    schedule = "1 0 * * *"
+    job_template {
+      metadata {}
+      spec {
+        template {
+          metadata {}
+          spec {
+            container {
+              name    = "hello"
+              image   = "%s"
+              command = ["echo", "'hello'"]
+            }
+            restart_policy = "Never"
+          }
+        }
+      }
+    }
+  }
+}
+`, name, imageName)
+}
+
+func testAccKubernetesDataSourceCronJobV1Config_read() string {
+	return `data "kubernetes_cron_job_v1" "test" {
+  metadata {
+    name      = "${kubernetes_cron_job_v1.test.metadata.0.name}"
+    namespace = "${kubernetes_cron_job_v1.test.metadata.0.namespace}"
+  }
+}
+`
+}
@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesDaemonSetV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesDaemonSetV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("daemon set", false),
+			"spec":     datasourceSchemaFromSchema(resourceKubernetesDaemonSetSchemaV1()["spec"]),
+			"status":   daemonSetStatusSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesDaemonSetV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+
+	om := metav1.ObjectMeta{
+		Namespace: metadata.Namespace,
+		Name:      metadata.Name,
+	}
+	d.SetId(buildId(om))
+
+	log.Printf("[INFO] Reading daemon set %s", metadata.Name)
+	daemonSet, err := conn.AppsV1().DaemonSets(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received daemon set: %#v", daemonSet)
+
+	err = d.Set("metadata", flattenMetadata(daemonSet.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec, err := flattenDaemonSetSpec(daemonSet.Spec, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("spec", spec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("status", flattenDaemonSetStatus(daemonSet.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
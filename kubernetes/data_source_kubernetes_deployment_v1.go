@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesDeploymentV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesDeploymentV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("deployment", false),
+			"spec":     datasourceSchemaFromSchema(resourceKubernetesDeploymentSchemaV1()["spec"]),
+			"status":   deploymentStatusSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesDeploymentV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+
+	om := metav1.ObjectMeta{
+		Namespace: metadata.Namespace,
+		Name:      metadata.Name,
+	}
+	d.SetId(buildId(om))
+
+	log.Printf("[INFO] Reading deployment %s", metadata.Name)
+	deployment, err := conn.AppsV1().Deployments(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received deployment: %#v", deployment)
+
+	err = d.Set("metadata", flattenMetadata(deployment.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec, err := flattenDeploymentSpec(deployment.Spec, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("spec", spec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("status", flattenDeploymentStatus(deployment.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
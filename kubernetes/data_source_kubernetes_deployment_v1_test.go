@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceDeploymentV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := nginxImageVersion
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceDeploymentV1Config_basic(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_deployment_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_deployment_v1.test", "spec.0.replicas", "2"),
+					resource.TestCheckResourceAttr("kubernetes_deployment_v1.test", "spec.0.template.0.spec.0.container.0.image", imageName),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceDeploymentV1Config_basic(name, imageName) +
+					testAccKubernetesDataSourceDeploymentV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_deployment_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_deployment_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment_v1.test", "spec.0.replicas", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment_v1.test", "spec.0.template.0.spec.0.container.0.image", imageName),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment_v1.test", "status.0.replicas", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment_v1.test", "status.0.ready_replicas", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_deployment_v1.test", "status.0.available_replicas", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceDeploymentV1Config_basic(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_deployment_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    replicas = 2
+    selector {
+      match_labels = {
+        app = "%s"
+      }
+    }
+    template {
+      metadata {
+        labels = {
+          app = "%s"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "tf-acc-test"
+        }
+      }
+    }
+  }
+}
+`, name, name, name, imageName)
+}
+
+func testAccKubernetesDataSourceDeploymentV1Config_read() string {
+	return `data "kubernetes_deployment_v1" "test" {
+  metadata {
+    name      = "${kubernetes_deployment_v1.test.metadata.0.name}"
+    namespace = "${kubernetes_deployment_v1.test.metadata.0.namespace}"
+  }
+}
+`
+}
@@ -0,0 +1,32 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesEndpointSliceV1() *schema.Resource {
+	rs := resourceKubernetesEndpointSliceV1().Schema
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesEndpointSliceV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata":     namespacedMetadataSchema("endpoint slice", false),
+			"address_type": datasourceSchemaFromSchema(rs["address_type"]),
+			"endpoint":     datasourceSchemaFromSchema(rs["endpoint"]),
+			"port":         datasourceSchemaFromSchema(rs["port"]),
+		},
+	}
+}
+
+func dataSourceKubernetesEndpointSliceV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	om := metav1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+	d.SetId(buildId(om))
+
+	return resourceKubernetesEndpointSliceV1Read(ctx, d, meta)
+}
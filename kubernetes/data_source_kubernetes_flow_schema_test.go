@@ -0,0 +1,53 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceFlowSchema_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesFlowSchemaConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_flow_schema.test", "metadata.0.name", name),
+				),
+			},
+			{
+				Config: testAccKubernetesFlowSchemaConfig_basic(name) +
+					testAccKubernetesDataSourceFlowSchemaConfig_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_flow_schema.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_flow_schema.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_flow_schema.test", "spec.0.priority_level_configuration.0.name", name),
+					resource.TestCheckResourceAttr("data.kubernetes_flow_schema.test", "status.0.condition.0.type", "Dangling"),
+					resource.TestCheckResourceAttr("data.kubernetes_flow_schema.test", "status.0.condition.0.status", "False"),
+					resource.TestCheckResourceAttr("data.kubernetes_priority_level_configuration.test", "spec.0.type", "Limited"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceFlowSchemaConfig_read() string {
+	return `data "kubernetes_flow_schema" "test" {
+  metadata {
+    name = kubernetes_flow_schema.test.metadata.0.name
+  }
+}
+
+data "kubernetes_priority_level_configuration" "test" {
+  metadata {
+    name = kubernetes_priority_level_configuration.test.metadata.0.name
+  }
+}
+`
+}
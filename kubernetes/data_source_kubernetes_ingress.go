@@ -131,7 +131,7 @@ func dataSourceKubernetesIngress() *schema.Resource {
 }
 
 func dataSourceKubernetesIngressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 
 	om := meta_v1.ObjectMeta{
 		Namespace: metadata.Namespace,
@@ -143,7 +143,7 @@ func dataSourceKubernetesIngressV1() *schema.Resource {
 }
 
 func dataSourceKubernetesIngressV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 
 	om := meta_v1.ObjectMeta{
 		Namespace: metadata.Namespace,
@@ -0,0 +1,72 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceJobV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImageVersion
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceJobV1Config_basic(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_job_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_job_v1.test", "spec.0.template.0.spec.0.container.0.image", imageName),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceJobV1Config_basic(name, imageName) +
+					testAccKubernetesDataSourceJobV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_job_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_job_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_job_v1.test", "spec.0.template.0.spec.0.container.0.image", imageName),
+					resource.TestCheckResourceAttr("data.kubernetes_job_v1.test", "status.0.succeeded", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.kubernetes_job_v1.test", "status.0.conditions.*", map[string]string{"type": "Complete", "status": "True"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceJobV1Config_basic(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "hello"
+          image   = "%s"
+          command = ["echo", "'hello'"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = true
+}
+`, name, imageName)
+}
+
+func testAccKubernetesDataSourceJobV1Config_read() string {
+	return `data "kubernetes_job_v1" "test" {
+  metadata {
+    name      = "${kubernetes_job_v1.test.metadata.0.name}"
+    namespace = "${kubernetes_job_v1.test.metadata.0.namespace}"
+  }
+}
+`
+}
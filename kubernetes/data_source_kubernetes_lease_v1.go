@@ -0,0 +1,29 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesLeaseV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesLeaseV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("lease", false),
+			"spec":     datasourceSchemaFromSchema(resourceKubernetesLeaseV1().Schema["spec"]),
+		},
+	}
+}
+
+func dataSourceKubernetesLeaseV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	om := metav1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+	d.SetId(buildId(om))
+
+	return resourceKubernetesLeaseV1Read(ctx, d, meta)
+}
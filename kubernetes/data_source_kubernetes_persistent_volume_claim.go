@@ -78,7 +78,7 @@ func dataSourceKubernetesPersistentVolumeClaim() *schema.Resource {
 }
 
 func dataSourceKubernetesPersistentVolumeClaimRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 
 	om := meta_v1.ObjectMeta{
 		Namespace: metadata.Namespace,
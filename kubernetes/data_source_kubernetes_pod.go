@@ -42,7 +42,7 @@ func dataSourceKubernetesPodRead(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 
 	om := metav1.ObjectMeta{
 		Namespace: metadata.Namespace,
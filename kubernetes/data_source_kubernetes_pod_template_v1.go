@@ -0,0 +1,29 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesPodTemplateV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesPodTemplateV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("pod template", false),
+			"template": datasourceSchemaFromSchema(resourceKubernetesPodTemplateV1().Schema["template"]),
+		},
+	}
+}
+
+func dataSourceKubernetesPodTemplateV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	om := metav1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+	d.SetId(buildId(om))
+
+	return resourceKubernetesPodTemplateV1Read(ctx, d, meta)
+}
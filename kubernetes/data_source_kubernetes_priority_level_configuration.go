@@ -0,0 +1,49 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func dataSourceKubernetesPriorityLevelConfiguration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesPriorityLevelConfigurationRead,
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("priority level configuration", false),
+			"spec":     datasourceSchemaFromSchema(resourceKubernetesPriorityLevelConfiguration().Schema["spec"]),
+			"status":   flowcontrolStatusSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesPriorityLevelConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+
+	obj, err := getPriorityLevelConfiguration(ctx, name, meta)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("metadata", flattenMetadata(obj.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenPriorityLevelConfigurationSpec(obj.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("status", flattenFlowcontrolStatus(obj.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
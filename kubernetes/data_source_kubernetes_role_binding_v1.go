@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesRoleBindingV1() *schema.Resource {
+	ds := datasourceSchemaFromResourceSchema(resourceKubernetesRoleBinding().Schema)
+	ds["metadata"] = namespacedMetadataSchema("role binding", false)
+
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesRoleBindingV1Read,
+		Schema:      ds,
+	}
+}
+
+func dataSourceKubernetesRoleBindingV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metadata := expandResourceMetadata(d)
+
+	om := metav1.ObjectMeta{
+		Namespace: metadata.Namespace,
+		Name:      metadata.Name,
+	}
+	d.SetId(buildId(om))
+
+	return resourceKubernetesRoleBindingRead(ctx, d, meta)
+}
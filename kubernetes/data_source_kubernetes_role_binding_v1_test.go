@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceRoleBindingV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceRoleBindingV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_role_binding_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_role_binding_v1.test", "role_ref.0.name", "admin"),
+					resource.TestCheckResourceAttr("kubernetes_role_binding_v1.test", "subject.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_role_binding_v1.test", "subject.0.name", "notauser"),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceRoleBindingV1Config_basic(name) +
+					testAccKubernetesDataSourceRoleBindingV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_role_binding_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_role_binding_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_binding_v1.test", "role_ref.0.name", "admin"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_binding_v1.test", "subject.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_binding_v1.test", "subject.0.name", "notauser"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceRoleBindingV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_role_binding_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  role_ref {
+    api_group = "rbac.authorization.k8s.io"
+    kind      = "Role"
+    name      = "admin"
+  }
+  subject {
+    kind      = "User"
+    name      = "notauser"
+    api_group = "rbac.authorization.k8s.io"
+  }
+}
+`, name)
+}
+
+func testAccKubernetesDataSourceRoleBindingV1Config_read() string {
+	return `data "kubernetes_role_binding_v1" "test" {
+  metadata {
+    name      = "${kubernetes_role_binding_v1.test.metadata.0.name}"
+    namespace = "${kubernetes_role_binding_v1.test.metadata.0.namespace}"
+  }
+}
+`
+}
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceRoleV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceRoleV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_role_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_role_v1.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_role_v1.test", "rule.0.resources.0", "pods"),
+					resource.TestCheckResourceAttr("kubernetes_role_v1.test", "rule.0.verbs.#", "2"),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceRoleV1Config_basic(name) +
+					testAccKubernetesDataSourceRoleV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_role_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_role_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_v1.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_v1.test", "rule.0.resources.0", "pods"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_v1.test", "rule.0.verbs.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceRoleV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_role_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  rule {
+    api_groups = [""]
+    resources  = ["pods"]
+    verbs      = ["get", "list"]
+  }
+}
+`, name)
+}
+
+func testAccKubernetesDataSourceRoleV1Config_read() string {
+	return `data "kubernetes_role_v1" "test" {
+  metadata {
+    name      = "${kubernetes_role_v1.test.metadata.0.name}"
+    namespace = "${kubernetes_role_v1.test.metadata.0.namespace}"
+  }
+}
+`
+}
@@ -0,0 +1,27 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKubernetesRuntimeClassV1() *schema.Resource {
+	rs := resourceKubernetesRuntimeClassV1().Schema
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesRuntimeClassV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata":   metadataSchema("runtime class", false),
+			"handler":    datasourceSchemaFromSchema(rs["handler"]),
+			"overhead":   datasourceSchemaFromSchema(rs["overhead"]),
+			"scheduling": datasourceSchemaFromSchema(rs["scheduling"]),
+		},
+	}
+}
+
+func dataSourceKubernetesRuntimeClassV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+	return resourceKubernetesRuntimeClassV1Read(ctx, d, meta)
+}
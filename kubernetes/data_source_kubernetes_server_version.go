@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	gversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKubernetesServerVersion() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesServerVersionRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Description: "Semantic version of the Kubernetes API server, without build metadata.",
+				Computed:    true,
+			},
+			"major": {
+				Type:        schema.TypeString,
+				Description: "Major version of the Kubernetes API server.",
+				Computed:    true,
+			},
+			"minor": {
+				Type:        schema.TypeString,
+				Description: "Minor version of the Kubernetes API server.",
+				Computed:    true,
+			},
+			"git_version": {
+				Type:        schema.TypeString,
+				Description: "Full version string reported by the Kubernetes API server.",
+				Computed:    true,
+			},
+			"git_commit": {
+				Type:        schema.TypeString,
+				Description: "Git commit the Kubernetes API server was built from.",
+				Computed:    true,
+			},
+			"build_date": {
+				Type:        schema.TypeString,
+				Description: "Date the Kubernetes API server was built.",
+				Computed:    true,
+			},
+			"go_version": {
+				Type:        schema.TypeString,
+				Description: "Go version the Kubernetes API server was built with.",
+				Computed:    true,
+			},
+			"compiler": {
+				Type:        schema.TypeString,
+				Description: "Compiler the Kubernetes API server was built with.",
+				Computed:    true,
+			},
+			"platform": {
+				Type:        schema.TypeString,
+				Description: "Operating system and architecture the Kubernetes API server runs on.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesServerVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading server version")
+	sv, err := conn.ServerVersion()
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received server version: %#v", sv)
+
+	v, err := gversion.NewVersion(sv.String())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	attrs := map[string]string{
+		"version":     v.Core().String(),
+		"major":       sv.Major,
+		"minor":       sv.Minor,
+		"git_version": sv.GitVersion,
+		"git_commit":  sv.GitCommit,
+		"build_date":  sv.BuildDate,
+		"go_version":  sv.GoVersion,
+		"compiler":    sv.Compiler,
+		"platform":    sv.Platform,
+	}
+	for k, v := range attrs {
+		err = d.Set(k, v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(sv.GitVersion)
+	return nil
+}
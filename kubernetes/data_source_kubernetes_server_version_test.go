@@ -0,0 +1,35 @@
+package kubernetes
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceServerVersion_basic(t *testing.T) {
+	rxNum := regexp.MustCompile(`^[0-9]+\+?$`)
+	rxVersion := regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceServerVersionConfig_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.kubernetes_server_version.test", "major", rxNum),
+					resource.TestMatchResourceAttr("data.kubernetes_server_version.test", "minor", rxNum),
+					resource.TestMatchResourceAttr("data.kubernetes_server_version.test", "version", rxVersion),
+					resource.TestCheckResourceAttrSet("data.kubernetes_server_version.test", "git_version"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_server_version.test", "platform"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceServerVersionConfig_basic() string {
+	return `
+data "kubernetes_server_version" "test" {}
+`
+}
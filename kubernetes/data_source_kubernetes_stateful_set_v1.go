@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesStatefulSetV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesStatefulSetV1Read,
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("stateful set", false),
+			"spec":     datasourceSchemaFromSchema(resourceKubernetesStatefulSetSchemaV1()["spec"]),
+			"status":   statefulSetStatusSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesStatefulSetV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+
+	om := metav1.ObjectMeta{
+		Namespace: metadata.Namespace,
+		Name:      metadata.Name,
+	}
+	d.SetId(buildId(om))
+
+	log.Printf("[INFO] Reading stateful set %s", metadata.Name)
+	statefulSet, err := conn.AppsV1().StatefulSets(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received stateful set: %#v", statefulSet)
+
+	err = d.Set("metadata", flattenMetadata(statefulSet.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec, err := flattenStatefulSetSpec(statefulSet.Spec, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("spec", spec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("status", flattenStatefulSetStatus(statefulSet.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
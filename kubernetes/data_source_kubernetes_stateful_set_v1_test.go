@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceStatefulSetV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := nginxImageVersion
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{ // The first apply creates the resource. The second apply reads the resource using a data source.
+				Config: testAccKubernetesDataSourceStatefulSetV1Config_basic(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_stateful_set_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_stateful_set_v1.test", "spec.0.replicas", "1"),
+					resource.TestCheckResourceAttr("kubernetes_stateful_set_v1.test", "spec.0.template.0.spec.0.container.0.image", imageName),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceStatefulSetV1Config_basic(name, imageName) +
+					testAccKubernetesDataSourceStatefulSetV1Config_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_stateful_set_v1.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_stateful_set_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_stateful_set_v1.test", "spec.0.replicas", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_stateful_set_v1.test", "spec.0.template.0.spec.0.container.0.image", imageName),
+					resource.TestCheckResourceAttr("data.kubernetes_stateful_set_v1.test", "status.0.replicas", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_stateful_set_v1.test", "status.0.ready_replicas", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceStatefulSetV1Config_basic(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_stateful_set_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    replicas     = 1
+    service_name = "%s"
+    selector {
+      match_labels = {
+        app = "%s"
+      }
+    }
+    template {
+      metadata {
+        labels = {
+          app = "%s"
+        }
+      }
+      spec {
+        container {
+          image = "%s"
+          name  = "tf-acc-test"
+        }
+      }
+    }
+  }
+}
+`, name, name, name, name, imageName)
+}
+
+func testAccKubernetesDataSourceStatefulSetV1Config_read() string {
+	return `data "kubernetes_stateful_set_v1" "test" {
+  metadata {
+    name      = "${kubernetes_stateful_set_v1.test.metadata.0.name}"
+    namespace = "${kubernetes_stateful_set_v1.test.metadata.0.namespace}"
+  }
+}
+`
+}
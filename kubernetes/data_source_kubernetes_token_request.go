@@ -0,0 +1,155 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func dataSourceKubernetesTokenRequest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesTokenRequestRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("service account", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Parameters of the requested token.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"audiences": {
+							Type:        schema.TypeList,
+							Description: "Intended audiences of the token. A recipient of the token must identify itself with an identifier in the list of audiences of the token. Defaults to the audiences of the API server.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"expiration_seconds": {
+							Type:         schema.TypeInt,
+							Description:  "The requested duration of validity of the token. The token issuer may return a token with a different validity duration. Defaults to 3600.",
+							Optional:     true,
+							Default:      3600,
+							ValidateFunc: validation.IntAtLeast(600),
+						},
+						"bound_object_ref": {
+							Type:        schema.TypeList,
+							Description: "Reference to an object that the token will be bound to. The token will only be valid for as long as the bound object exists.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"api_version": {
+										Type:        schema.TypeString,
+										Description: "API version of the referent.",
+										Optional:    true,
+										Default:     "v1",
+									},
+									"kind": {
+										Type:         schema.TypeString,
+										Description:  "Kind of the referent. Valid kinds are 'Pod' and 'Secret'.",
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"Pod", "Secret"}, false),
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the referent.",
+										Required:    true,
+									},
+									"uid": {
+										Type:        schema.TypeString,
+										Description: "UID of the referent.",
+										Optional:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "The bound service account token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expiration_timestamp": {
+				Type:        schema.TypeString,
+				Description: "Time at which the token expires, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesTokenRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+	tokenRequest := authv1.TokenRequest{
+		Spec: expandTokenRequestSpec(d.Get("spec").([]interface{})),
+	}
+
+	log.Printf("[INFO] Requesting token for service account %s/%s", metadata.Namespace, metadata.Name)
+	out, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).CreateToken(ctx, metadata.Name, &tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.Errorf("Failed to request token for service account %s/%s: %s", metadata.Namespace, metadata.Name, err)
+	}
+	log.Printf("[INFO] Received token expiring at %s", out.Status.ExpirationTimestamp)
+
+	err = d.Set("token", out.Status.Token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("expiration_timestamp", out.Status.ExpirationTimestamp.UTC().Format(time.RFC3339))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	om := metav1.ObjectMeta{
+		Namespace: metadata.Namespace,
+		Name:      metadata.Name,
+	}
+	d.SetId(fmt.Sprintf("%s/%d", buildId(om), out.Status.ExpirationTimestamp.Unix()))
+	return nil
+}
+
+func expandTokenRequestSpec(l []interface{}) authv1.TokenRequestSpec {
+	expirationSeconds := int64(3600)
+	obj := authv1.TokenRequestSpec{
+		ExpirationSeconds: &expirationSeconds,
+	}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["audiences"].([]interface{}); ok && len(v) > 0 {
+		obj.Audiences = expandStringSlice(v)
+	}
+	if v, ok := in["expiration_seconds"].(int); ok && v > 0 {
+		expirationSeconds = int64(v)
+	}
+	if v, ok := in["bound_object_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		ref := v[0].(map[string]interface{})
+		obj.BoundObjectRef = &authv1.BoundObjectReference{
+			APIVersion: ref["api_version"].(string),
+			Kind:       ref["kind"].(string),
+			Name:       ref["name"].(string),
+			UID:        types.UID(ref["uid"].(string)),
+		}
+	}
+	return obj
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+func TestAccKubernetesDataSourceTokenRequest_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceTokenRequestConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.kubernetes_token_request.test", "token", regexp.MustCompile(`^[\w-]+\.[\w-]+\.[\w-]+$`)),
+					resource.TestCheckResourceAttrSet("data.kubernetes_token_request.test", "expiration_timestamp"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandTokenRequestSpec(t *testing.T) {
+	defaultExpiration := int64(3600)
+	expiration := int64(1200)
+	cases := []struct {
+		in       []interface{}
+		expected authv1.TokenRequestSpec
+	}{
+		{
+			in:       []interface{}{},
+			expected: authv1.TokenRequestSpec{ExpirationSeconds: &defaultExpiration},
+		},
+		{
+			in: []interface{}{map[string]interface{}{
+				"audiences":          []interface{}{"vault"},
+				"expiration_seconds": 1200,
+				"bound_object_ref": []interface{}{map[string]interface{}{
+					"api_version": "v1",
+					"kind":        "Secret",
+					"name":        "bootstrap",
+					"uid":         "",
+				}},
+			}},
+			expected: authv1.TokenRequestSpec{
+				Audiences:         []string{"vault"},
+				ExpirationSeconds: &expiration,
+				BoundObjectRef: &authv1.BoundObjectReference{
+					APIVersion: "v1",
+					Kind:       "Secret",
+					Name:       "bootstrap",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		out := expandTokenRequestSpec(tc.in)
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Fatalf("expected %#v, got %#v", tc.expected, out)
+		}
+	}
+}
+
+func testAccKubernetesDataSourceTokenRequestConfig_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_service_account_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+}
+
+data "kubernetes_token_request" "test" {
+  metadata {
+    name      = kubernetes_service_account_v1.test.metadata.0.name
+    namespace = kubernetes_service_account_v1.test.metadata.0.namespace
+  }
+  spec {
+    audiences          = ["https://kubernetes.default.svc"]
+    expiration_seconds = 600
+  }
+}
+`, name)
+}
@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -16,3 +18,15 @@ func suppressEquivalentResourceQuantity(k, old, new string, d *schema.ResourceDa
 	}
 	return oldQ.Cmp(newQ) == 0
 }
+
+func suppressEquivalentRFC3339Time(k, old, new string, d *schema.ResourceData) bool {
+	oldT, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newT, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldT.Equal(newT)
+}
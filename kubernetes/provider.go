@@ -1,33 +1,38 @@
 package kubernetes
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-cty/cty"
 	gversion "github.com/hashicorp/go-version"
-	"github.com/mitchellh/go-homedir"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/restmapper"
 
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/transport"
 	aggregator "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 )
 
@@ -57,7 +62,7 @@ func Provider() *schema.Provider {
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("KUBE_INSECURE", false),
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_INSECURE", nil),
 				Description: "Whether server should be accessed without verifying the TLS certificate.",
 			},
 			"client_certificate": {
@@ -91,6 +96,12 @@ func Provider() *schema.Provider {
 				Description:   "Path to the kube config file. Can be set with KUBE_CONFIG_PATH.",
 				ConflictsWith: []string{"config_paths"},
 			},
+			"config_raw": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Raw kube config file content. Merged with the files in config_path or config_paths, taking precedence over them. Can be set with KUBE_CONFIG_RAW.",
+			},
 			"config_context": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -108,6 +119,16 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_CTX_CLUSTER", ""),
 				Description: "",
 			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Namespace of the namespaced resources that do not set one. Can be set with KUBE_NAMESPACE. Defaults to `default`, or to the namespace of the current kubeconfig context when `use_kubeconfig_namespace` is true.",
+			},
+			"use_kubeconfig_namespace": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Use the namespace of the current kubeconfig context for the namespaced resources that do not set one when `namespace` is not set.",
+			},
 			"token": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -117,8 +138,137 @@ func Provider() *schema.Provider {
 			"proxy_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "URL to the proxy to be used for all API requests",
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_PROXY_URL", ""),
+				Description: "URL to the proxy to be used for all API requests",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Server name used to verify the TLS certificate of the Kubernetes API server. Can be set with KUBE_TLS_SERVER_NAME.",
+			},
+			"kubernetes_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Kubernetes version of the cluster, such as `1.23`. Selects the OpenAPI schema bundled with the provider that `kubernetes_manifest` uses when the cluster cannot be reached.",
+			},
+			"qps": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "Maximum queries per second sent to the Kubernetes API server. Can be set with KUBE_QPS.",
+			},
+			"burst": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum burst of queries sent to the Kubernetes API server on top of qps. Can be set with KUBE_BURST.",
+			},
+			"request_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Timeout for a single request to the Kubernetes API server, as a duration such as \"30s\". Can be set with KUBE_REQUEST_TIMEOUT.",
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry requests that fail with a transient error.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Maximum number of attempts for a request, including the first one. Defaults to 5. The Kubernetes client retries responses with a Retry-After header up to 10 times by itself, within each attempt, so a request can be sent more often than max_attempts.",
+						},
+						"min_backoff": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Time to wait before the first retry, doubled on every further retry. Defaults to \"500ms\".",
+						},
+						"max_backoff": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Maximum time to wait between retries. Defaults to \"10s\".",
+						},
+						"status_codes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "HTTP status codes that are retried for idempotent requests. Defaults to 429, 500, 502, 503 and 504.",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+			"eks": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Authenticate to an EKS cluster with a token generated from the AWS credentials in the environment, without an exec plugin.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the EKS cluster.",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "AWS region of the EKS cluster. Defaults to AWS_REGION or AWS_DEFAULT_REGION.",
+						},
+						"role_arn": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ARN of an IAM role to assume before generating the token.",
+						},
+						"sts_endpoint": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Custom STS endpoint URL. Defaults to the regional STS endpoint.",
+						},
+					},
+				},
+			},
+			"oidc": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Authenticate with a token obtained from an OIDC provider using the client credentials or refresh token flow, without an exec plugin.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token_url": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Token endpoint of the OIDC provider.",
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "OIDC client ID.",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "OIDC client secret.",
+						},
+						"refresh_token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Refresh token to use the refresh token flow instead of the client credentials flow.",
+						},
+						"scopes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Scopes to request.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"use_id_token": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Send the ID token instead of the access token to the Kubernetes API server.",
+						},
+					},
+				},
 			},
 			"exec": {
 				Type:     schema.TypeList,
@@ -184,6 +334,7 @@ func Provider() *schema.Provider {
 					},
 				},
 			},
+			"clusters": clustersSchema(),
 			"ignore_annotations": {
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
@@ -202,6 +353,18 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "List of Kubernetes metadata labels to ignore across all resources handled by this provider for situations where external systems are managing certain resource labels. Each item is a regular expression.",
 			},
+			"default_annotations": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Map of Kubernetes metadata annotations to add to all resources handled by this provider. Annotations set on a resource take precedence.",
+			},
+			"default_labels": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Map of Kubernetes metadata labels to add to all resources handled by this provider. Labels set on a resource take precedence.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -220,13 +383,48 @@ func Provider() *schema.Provider {
 			"kubernetes_pod_v1":                     dataSourceKubernetesPod(),
 			"kubernetes_service_account":            dataSourceKubernetesServiceAccount(),
 			"kubernetes_service_account_v1":         dataSourceKubernetesServiceAccount(),
+			"kubernetes_token_request":              dataSourceKubernetesTokenRequest(),
 			"kubernetes_persistent_volume_claim":    dataSourceKubernetesPersistentVolumeClaim(),
 			"kubernetes_persistent_volume_claim_v1": dataSourceKubernetesPersistentVolumeClaim(),
+			"kubernetes_pod_template_v1":            dataSourceKubernetesPodTemplateV1(),
+
+			// apps
+			"kubernetes_deployment_v1":   dataSourceKubernetesDeploymentV1(),
+			"kubernetes_stateful_set_v1": dataSourceKubernetesStatefulSetV1(),
+			"kubernetes_daemon_set_v1":   dataSourceKubernetesDaemonSetV1(),
+
+			// batch
+			"kubernetes_job_v1":      dataSourceKubernetesJobV1(),
+			"kubernetes_cron_job_v1": dataSourceKubernetesCronJobV1(),
+
+			// rbac
+			"kubernetes_role_v1":                 dataSourceKubernetesRoleV1(),
+			"kubernetes_role_binding_v1":         dataSourceKubernetesRoleBindingV1(),
+			"kubernetes_cluster_role_v1":         dataSourceKubernetesClusterRoleV1(),
+			"kubernetes_cluster_role_binding_v1": dataSourceKubernetesClusterRoleBindingV1(),
+
+			// authorization
+			"kubernetes_access_review": dataSourceKubernetesAccessReview(),
+
+			// coordination
+			"kubernetes_lease_v1": dataSourceKubernetesLeaseV1(),
+
+			// discovery
+			"kubernetes_server_version":    dataSourceKubernetesServerVersion(),
+			"kubernetes_api_resources":     dataSourceKubernetesAPIResources(),
+			"kubernetes_endpoint_slice_v1": dataSourceKubernetesEndpointSliceV1(),
+
+			// flowcontrol
+			"kubernetes_flow_schema":                  dataSourceKubernetesFlowSchema(),
+			"kubernetes_priority_level_configuration": dataSourceKubernetesPriorityLevelConfiguration(),
 
 			// networking
 			"kubernetes_ingress":    dataSourceKubernetesIngress(),
 			"kubernetes_ingress_v1": dataSourceKubernetesIngressV1(),
 
+			// node
+			"kubernetes_runtime_class_v1": dataSourceKubernetesRuntimeClassV1(),
+
 			// storage
 			"kubernetes_storage_class":    dataSourceKubernetesStorageClass(),
 			"kubernetes_storage_class_v1": dataSourceKubernetesStorageClass(),
@@ -255,6 +453,7 @@ func Provider() *schema.Provider {
 			"kubernetes_endpoints":                  resourceKubernetesEndpoints(),
 			"kubernetes_endpoints_v1":               resourceKubernetesEndpoints(),
 			"kubernetes_env":                        resourceKubernetesEnv(),
+			"kubernetes_container_image":            resourceKubernetesContainerImage(),
 			"kubernetes_limit_range":                resourceKubernetesLimitRange(),
 			"kubernetes_limit_range_v1":             resourceKubernetesLimitRange(),
 			"kubernetes_persistent_volume":          resourceKubernetesPersistentVolume(),
@@ -265,6 +464,7 @@ func Provider() *schema.Provider {
 			"kubernetes_replication_controller_v1":  resourceKubernetesReplicationController(),
 			"kubernetes_resource_quota":             resourceKubernetesResourceQuota(),
 			"kubernetes_resource_quota_v1":          resourceKubernetesResourceQuota(),
+			"kubernetes_pod_template_v1":            resourceKubernetesPodTemplateV1(),
 
 			// api registration
 			"kubernetes_api_service":    resourceKubernetesAPIService(),
@@ -290,6 +490,9 @@ func Provider() *schema.Provider {
 			"kubernetes_horizontal_pod_autoscaler_v2beta2": resourceKubernetesHorizontalPodAutoscalerV2Beta2(),
 			"kubernetes_horizontal_pod_autoscaler_v2":      resourceKubernetesHorizontalPodAutoscalerV2(),
 
+			// coordination
+			"kubernetes_lease_v1": resourceKubernetesLeaseV1(),
+
 			// certificates
 			"kubernetes_certificate_signing_request":    resourceKubernetesCertificateSigningRequest(),
 			"kubernetes_certificate_signing_request_v1": resourceKubernetesCertificateSigningRequestV1(),
@@ -304,6 +507,13 @@ func Provider() *schema.Provider {
 			"kubernetes_cluster_role_binding":    resourceKubernetesClusterRoleBinding(),
 			"kubernetes_cluster_role_binding_v1": resourceKubernetesClusterRoleBinding(),
 
+			// discovery
+			"kubernetes_endpoint_slice_v1": resourceKubernetesEndpointSliceV1(),
+
+			// flowcontrol
+			"kubernetes_flow_schema":                  resourceKubernetesFlowSchema(),
+			"kubernetes_priority_level_configuration": resourceKubernetesPriorityLevelConfiguration(),
+
 			// networking
 			"kubernetes_ingress":           resourceKubernetesIngress(),
 			"kubernetes_ingress_v1":        resourceKubernetesIngressV1(),
@@ -318,15 +528,20 @@ func Provider() *schema.Provider {
 			"kubernetes_pod_security_policy":         resourceKubernetesPodSecurityPolicy(),
 			"kubernetes_pod_security_policy_v1beta1": resourceKubernetesPodSecurityPolicy(),
 
+			// node
+			"kubernetes_runtime_class_v1": resourceKubernetesRuntimeClassV1(),
+
 			// scheduling
 			"kubernetes_priority_class":    resourceKubernetesPriorityClass(),
 			"kubernetes_priority_class_v1": resourceKubernetesPriorityClass(),
 
 			// admission control
-			"kubernetes_validating_webhook_configuration":    resourceKubernetesValidatingWebhookConfiguration(),
-			"kubernetes_validating_webhook_configuration_v1": resourceKubernetesValidatingWebhookConfigurationV1(),
-			"kubernetes_mutating_webhook_configuration":      resourceKubernetesMutatingWebhookConfiguration(),
-			"kubernetes_mutating_webhook_configuration_v1":   resourceKubernetesMutatingWebhookConfigurationV1(),
+			"kubernetes_validating_webhook_configuration":       resourceKubernetesValidatingWebhookConfiguration(),
+			"kubernetes_validating_webhook_configuration_v1":    resourceKubernetesValidatingWebhookConfigurationV1(),
+			"kubernetes_mutating_webhook_configuration":         resourceKubernetesMutatingWebhookConfiguration(),
+			"kubernetes_mutating_webhook_configuration_v1":      resourceKubernetesMutatingWebhookConfigurationV1(),
+			"kubernetes_validating_admission_policy_v1":         resourceKubernetesValidatingAdmissionPolicyV1(),
+			"kubernetes_validating_admission_policy_binding_v1": resourceKubernetesValidatingAdmissionPolicyBindingV1(),
 
 			// storage
 			"kubernetes_storage_class":    resourceKubernetesStorageClass(),
@@ -337,9 +552,25 @@ func Provider() *schema.Provider {
 			// provider helper resources
 			"kubernetes_labels":      resourceKubernetesLabels(),
 			"kubernetes_annotations": resourceKubernetesAnnotations(),
+			"kubernetes_patch":       resourceKubernetesPatch(),
 		},
 	}
 
+	for name, r := range p.ResourcesMap {
+		if gk, ok := deletionPolicyKinds[name]; ok {
+			withDeletionPolicy(r, gk)
+		}
+		withDefaultNamespace(r, false)
+		withDefaultMetadata(r)
+		withClusterSelection(r, true)
+		withDeferredConfiguration(r, false)
+	}
+	for _, r := range p.DataSourcesMap {
+		withDefaultNamespace(r, true)
+		withClusterSelection(r, false)
+		withDeferredConfiguration(r, true)
+	}
+
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		return providerConfigure(ctx, d, p.TerraformVersion)
 	}
@@ -352,6 +583,7 @@ type KubeClientsets interface {
 	AggregatorClientset() (*aggregator.Clientset, error)
 	DynamicClient() (dynamic.Interface, error)
 	DiscoveryClient() (discovery.DiscoveryInterface, error)
+	RESTMapper() (apimeta.RESTMapper, error)
 }
 
 type kubeClientsets struct {
@@ -362,9 +594,21 @@ type kubeClientsets struct {
 	aggregatorClientset *aggregator.Clientset
 	dynamicClient       dynamic.Interface
 	discoveryClient     discovery.DiscoveryInterface
+	restMapper          *cachedRESTMapper
+
+	IgnoreAnnotations  []string
+	IgnoreLabels       []string
+	DefaultAnnotations map[string]string
+	DefaultLabels      map[string]string
+	// Namespace is used for namespaced objects that do not set one, see
+	// defaultNamespace.
+	Namespace string
+
+	clusters *clusterClientsets
 
-	IgnoreAnnotations []string
-	IgnoreLabels      []string
+	// configUnknown is set when the provider configuration is only known
+	// during apply. No clients are configured then.
+	configUnknown bool
 }
 
 func (k kubeClientsets) MainClientset() (*kubernetes.Clientset, error) {
@@ -426,11 +670,39 @@ func (k kubeClientsets) DiscoveryClient() (discovery.DiscoveryInterface, error)
 	return k.discoveryClient, nil
 }
 
+// cachedRESTMapper holds a REST mapper shared by all copies of the provider
+// meta, so the resources of the API server are only discovered once.
+type cachedRESTMapper struct {
+	mu     sync.Mutex
+	mapper apimeta.RESTMapper
+}
+
+// RESTMapper returns a mapper that discovers the resources of the API server
+// on first use. It discovers them again when a kind is not found, so kinds
+// added to the cluster by the same run are found as well.
+func (k kubeClientsets) RESTMapper() (apimeta.RESTMapper, error) {
+	c := k.restMapper
+	if c == nil {
+		c = &cachedRESTMapper{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mapper == nil {
+		dc, err := k.DiscoveryClient()
+		if err != nil {
+			return nil, err
+		}
+		c.mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+	}
+	return c.mapper, nil
+}
+
 func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	// Config initialization
-	cfg, err := initializeConfiguration(d)
+	cfg, namespace, clusters, err := initializeConfiguration(d)
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, configDiagnostics(err)
 	}
 	if cfg == nil {
 		// This is a TEMPORARY measure to work around https://github.com/hashicorp/terraform/issues/24055
@@ -440,14 +712,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 		cfg = &restclient.Config{}
 	}
 
-	cfg.UserAgent = fmt.Sprintf("HashiCorp/1.0 Terraform/%s", terraformVersion)
-
-	if logging.IsDebugOrHigher() {
-		log.Printf("[DEBUG] Enabling HTTP requests/responses tracing")
-		cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
-			return logging.NewTransport("Kubernetes", rt)
-		}
-	}
+	cfg.UserAgent = fmt.Sprintf("%s/1.0 Terraform/%s", typedResourceFieldManagerName, terraformVersion)
+	clusters.userAgent = cfg.UserAgent
 
 	ignoreAnnotations := []string{}
 	ignoreLabels := []string{}
@@ -458,121 +724,112 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 	if v, ok := d.Get("ignore_labels").([]interface{}); ok {
 		ignoreLabels = expandStringSlice(v)
 	}
+	defaultAnnotations := expandStringMap(d.Get("default_annotations").(map[string]interface{}))
+	defaultLabels := expandStringMap(d.Get("default_labels").(map[string]interface{}))
 
 	m := kubeClientsets{
 		config:              cfg,
 		mainClientset:       nil,
 		aggregatorClientset: nil,
+		restMapper:          &cachedRESTMapper{},
 		IgnoreAnnotations:   ignoreAnnotations,
 		IgnoreLabels:        ignoreLabels,
+		DefaultAnnotations:  defaultAnnotations,
+		DefaultLabels:       defaultLabels,
+		Namespace:           namespace,
+		clusters:            clusters,
 	}
 	return m, diag.Diagnostics{}
 }
 
-func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, error) {
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := &clientcmd.ClientConfigLoadingRules{}
-
-	configPaths := []string{}
-
-	if v, ok := d.Get("config_path").(string); ok && v != "" {
-		configPaths = []string{v}
-	} else if v, ok := d.Get("config_paths").([]interface{}); ok && len(v) > 0 {
-		for _, p := range v {
-			configPaths = append(configPaths, p.(string))
+// configDiagnostics returns a diagnostic per error of the resolved provider
+// configuration, pointing at the attribute that caused it.
+func configDiagnostics(err error) diag.Diagnostics {
+	errs, ok := err.(util.ConfigErrors)
+	if !ok {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	for _, e := range errs {
+		d := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid provider configuration",
+			Detail:   e.Error(),
 		}
-	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
-		// NOTE we have to do this here because the schema
-		// does not yet allow you to set a default for a TypeList
-		configPaths = filepath.SplitList(v)
-	}
-
-	if len(configPaths) > 0 {
-		expandedPaths := []string{}
-		for _, p := range configPaths {
-			path, err := homedir.Expand(p)
-			if err != nil {
-				return nil, err
+		var ae *util.AttributeError
+		if errors.As(e, &ae) {
+			for _, step := range ae.Path {
+				switch step := step.(type) {
+				case string:
+					d.AttributePath = d.AttributePath.GetAttr(step)
+				case int:
+					d.AttributePath = d.AttributePath.IndexInt(step)
+				}
 			}
-
-			log.Printf("[DEBUG] Using kubeconfig: %s", path)
-			expandedPaths = append(expandedPaths, path)
-		}
-
-		if len(expandedPaths) == 1 {
-			loader.ExplicitPath = expandedPaths[0]
-		} else {
-			loader.Precedence = expandedPaths
 		}
+		diags = append(diags, d)
+	}
+	return diags
+}
 
-		ctxSuffix := "; default context"
-
-		kubectx, ctxOk := d.GetOk("config_context")
-		authInfo, authInfoOk := d.GetOk("config_context_auth_info")
-		cluster, clusterOk := d.GetOk("config_context_cluster")
-		if ctxOk || authInfoOk || clusterOk {
-			ctxSuffix = "; overriden context"
-			if ctxOk {
-				overrides.CurrentContext = kubectx.(string)
-				ctxSuffix += fmt.Sprintf("; config ctx: %s", overrides.CurrentContext)
-				log.Printf("[DEBUG] Using custom current context: %q", overrides.CurrentContext)
-			}
+// attributeValue returns the value of a string attribute of the provider
+// block, or an empty string when it holds the value of envVar set by its
+// DefaultFunc. util.ResolveConfig then reads envVar itself, so that the
+// resolved configuration reports the environment as its source.
+func attributeValue(d *schema.ResourceData, key, envVar string) string {
+	v := d.Get(key).(string)
+	if v != "" && v == os.Getenv(envVar) {
+		return ""
+	}
+	return v
+}
 
-			overrides.Context = clientcmdapi.Context{}
-			if authInfoOk {
-				overrides.Context.AuthInfo = authInfo.(string)
-				ctxSuffix += fmt.Sprintf("; auth_info: %s", overrides.Context.AuthInfo)
-			}
-			if clusterOk {
-				overrides.Context.Cluster = cluster.(string)
-				ctxSuffix += fmt.Sprintf("; cluster: %s", overrides.Context.Cluster)
+// initializeConfiguration returns the client configuration of the provider,
+// the namespace of the namespaced resources that do not set one and the
+// clusters of the clusters block.
+func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, string, *clusterClientsets, error) {
+	c := util.ProviderConfig{
+		Host:                   attributeValue(d, "host", "KUBE_HOST"),
+		Username:               attributeValue(d, "username", "KUBE_USER"),
+		Password:               attributeValue(d, "password", "KUBE_PASSWORD"),
+		ClientCertificate:      attributeValue(d, "client_certificate", "KUBE_CLIENT_CERT_DATA"),
+		ClientKey:              attributeValue(d, "client_key", "KUBE_CLIENT_KEY_DATA"),
+		ClusterCACertificate:   attributeValue(d, "cluster_ca_certificate", "KUBE_CLUSTER_CA_CERT_DATA"),
+		ConfigPath:             attributeValue(d, "config_path", "KUBE_CONFIG_PATH"),
+		ConfigPaths:            expandStringSlice(d.Get("config_paths").([]interface{})),
+		ConfigRaw:              d.Get("config_raw").(string),
+		ConfigContext:          attributeValue(d, "config_context", "KUBE_CTX"),
+		ConfigContextAuthInfo:  attributeValue(d, "config_context_auth_info", "KUBE_CTX_AUTH_INFO"),
+		ConfigContextCluster:   attributeValue(d, "config_context_cluster", "KUBE_CTX_CLUSTER"),
+		Namespace:              d.Get("namespace").(string),
+		UseKubeconfigNamespace: d.Get("use_kubeconfig_namespace").(bool),
+		Token:                  attributeValue(d, "token", "KUBE_TOKEN"),
+		ProxyURL:               attributeValue(d, "proxy_url", "KUBE_PROXY_URL"),
+		TLSServerName:          d.Get("tls_server_name").(string),
+		QPS:                    d.Get("qps").(float64),
+		Burst:                  d.Get("burst").(int),
+		RequestTimeout:         d.Get("request_timeout").(string),
+	}
+	if v, ok := d.GetOk("retry"); ok {
+		c.Retry = &util.RetryConfig{}
+		if spec, ok := v.([]interface{})[0].(map[string]interface{}); ok {
+			c.Retry.MaxAttempts = spec["max_attempts"].(int)
+			c.Retry.MinBackoff = spec["min_backoff"].(string)
+			c.Retry.MaxBackoff = spec["max_backoff"].(string)
+			for _, code := range spec["status_codes"].([]interface{}) {
+				c.Retry.StatusCodes = append(c.Retry.StatusCodes, code.(int))
 			}
-			log.Printf("[DEBUG] Using overidden context: %#v", overrides.Context)
 		}
 	}
-
-	// Overriding with static configuration
-	if v, ok := d.GetOk("insecure"); ok {
-		overrides.ClusterInfo.InsecureSkipTLSVerify = v.(bool)
-	}
-	if v, ok := d.GetOk("cluster_ca_certificate"); ok {
-		overrides.ClusterInfo.CertificateAuthorityData = bytes.NewBufferString(v.(string)).Bytes()
-	}
-	if v, ok := d.GetOk("client_certificate"); ok {
-		overrides.AuthInfo.ClientCertificateData = bytes.NewBufferString(v.(string)).Bytes()
-	}
-	if v, ok := d.GetOk("host"); ok {
-		// Server has to be the complete address of the kubernetes cluster (scheme://hostname:port), not just the hostname,
-		// because `overrides` are processed too late to be taken into account by `defaultServerUrlFor()`.
-		// This basically replicates what defaultServerUrlFor() does with config but for overrides,
-		// see https://github.com/kubernetes/client-go/blob/v12.0.0/rest/url_utils.go#L85-L87
-		hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
-		hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
-		defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
-		host, _, err := restclient.DefaultServerURL(v.(string), "", apimachineryschema.GroupVersion{}, defaultTLS)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse host: %s", err)
+	if v, ok := d.GetOkExists("insecure"); ok {
+		insecure := v.(bool)
+		if env, err := strconv.ParseBool(os.Getenv("KUBE_INSECURE")); err != nil || env != insecure {
+			c.Insecure = &insecure
 		}
-
-		overrides.ClusterInfo.Server = host.String()
-	}
-	if v, ok := d.GetOk("username"); ok {
-		overrides.AuthInfo.Username = v.(string)
 	}
-	if v, ok := d.GetOk("password"); ok {
-		overrides.AuthInfo.Password = v.(string)
-	}
-	if v, ok := d.GetOk("client_key"); ok {
-		overrides.AuthInfo.ClientKeyData = bytes.NewBufferString(v.(string)).Bytes()
-	}
-	if v, ok := d.GetOk("token"); ok {
-		overrides.AuthInfo.Token = v.(string)
-	}
-
 	if v, ok := d.GetOk("exec"); ok {
 		exec := &clientcmdapi.ExecConfig{}
 		if spec, ok := v.([]interface{})[0].(map[string]interface{}); ok {
-			exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
 			exec.APIVersion = spec["api_version"].(string)
 			exec.Command = spec["command"].(string)
 			exec.Args = expandStringSlice(spec["args"].([]interface{}))
@@ -580,33 +837,75 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, error)
 				exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: vv.(string)})
 			}
 		} else {
-			return nil, fmt.Errorf("Failed to parse exec")
+			return nil, "", nil, fmt.Errorf("Failed to parse exec")
 		}
-		overrides.AuthInfo.Exec = exec
+		c.Exec = exec
 	}
 
-	if v, ok := d.GetOk("proxy_url"); ok {
-		overrides.ClusterDefaults.ProxyURL = v.(string)
+	if v, ok := d.GetOk("eks"); ok {
+		if spec, ok := v.([]interface{})[0].(map[string]interface{}); ok {
+			c.EKS = &util.EKSAuthConfig{
+				ClusterName: spec["cluster_name"].(string),
+				Region:      spec["region"].(string),
+				RoleARN:     spec["role_arn"].(string),
+				STSEndpoint: spec["sts_endpoint"].(string),
+			}
+		}
+	}
+	if v, ok := d.GetOk("oidc"); ok {
+		if spec, ok := v.([]interface{})[0].(map[string]interface{}); ok {
+			c.OIDC = &util.OIDCAuthConfig{
+				TokenURL:     spec["token_url"].(string),
+				ClientID:     spec["client_id"].(string),
+				ClientSecret: spec["client_secret"].(string),
+				RefreshToken: spec["refresh_token"].(string),
+				Scopes:       expandStringSlice(spec["scopes"].([]interface{})),
+				UseIDToken:   spec["use_id_token"].(bool),
+			}
+		}
 	}
 
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
-	cfg, err := cc.ClientConfig()
+	resolved, err := util.ResolveConfig(c)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	clusterConfigs, err := resolved.ResolveClusters(expandProviderClusters(d))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	var wrappers []transport.WrapperFunc
+	if logging.IsDebugOrHigher() {
+		log.Printf("[DEBUG] Enabling HTTP requests/responses tracing")
+		wrappers = append(wrappers, func(rt http.RoundTripper) http.RoundTripper {
+			return logging.NewTransport("Kubernetes", rt)
+		})
+	}
+	clusters := &clusterClientsets{
+		configs:    clusterConfigs,
+		wrappers:   wrappers,
+		clientsets: map[string]kubeClientsets{},
+	}
+	cfg, err := resolved.ClientConfig(wrappers...)
 	if err != nil {
 		log.Printf("[WARN] Invalid provider configuration was supplied. Provider operations likely to fail: %v", err)
-		return nil, nil
+		return nil, resolved.Namespace(), clusters, nil
 	}
+	log.Printf("[DEBUG] Resolved provider configuration:\n%s", resolved.Report)
 
-	return cfg, nil
+	return cfg, resolved.Namespace(), clusters, nil
 }
 
-var useadmissionregistrationv1beta1 *bool
+// admissionregistrationV1beta1 caches the result of useAdmissionregistrationV1beta1
+// by API server URL, as resources of different clusters can be managed by the
+// same provider and the clientsets are not kept across calls.
+var admissionregistrationV1beta1 sync.Map
 
 func useAdmissionregistrationV1beta1(conn *kubernetes.Clientset) (bool, error) {
-	if useadmissionregistrationv1beta1 != nil {
-		return *useadmissionregistrationv1beta1, nil
-	}
-
 	d := conn.Discovery()
+	server := d.RESTClient().Get().URL().String()
+	if v, ok := admissionregistrationV1beta1.Load(server); ok {
+		return v.(bool), nil
+	}
 
 	group := "admissionregistration.k8s.io"
 
@@ -618,7 +917,7 @@ func useAdmissionregistrationV1beta1(conn *kubernetes.Clientset) (bool, error) {
 	err = discovery.ServerSupportsVersion(d, v1)
 	if err == nil {
 		log.Printf("[INFO] Using %s/v1", group)
-		useadmissionregistrationv1beta1 = ptrToBool(false)
+		admissionregistrationV1beta1.Store(server, false)
 		return false, nil
 	}
 
@@ -633,10 +932,42 @@ func useAdmissionregistrationV1beta1(conn *kubernetes.Clientset) (bool, error) {
 	}
 
 	log.Printf("[INFO] Using %s/v1beta1", group)
-	useadmissionregistrationv1beta1 = ptrToBool(true)
+	admissionregistrationV1beta1.Store(server, true)
 	return true, nil
 }
 
+// flowcontrolVersions are the versions of the flowcontrol.apiserver.k8s.io
+// API that the provider supports, from the most preferred.
+var flowcontrolVersions = []string{"v1", "v1beta3", "v1beta2", "v1beta1"}
+
+// flowcontrolGroupVersion returns the most preferred version of the API
+// Priority and Fairness API served by the cluster. It is not cached, as
+// resources of different clusters can be managed by the same provider.
+func flowcontrolGroupVersion(d discovery.DiscoveryInterface) (apimachineryschema.GroupVersion, error) {
+	group := "flowcontrol.apiserver.k8s.io"
+
+	groups, err := d.ServerGroups()
+	if err != nil {
+		return apimachineryschema.GroupVersion{}, err
+	}
+	served := map[string]bool{}
+	for _, g := range groups.Groups {
+		if g.Name != group {
+			continue
+		}
+		for _, v := range g.Versions {
+			served[v.Version] = true
+		}
+	}
+	for _, v := range flowcontrolVersions {
+		if served[v] {
+			log.Printf("[INFO] Using %s/%s", group, v)
+			return apimachineryschema.GroupVersion{Group: group, Version: v}, nil
+		}
+	}
+	return apimachineryschema.GroupVersion{}, fmt.Errorf("The cluster does not serve any of the versions %s of the %s API", strings.Join(flowcontrolVersions, ", "), group)
+}
+
 func getServerVersion(connection *kubernetes.Clientset) (*gversion.Version, error) {
 	sv, err := connection.ServerVersion()
 	if err != nil {
@@ -0,0 +1,282 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/transport"
+	aggregator "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
+)
+
+// clusterImportSeparator separates the cluster name from the ID of the
+// resource when importing a resource into a cluster of the clusters block,
+// as in "<cluster>@<id>".
+const clusterImportSeparator = "@"
+
+func clustersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Additional clusters that resources can select with their `cluster` attribute. Environment variables and the exec, eks and oidc blocks only apply to the cluster configured at the top level of the provider block.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name used to select the cluster in the `cluster` attribute of resources and data sources.",
+				},
+				"host": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The hostname (in form of URI) of Kubernetes master.",
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The username to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
+				},
+				"password": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The password to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
+				},
+				"insecure": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether server should be accessed without verifying the TLS certificate.",
+				},
+				"client_certificate": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM-encoded client certificate for TLS authentication.",
+				},
+				"client_key": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "PEM-encoded client certificate key for TLS authentication.",
+				},
+				"cluster_ca_certificate": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM-encoded root certificates bundle for TLS authentication.",
+				},
+				"config_path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path to the kube config file.",
+				},
+				"config_context": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Context to use from the kube config file.",
+				},
+				"config_context_auth_info": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "User to use from the kube config file.",
+				},
+				"config_context_cluster": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Cluster to use from the kube config file.",
+				},
+				"token": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Token to authenticate an service account",
+				},
+				"proxy_url": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "URL to the proxy to be used for all API requests",
+				},
+				"tls_server_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Server name used to verify the TLS certificate of the Kubernetes API server.",
+				},
+			},
+		},
+	}
+}
+
+func expandProviderClusters(d *schema.ResourceData) []util.ClusterConfig {
+	in := d.Get("clusters").([]interface{})
+	clusters := make([]util.ClusterConfig, 0, len(in))
+	for i, v := range in {
+		spec, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := util.ClusterConfig{
+			Name: spec["name"].(string),
+			ProviderConfig: util.ProviderConfig{
+				Host:                  spec["host"].(string),
+				Username:              spec["username"].(string),
+				Password:              spec["password"].(string),
+				ClientCertificate:     spec["client_certificate"].(string),
+				ClientKey:             spec["client_key"].(string),
+				ClusterCACertificate:  spec["cluster_ca_certificate"].(string),
+				ConfigPath:            spec["config_path"].(string),
+				ConfigContext:         spec["config_context"].(string),
+				ConfigContextAuthInfo: spec["config_context_auth_info"].(string),
+				ConfigContextCluster:  spec["config_context_cluster"].(string),
+				Token:                 spec["token"].(string),
+				ProxyURL:              spec["proxy_url"].(string),
+				TLSServerName:         spec["tls_server_name"].(string),
+			},
+		}
+		// An explicit false overrides insecure-skip-tls-verify of the kubeconfig.
+		if v, ok := d.GetOkExists(fmt.Sprintf("clusters.%d.insecure", i)); ok {
+			insecure := v.(bool)
+			c.Insecure = &insecure
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters
+}
+
+// clusterClientsets holds the clients of the clusters block. They are shared
+// by all copies of the provider meta and only created when a resource first
+// selects their cluster.
+type clusterClientsets struct {
+	mu         sync.Mutex
+	configs    map[string]*util.ResolvedConfig
+	wrappers   []transport.WrapperFunc
+	userAgent  string
+	clientsets map[string]kubeClientsets
+}
+
+func (k kubeClientsets) forCluster(name string) (kubeClientsets, error) {
+	c := k.clusters
+	if c == nil {
+		return kubeClientsets{}, fmt.Errorf("Cluster %q is not defined in the clusters block of the provider", name)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cs, ok := c.clientsets[name]; ok {
+		return cs, nil
+	}
+	resolved, ok := c.configs[name]
+	if !ok {
+		return kubeClientsets{}, fmt.Errorf("Cluster %q is not defined in the clusters block of the provider", name)
+	}
+	cfg, err := resolved.ClientConfig(c.wrappers...)
+	if err != nil {
+		return kubeClientsets{}, fmt.Errorf("Failed to configure cluster %q: %s", name, err)
+	}
+	cfg.UserAgent = c.userAgent
+
+	cs := kubeClientsets{
+		config:             cfg,
+		restMapper:         &cachedRESTMapper{},
+		IgnoreAnnotations:  k.IgnoreAnnotations,
+		IgnoreLabels:       k.IgnoreLabels,
+		DefaultAnnotations: k.DefaultAnnotations,
+		DefaultLabels:      k.DefaultLabels,
+		Namespace:          resolved.Namespace(),
+		clusters:           c,
+	}
+	// The clients are created here once, as kubeClientsets is passed by value
+	// and cannot cache them itself.
+	if cs.mainClientset, err = kubernetes.NewForConfig(cfg); err != nil {
+		return kubeClientsets{}, fmt.Errorf("Failed to configure client for cluster %q: %s", name, err)
+	}
+	if cs.aggregatorClientset, err = aggregator.NewForConfig(cfg); err != nil {
+		return kubeClientsets{}, fmt.Errorf("Failed to configure client for cluster %q: %s", name, err)
+	}
+	if cs.dynamicClient, err = dynamic.NewForConfig(cfg); err != nil {
+		return kubeClientsets{}, fmt.Errorf("Failed to configure dynamic client for cluster %q: %s", name, err)
+	}
+	if cs.discoveryClient, err = discovery.NewDiscoveryClientForConfig(cfg); err != nil {
+		return kubeClientsets{}, fmt.Errorf("Failed to configure discovery client for cluster %q: %s", name, err)
+	}
+	c.clientsets[name] = cs
+	return cs, nil
+}
+
+// clusterMeta returns the provider meta for the named cluster. An empty
+// name selects the cluster configured at the top level of the provider block.
+func clusterMeta(name string, meta interface{}) (interface{}, error) {
+	if k, ok := meta.(kubeClientsets); ok && k.configUnknown {
+		return nil, errConfigUnknown
+	}
+	if name == "" {
+		return meta, nil
+	}
+	k, ok := meta.(kubeClientsets)
+	if !ok {
+		return nil, fmt.Errorf("Cluster %q is not defined in the clusters block of the provider", name)
+	}
+	return k.forCluster(name)
+}
+
+// withClusterSelection adds the cluster attribute to r and makes all of its
+// operations use the clients of the selected cluster.
+func withClusterSelection(r *schema.Resource, forceNew bool) {
+	if _, ok := r.Schema["cluster"]; ok {
+		return
+	}
+	r.Schema["cluster"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    forceNew,
+		Description: "Name of the cluster from the clusters block of the provider. Defaults to the cluster configured at the top level of the provider block.",
+	}
+
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			m, err := clusterMeta(d.Get("cluster").(string), meta)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return f(ctx, d, m)
+		}
+	}
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	if f := r.CustomizeDiff; f != nil {
+		r.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			m, err := clusterMeta(diff.Get("cluster").(string), meta)
+			if err != nil {
+				return err
+			}
+			return f(ctx, diff, m)
+		}
+	}
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		f := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if cluster, id, ok := strings.Cut(d.Id(), clusterImportSeparator); ok {
+				d.SetId(id)
+				d.Set("cluster", cluster)
+			}
+			m, err := clusterMeta(d.Get("cluster").(string), meta)
+			if err != nil {
+				return nil, err
+			}
+			return f(ctx, d, m)
+		}
+	}
+}
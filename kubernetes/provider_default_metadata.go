@@ -0,0 +1,185 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withDefaultMetadata adds the default_labels and default_annotations
+// attributes to r. They hold the defaults of the provider that apply to the
+// resource and are planned like any other attribute, so changing a default
+// shows a diff and updates the resource. expandResourceMetadata and
+// patchMetadata merge them into the metadata sent to the API server, while
+// the metadata in the state only holds the values set on the resource.
+func withDefaultMetadata(r *schema.Resource) {
+	if !hasMetadataLabelsAndAnnotations(r) || r.CreateContext == nil {
+		return
+	}
+	r.Schema["default_annotations"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Annotations from the `default_annotations` of the provider that are added to the resource. Annotations set in the metadata of the resource take precedence.",
+	}
+	r.Schema["default_labels"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Labels from the `default_labels` of the provider that are added to the resource. Labels set in the metadata of the resource take precedence.",
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, diff, meta); err != nil {
+				return err
+			}
+		}
+		return planDefaultMetadata(diff, meta)
+	}
+
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		// The plan does not hold the defaults when the configuration of the
+		// provider was not known yet.
+		if err := setDefaultMetadata(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+		return create(ctx, d, meta)
+	}
+
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := read(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		if err := stripDefaultMetadata(d); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		return diags
+	}
+}
+
+func hasMetadataLabelsAndAnnotations(r *schema.Resource) bool {
+	s, ok := r.Schema["metadata"]
+	if !ok {
+		return false
+	}
+	elem, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return false
+	}
+	_, hasLabels := elem.Schema["labels"]
+	_, hasAnnotations := elem.Schema["annotations"]
+	return hasLabels && hasAnnotations
+}
+
+// defaultMetadata returns the default labels and annotations of the provider
+// that apply to a resource with the given labels and annotations.
+func defaultMetadata(meta interface{}, labels, annotations map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	k, _ := meta.(kubeClientsets)
+	return unsetDefaultMetadata(labels, k.DefaultLabels), unsetDefaultMetadata(annotations, k.DefaultAnnotations)
+}
+
+func planDefaultMetadata(diff *schema.ResourceDiff, meta interface{}) error {
+	labels, _ := diff.Get("metadata.0.labels").(map[string]interface{})
+	annotations, _ := diff.Get("metadata.0.annotations").(map[string]interface{})
+	defaultLabels, defaultAnnotations := defaultMetadata(meta, labels, annotations)
+
+	for key, planned := range map[string]map[string]interface{}{
+		"default_labels":      defaultLabels,
+		"default_annotations": defaultAnnotations,
+	} {
+		old, _ := diff.Get(key).(map[string]interface{})
+		if diff.Id() != "" && sameMetadata(old, planned) {
+			continue
+		}
+		if err := diff.SetNew(key, planned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setDefaultMetadata(d *schema.ResourceData, meta interface{}) error {
+	labels, _ := d.Get("metadata.0.labels").(map[string]interface{})
+	annotations, _ := d.Get("metadata.0.annotations").(map[string]interface{})
+	defaultLabels, defaultAnnotations := defaultMetadata(meta, labels, annotations)
+	if err := d.Set("default_labels", defaultLabels); err != nil {
+		return err
+	}
+	return d.Set("default_annotations", defaultAnnotations)
+}
+
+// stripDefaultMetadata removes the defaults applied to the resource from
+// the metadata read back from the API server.
+func stripDefaultMetadata(d *schema.ResourceData) error {
+	defaultLabels, _ := d.Get("default_labels").(map[string]interface{})
+	defaultAnnotations, _ := d.Get("default_annotations").(map[string]interface{})
+	if len(defaultLabels) == 0 && len(defaultAnnotations) == 0 {
+		return nil
+	}
+
+	metadata, ok := d.Get("metadata").([]interface{})
+	if !ok || len(metadata) == 0 || metadata[0] == nil {
+		return nil
+	}
+	m := make(map[string]interface{})
+	for k, v := range metadata[0].(map[string]interface{}) {
+		m[k] = v
+	}
+	labels, _ := m["labels"].(map[string]interface{})
+	annotations, _ := m["annotations"].(map[string]interface{})
+	m["labels"] = removeDefaultMetadata(labels, defaultLabels)
+	m["annotations"] = removeDefaultMetadata(annotations, defaultAnnotations)
+	return d.Set("metadata", []interface{}{m})
+}
+
+// unsetDefaultMetadata returns the defaults whose keys are not set in values.
+func unsetDefaultMetadata(values map[string]interface{}, defaults map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(defaults))
+	for k, v := range defaults {
+		if _, ok := values[k]; !ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// mergeDefaultMetadata returns the values of the resource merged over the
+// defaults applied to it.
+func mergeDefaultMetadata(values, defaults map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(values)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	return merged
+}
+
+// removeDefaultMetadata removes the keys that hold the value of a default
+// from values. A value changed outside of Terraform is kept, so that it
+// shows up as a diff.
+func removeDefaultMetadata(values, defaults map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		if dv, ok := defaults[k]; ok && v == dv {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func sameMetadata(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
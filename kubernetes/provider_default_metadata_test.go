@@ -0,0 +1,156 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergeDefaultMetadata(t *testing.T) {
+	defaults := map[string]interface{}{
+		"app.kubernetes.io/managed-by": "terraform",
+		"team":                         "platform",
+	}
+	values := map[string]interface{}{
+		"team": "apps",
+		"app":  "api",
+	}
+	expected := map[string]interface{}{
+		"app.kubernetes.io/managed-by": "terraform",
+		"team":                         "apps",
+		"app":                          "api",
+	}
+	merged := mergeDefaultMetadata(values, defaults)
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("expected %v, got %v", expected, merged)
+	}
+
+	applied := unsetDefaultMetadata(values, map[string]string{"app.kubernetes.io/managed-by": "terraform", "team": "platform"})
+	expected = map[string]interface{}{"app.kubernetes.io/managed-by": "terraform"}
+	if !reflect.DeepEqual(applied, expected) {
+		t.Fatalf("expected %v, got %v", expected, applied)
+	}
+	if removed := removeDefaultMetadata(merged, applied); !reflect.DeepEqual(removed, values) {
+		t.Fatalf("expected %v, got %v", values, removed)
+	}
+}
+
+func TestRemoveDefaultMetadata(t *testing.T) {
+	defaults := map[string]interface{}{"team": "platform"}
+	cases := []struct {
+		Values   map[string]interface{}
+		Expected map[string]interface{}
+	}{
+		{
+			map[string]interface{}{"team": "platform", "app": "api"},
+			map[string]interface{}{"app": "api"},
+		},
+		{
+			// a value changed outside of Terraform is kept to show the diff
+			map[string]interface{}{"team": "apps"},
+			map[string]interface{}{"team": "apps"},
+		},
+	}
+	for _, tc := range cases {
+		removed := removeDefaultMetadata(tc.Values, defaults)
+		if !reflect.DeepEqual(removed, tc.Expected) {
+			t.Errorf("%v: expected %v, got %v", tc.Values, tc.Expected, removed)
+		}
+	}
+}
+
+func testDefaultMetadataResource(created *metav1.ObjectMeta, patched *PatchOperations) *schema.Resource {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("test", true),
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			*created = expandResourceMetadata(d)
+			d.SetId("test")
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			*patched = patchMetadata("metadata.0.", "/metadata/", d)
+			return nil
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	}
+	withDefaultMetadata(r)
+	return r
+}
+
+func TestWithDefaultMetadata(t *testing.T) {
+	var created metav1.ObjectMeta
+	var patched PatchOperations
+	r := testDefaultMetadataResource(&created, &patched)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":   "test",
+			"labels": map[string]interface{}{"app": "api", "team": "apps"},
+		}},
+	})
+	ctx := context.Background()
+
+	meta := kubeClientsets{DefaultLabels: map[string]string{"team": "platform", "tier": "backend"}}
+	diff, err := r.Diff(ctx, nil, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attr := diff.Attributes["default_labels.tier"]; attr == nil || attr.New != "backend" {
+		t.Fatalf("expected the default label in the plan, got %#v", diff.Attributes)
+	}
+	if attr := diff.Attributes["default_labels.team"]; attr != nil {
+		t.Fatalf("expected the label of the resource to take precedence, got %#v", attr)
+	}
+	state, diags := r.Apply(ctx, nil, diff, meta)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	expected := map[string]string{"app": "api", "team": "apps", "tier": "backend"}
+	if !reflect.DeepEqual(created.Labels, expected) {
+		t.Fatalf("expected the object to be created with %v, got %v", expected, created.Labels)
+	}
+	if state.Attributes["metadata.0.labels.%"] != "2" {
+		t.Fatalf("expected only the labels of the resource in the metadata, got %v", state.Attributes)
+	}
+
+	// Changing a default shows a diff and patches the object.
+	meta = kubeClientsets{DefaultLabels: map[string]string{"team": "platform", "tier": "frontend"}}
+	diff, err = r.Diff(ctx, state, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attr := diff.Attributes["default_labels.tier"]; attr == nil || attr.Old != "backend" || attr.New != "frontend" {
+		t.Fatalf("expected the changed default in the plan, got %#v", diff.Attributes)
+	}
+	if _, diags = r.Apply(ctx, state, diff, meta); diags.HasError() {
+		t.Fatal(diags)
+	}
+	expectedOps := PatchOperations{&ReplaceOperation{Path: "/metadata/labels/tier", Value: "frontend"}}
+	if !reflect.DeepEqual(patched, expectedOps) {
+		t.Fatalf("expected %v, got %v", expectedOps, patched)
+	}
+
+	// Removing a default removes it from the object.
+	diff, err = r.Diff(ctx, state, config, kubeClientsets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, diags = r.Apply(ctx, state, diff, kubeClientsets{}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	expectedOps = PatchOperations{&RemoveOperation{Path: "/metadata/labels/tier"}}
+	if !reflect.DeepEqual(patched, expectedOps) {
+		t.Fatalf("expected %v, got %v", expectedOps, patched)
+	}
+}
@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultNamespace returns the namespace of the provider, which is used for
+// namespaced objects that do not set one.
+func defaultNamespace(m interface{}) string {
+	if k, ok := m.(kubeClientsets); ok && k.Namespace != "" {
+		return k.Namespace
+	}
+	return "default"
+}
+
+// withDefaultNamespace sets the namespace of the provider in the metadata of
+// r when it is not configured, before the object is created or, for data
+// sources, read. Resources also accept a bare name as import ID.
+func withDefaultNamespace(r *schema.Resource, isDataSource bool) {
+	if !hasComputedNamespace(r) {
+		return
+	}
+
+	if isDataSource {
+		read := r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := setDefaultNamespace(d, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return read(ctx, d, meta)
+		}
+		return
+	}
+
+	if r.CreateContext != nil {
+		create := r.CreateContext
+		r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := setDefaultNamespace(d, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return create(ctx, d, meta)
+		}
+	}
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if !strings.Contains(d.Id(), "/") {
+				d.SetId(defaultNamespace(meta) + "/" + d.Id())
+			}
+			return importState(ctx, d, meta)
+		}
+	}
+}
+
+// hasComputedNamespace reports whether the metadata of r has a namespace
+// that falls back to the namespace of the provider.
+func hasComputedNamespace(r *schema.Resource) bool {
+	s, ok := r.Schema["metadata"]
+	if !ok {
+		return false
+	}
+	elem, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return false
+	}
+	ns, ok := elem.Schema["namespace"]
+	return ok && ns.Optional && ns.Computed
+}
+
+func setDefaultNamespace(d *schema.ResourceData, meta interface{}) error {
+	metadata, ok := d.Get("metadata").([]interface{})
+	if !ok || len(metadata) == 0 || metadata[0] == nil {
+		return nil
+	}
+	m := make(map[string]interface{})
+	for k, v := range metadata[0].(map[string]interface{}) {
+		m[k] = v
+	}
+	if ns, _ := m["namespace"].(string); ns != "" {
+		return nil
+	}
+	m["namespace"] = defaultNamespace(meta)
+	return d.Set("metadata", []interface{}{m})
+}
+
+var namespacePath = cty.GetAttrPath("metadata").IndexInt(0).GetAttr("namespace")
+
+// planNamespace plans the namespace that resources without a configured
+// namespace are created in. The SDK plans optional computed attributes as
+// unknown, which would keep the namespace from being used in for_each or in
+// the configuration of other resources. Replaced objects keep their namespace.
+func (s *deferredConfigServer) planNamespace(req *tfprotov5.PlanResourceChangeRequest, resp *tfprotov5.PlanResourceChangeResponse) (*tfprotov5.PlanResourceChangeResponse, error) {
+	if resp.PlannedState == nil || req.Config == nil || req.PriorState == nil {
+		return resp, nil
+	}
+	r, ok := s.provider.ResourcesMap[req.TypeName]
+	if !ok || !hasComputedNamespace(r) {
+		return resp, nil
+	}
+
+	ty := r.CoreConfigSchema().ImpliedType()
+	planned, err := msgpack.Unmarshal(resp.PlannedState.MsgPack, ty)
+	if err != nil {
+		return resp, err
+	}
+	config, err := msgpack.Unmarshal(req.Config.MsgPack, ty)
+	if err != nil {
+		return resp, err
+	}
+	prior, err := msgpack.Unmarshal(req.PriorState.MsgPack, ty)
+	if err != nil {
+		return resp, err
+	}
+	cluster := config.GetAttr("cluster")
+	if planned.IsNull() || !cluster.IsKnown() {
+		return resp, nil
+	}
+	var clusterName string
+	if !cluster.IsNull() {
+		clusterName = cluster.AsString()
+	}
+	meta, err := clusterMeta(clusterName, s.provider.Meta())
+	if err != nil {
+		// the namespace is left unknown, and the error is reported during apply
+		return resp, nil
+	}
+
+	planned, err = planDefaultNamespace(planned, prior, config, defaultNamespace(meta))
+	if err != nil {
+		return resp, err
+	}
+	b, err := msgpack.Marshal(planned, ty)
+	if err != nil {
+		return resp, err
+	}
+	resp.PlannedState = &tfprotov5.DynamicValue{MsgPack: b}
+	return resp, nil
+}
+
+// planDefaultNamespace replaces an unknown planned namespace that is not
+// configured with the namespace of the prior object, or with namespace for
+// new objects.
+func planDefaultNamespace(planned, prior, config cty.Value, namespace string) (cty.Value, error) {
+	if v, err := namespacePath.Apply(planned); err != nil || v.IsKnown() {
+		return planned, nil
+	}
+	if v, err := namespacePath.Apply(config); err != nil || !v.IsNull() {
+		return planned, nil
+	}
+	if !prior.IsNull() {
+		if v, err := namespacePath.Apply(prior); err == nil && v.IsKnown() && !v.IsNull() && v.AsString() != "" {
+			namespace = v.AsString()
+		}
+	}
+	return cty.Transform(planned, func(p cty.Path, v cty.Value) (cty.Value, error) {
+		if p.Equals(namespacePath) {
+			return cty.StringVal(namespace), nil
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,203 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWithDefaultNamespace(t *testing.T) {
+	var created string
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("test", false),
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			created = d.Get("metadata.0.namespace").(string)
+			d.SetId(buildId(expandResourceMetadata(d)))
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+	withDefaultNamespace(r, false)
+	meta := kubeClientsets{Namespace: "team-a"}
+
+	cases := []struct {
+		Namespace string
+		Expected  string
+	}{
+		{"", "team-a"},
+		{"kube-system", "kube-system"},
+	}
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{
+				"name":      "test",
+				"namespace": tc.Namespace,
+			}},
+		})
+		if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if created != tc.Expected {
+			t.Errorf("expected the object to be created in %q, got %q", tc.Expected, created)
+		}
+		if id := d.Id(); id != tc.Expected+"/test" {
+			t.Errorf("expected ID %q, got %q", tc.Expected+"/test", id)
+		}
+	}
+
+	for id, expected := range map[string]string{
+		"test":             "team-a/test",
+		"kube-system/test": "kube-system/test",
+	} {
+		d := r.Data(nil)
+		d.SetId(id)
+		if _, err := r.Importer.StateContext(context.Background(), d, meta); err != nil {
+			t.Fatal(err)
+		}
+		if d.Id() != expected {
+			t.Errorf("expected import ID %q to become %q, got %q", id, expected, d.Id())
+		}
+	}
+}
+
+func TestDefaultNamespace(t *testing.T) {
+	if ns := defaultNamespace(kubeClientsets{}); ns != "default" {
+		t.Errorf("expected %q, got %q", "default", ns)
+	}
+	if ns := defaultNamespace(kubeClientsets{Namespace: "team-a"}); ns != "team-a" {
+		t.Errorf("expected %q, got %q", "team-a", ns)
+	}
+}
+
+// nullObject returns an object of type ty with the given attributes, and all
+// other attributes null.
+func nullObject(ty cty.Type, attrs map[string]cty.Value) cty.Value {
+	vals := map[string]cty.Value{}
+	for name, at := range ty.AttributeTypes() {
+		if v, ok := attrs[name]; ok {
+			vals[name] = v
+			continue
+		}
+		vals[name] = cty.NullVal(at)
+	}
+	return cty.ObjectVal(vals)
+}
+
+func TestProviderServer_planDefaultNamespace(t *testing.T) {
+	s := ProviderServer().(*deferredConfigServer)
+	pty := schema.InternalMap(s.provider.Schema).CoreConfigSchema().ImpliedType()
+	pcfg, err := msgpack.Marshal(nullObject(pty, map[string]cty.Value{
+		"host":      cty.StringVal("https://example.com"),
+		"namespace": cty.StringVal("team-a"),
+	}), pty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cresp, err := s.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+		Config: &tfprotov5.DynamicValue{MsgPack: pcfg},
+	})
+	if err != nil || len(cresp.Diagnostics) > 0 {
+		t.Fatalf("failed to configure provider: %v %v", err, cresp.Diagnostics)
+	}
+
+	ty := s.provider.ResourcesMap["kubernetes_config_map_v1"].CoreConfigSchema().ImpliedType()
+	metaTy := ty.AttributeType("metadata").ElementType()
+	config := func(namespace cty.Value) cty.Value {
+		return nullObject(ty, map[string]cty.Value{
+			"metadata": cty.ListVal([]cty.Value{nullObject(metaTy, map[string]cty.Value{
+				"name":      cty.StringVal("test"),
+				"namespace": namespace,
+			})}),
+		})
+	}
+	plan := func(prior, config cty.Value) string {
+		encode := func(v cty.Value) *tfprotov5.DynamicValue {
+			b, err := msgpack.Marshal(v, ty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &tfprotov5.DynamicValue{MsgPack: b}
+		}
+		resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "kubernetes_config_map_v1",
+			PriorState:       encode(prior),
+			ProposedNewState: encode(config),
+			Config:           encode(config),
+		})
+		if err != nil || len(resp.Diagnostics) > 0 {
+			t.Fatalf("failed to plan: %v %v", err, resp.Diagnostics)
+		}
+		planned, err := msgpack.Unmarshal(resp.PlannedState.MsgPack, ty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ns, err := namespacePath.Apply(planned)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ns.IsKnown() {
+			return "(known after apply)"
+		}
+		return ns.AsString()
+	}
+
+	if ns := plan(cty.NullVal(ty), config(cty.NullVal(cty.String))); ns != "team-a" {
+		t.Errorf("expected new objects to be planned in the namespace of the provider, got %q", ns)
+	}
+	if ns := plan(cty.NullVal(ty), config(cty.StringVal("kube-system"))); ns != "kube-system" {
+		t.Errorf("expected the configured namespace, got %q", ns)
+	}
+}
+
+func TestPlanDefaultNamespace(t *testing.T) {
+	ty := cty.Object(map[string]cty.Type{
+		"metadata": cty.List(cty.Object(map[string]cty.Type{"namespace": cty.String})),
+	})
+	value := func(namespace cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"metadata": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"namespace": namespace})}),
+		})
+	}
+	unknown := value(cty.UnknownVal(cty.String))
+	unset := value(cty.NullVal(cty.String))
+
+	cases := []struct {
+		Planned  cty.Value
+		Prior    cty.Value
+		Config   cty.Value
+		Expected cty.Value
+	}{
+		// new objects are created in the namespace of the provider
+		{unknown, cty.NullVal(ty), unset, cty.StringVal("team-a")},
+		// replaced objects keep their namespace
+		{unknown, value(cty.StringVal("default")), unset, cty.StringVal("default")},
+		// configured namespaces are planned by the SDK
+		{unknown, cty.NullVal(ty), value(cty.UnknownVal(cty.String)), cty.UnknownVal(cty.String)},
+		{value(cty.StringVal("kube-system")), cty.NullVal(ty), value(cty.StringVal("kube-system")), cty.StringVal("kube-system")},
+	}
+	for i, tc := range cases {
+		planned, err := planDefaultNamespace(tc.Planned, tc.Prior, tc.Config, "team-a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ns, err := namespacePath.Apply(planned)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ns.RawEquals(tc.Expected) {
+			t.Errorf("%d: expected namespace %#v, got %#v", i, tc.Expected, ns)
+		}
+	}
+}
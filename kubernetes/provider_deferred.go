@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var errConfigUnknown = errors.New("The provider configuration depends on values that are not known yet, such as the credentials of a cluster created in the same run. Apply the resources it depends on first, for example with -target.")
+
+// ProviderServer returns the gRPC server of the provider. Unlike the server
+// returned by GRPCProvider, it accepts a provider configuration that is only
+// known during apply and defers all API calls until then.
+func ProviderServer() tfprotov5.ProviderServer {
+	p := Provider()
+	return &deferredConfigServer{
+		ProviderServer: p.GRPCProvider(),
+		provider:       p,
+	}
+}
+
+type deferredConfigServer struct {
+	tfprotov5.ProviderServer
+	provider *schema.Provider
+}
+
+func (s *deferredConfigServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	// The SDK hides unknown values from the configure function, so they have
+	// to be detected on the raw configuration.
+	if req.Config != nil && len(req.Config.MsgPack) > 0 {
+		ty := schema.InternalMap(s.provider.Schema).CoreConfigSchema().ImpliedType()
+		cfg, err := msgpack.Unmarshal(req.Config.MsgPack, ty)
+		if err == nil && !cfg.IsWhollyKnown() {
+			log.Printf("[INFO] Provider configuration is not known yet, deferring API calls until apply")
+			s.provider.SetMeta(kubeClientsets{configUnknown: true})
+			return &tfprotov5.ConfigureProviderResponse{}, nil
+		}
+	}
+	return s.ProviderServer.ConfigureProvider(ctx, req)
+}
+
+// PlanResourceChange completes the plan of the SDK with the namespace of new
+// objects and the import marker of the private state.
+func (s *deferredConfigServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if err := planImported(req, resp); err != nil {
+		return resp, err
+	}
+	return s.planNamespace(req, resp)
+}
+
+// withDeferredConfiguration makes r plan without calling the API while the
+// provider configuration is unknown. Resources keep their state on refresh
+// and skip the diff customizations that need the API, so that they plan as
+// their configuration says. Data sources cannot be read at all.
+func withDeferredConfiguration(r *schema.Resource, isDataSource bool) {
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if k, ok := meta.(kubeClientsets); ok && k.configUnknown {
+			if isDataSource {
+				return diag.FromErr(errConfigUnknown)
+			}
+			log.Printf("[INFO] Provider configuration is not known yet, keeping the state of %s", d.Id())
+			return nil
+		}
+		return read(ctx, d, meta)
+	}
+
+	if f := r.CustomizeDiff; f != nil {
+		r.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if k, ok := meta.(kubeClientsets); ok && k.configUnknown {
+				return nil
+			}
+			return f(ctx, diff, meta)
+		}
+	}
+}
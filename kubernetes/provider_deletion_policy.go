@@ -0,0 +1,268 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+const (
+	deletionPolicyDelete           = "delete"
+	deletionPolicyOrphan           = "orphan"
+	deletionPolicyOrphanIfImported = "orphan_if_imported"
+)
+
+// typedResourceFieldManagerName is the field manager that the API server
+// records for the requests of the typed resources, which do not set one. The
+// API server uses the product of the user agent, which providerConfigure
+// builds from this name.
+const typedResourceFieldManagerName = "HashiCorp"
+
+// deletionPolicyKinds maps the typed resources that support the
+// deletion_policy attribute to the kind of the objects they manage.
+var deletionPolicyKinds = map[string]k8sschema.GroupKind{
+	"kubernetes_namespace":                              {Kind: "Namespace"},
+	"kubernetes_namespace_v1":                           {Kind: "Namespace"},
+	"kubernetes_service":                                {Kind: "Service"},
+	"kubernetes_service_v1":                             {Kind: "Service"},
+	"kubernetes_service_account":                        {Kind: "ServiceAccount"},
+	"kubernetes_service_account_v1":                     {Kind: "ServiceAccount"},
+	"kubernetes_config_map":                             {Kind: "ConfigMap"},
+	"kubernetes_config_map_v1":                          {Kind: "ConfigMap"},
+	"kubernetes_secret":                                 {Kind: "Secret"},
+	"kubernetes_secret_v1":                              {Kind: "Secret"},
+	"kubernetes_pod":                                    {Kind: "Pod"},
+	"kubernetes_pod_v1":                                 {Kind: "Pod"},
+	"kubernetes_endpoints":                              {Kind: "Endpoints"},
+	"kubernetes_endpoints_v1":                           {Kind: "Endpoints"},
+	"kubernetes_limit_range":                            {Kind: "LimitRange"},
+	"kubernetes_limit_range_v1":                         {Kind: "LimitRange"},
+	"kubernetes_persistent_volume":                      {Kind: "PersistentVolume"},
+	"kubernetes_persistent_volume_v1":                   {Kind: "PersistentVolume"},
+	"kubernetes_persistent_volume_claim":                {Kind: "PersistentVolumeClaim"},
+	"kubernetes_persistent_volume_claim_v1":             {Kind: "PersistentVolumeClaim"},
+	"kubernetes_replication_controller":                 {Kind: "ReplicationController"},
+	"kubernetes_replication_controller_v1":              {Kind: "ReplicationController"},
+	"kubernetes_resource_quota":                         {Kind: "ResourceQuota"},
+	"kubernetes_resource_quota_v1":                      {Kind: "ResourceQuota"},
+	"kubernetes_pod_template_v1":                        {Kind: "PodTemplate"},
+	"kubernetes_api_service":                            {Group: "apiregistration.k8s.io", Kind: "APIService"},
+	"kubernetes_api_service_v1":                         {Group: "apiregistration.k8s.io", Kind: "APIService"},
+	"kubernetes_deployment":                             {Group: "apps", Kind: "Deployment"},
+	"kubernetes_deployment_v1":                          {Group: "apps", Kind: "Deployment"},
+	"kubernetes_daemonset":                              {Group: "apps", Kind: "DaemonSet"},
+	"kubernetes_daemon_set_v1":                          {Group: "apps", Kind: "DaemonSet"},
+	"kubernetes_stateful_set":                           {Group: "apps", Kind: "StatefulSet"},
+	"kubernetes_stateful_set_v1":                        {Group: "apps", Kind: "StatefulSet"},
+	"kubernetes_job":                                    {Group: "batch", Kind: "Job"},
+	"kubernetes_job_v1":                                 {Group: "batch", Kind: "Job"},
+	"kubernetes_cron_job":                               {Group: "batch", Kind: "CronJob"},
+	"kubernetes_cron_job_v1":                            {Group: "batch", Kind: "CronJob"},
+	"kubernetes_horizontal_pod_autoscaler":              {Group: "autoscaling", Kind: "HorizontalPodAutoscaler"},
+	"kubernetes_horizontal_pod_autoscaler_v1":           {Group: "autoscaling", Kind: "HorizontalPodAutoscaler"},
+	"kubernetes_horizontal_pod_autoscaler_v2beta2":      {Group: "autoscaling", Kind: "HorizontalPodAutoscaler"},
+	"kubernetes_horizontal_pod_autoscaler_v2":           {Group: "autoscaling", Kind: "HorizontalPodAutoscaler"},
+	"kubernetes_lease_v1":                               {Group: "coordination.k8s.io", Kind: "Lease"},
+	"kubernetes_certificate_signing_request":            {Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"},
+	"kubernetes_certificate_signing_request_v1":         {Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"},
+	"kubernetes_role":                                   {Group: "rbac.authorization.k8s.io", Kind: "Role"},
+	"kubernetes_role_v1":                                {Group: "rbac.authorization.k8s.io", Kind: "Role"},
+	"kubernetes_role_binding":                           {Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"},
+	"kubernetes_role_binding_v1":                        {Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"},
+	"kubernetes_cluster_role":                           {Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+	"kubernetes_cluster_role_v1":                        {Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+	"kubernetes_cluster_role_binding":                   {Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+	"kubernetes_cluster_role_binding_v1":                {Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+	"kubernetes_endpoint_slice_v1":                      {Group: "discovery.k8s.io", Kind: "EndpointSlice"},
+	"kubernetes_flow_schema":                            {Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"},
+	"kubernetes_priority_level_configuration":           {Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"},
+	"kubernetes_ingress":                                {Group: "networking.k8s.io", Kind: "Ingress"},
+	"kubernetes_ingress_v1":                             {Group: "networking.k8s.io", Kind: "Ingress"},
+	"kubernetes_ingress_class":                          {Group: "networking.k8s.io", Kind: "IngressClass"},
+	"kubernetes_ingress_class_v1":                       {Group: "networking.k8s.io", Kind: "IngressClass"},
+	"kubernetes_network_policy":                         {Group: "networking.k8s.io", Kind: "NetworkPolicy"},
+	"kubernetes_network_policy_v1":                      {Group: "networking.k8s.io", Kind: "NetworkPolicy"},
+	"kubernetes_pod_disruption_budget":                  {Group: "policy", Kind: "PodDisruptionBudget"},
+	"kubernetes_pod_disruption_budget_v1":               {Group: "policy", Kind: "PodDisruptionBudget"},
+	"kubernetes_pod_security_policy":                    {Group: "policy", Kind: "PodSecurityPolicy"},
+	"kubernetes_pod_security_policy_v1beta1":            {Group: "policy", Kind: "PodSecurityPolicy"},
+	"kubernetes_runtime_class_v1":                       {Group: "node.k8s.io", Kind: "RuntimeClass"},
+	"kubernetes_priority_class":                         {Group: "scheduling.k8s.io", Kind: "PriorityClass"},
+	"kubernetes_priority_class_v1":                      {Group: "scheduling.k8s.io", Kind: "PriorityClass"},
+	"kubernetes_validating_webhook_configuration":       {Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"},
+	"kubernetes_validating_webhook_configuration_v1":    {Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"},
+	"kubernetes_mutating_webhook_configuration":         {Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"},
+	"kubernetes_mutating_webhook_configuration_v1":      {Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"},
+	"kubernetes_validating_admission_policy_v1":         {Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"},
+	"kubernetes_validating_admission_policy_binding_v1": {Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"},
+	"kubernetes_storage_class":                          {Group: "storage.k8s.io", Kind: "StorageClass"},
+	"kubernetes_storage_class_v1":                       {Group: "storage.k8s.io", Kind: "StorageClass"},
+	"kubernetes_csi_driver":                             {Group: "storage.k8s.io", Kind: "CSIDriver"},
+	"kubernetes_csi_driver_v1":                          {Group: "storage.k8s.io", Kind: "CSIDriver"},
+}
+
+// withDeletionPolicy adds the deletion_policy attribute to r, which manages
+// objects of kind gk. With the "orphan" policy, destroying the resource
+// leaves the object in the cluster and only removes the managed fields
+// entries of the provider from it. The "orphan_if_imported" policy only does
+// so for objects that were imported, which the private state of the resource
+// records, see markImported.
+func withDeletionPolicy(r *schema.Resource, gk k8sschema.GroupKind) {
+	if _, ok := r.Schema["deletion_policy"]; ok {
+		return
+	}
+	r.Schema["deletion_policy"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  fmt.Sprintf("What to do with the object when the resource is destroyed: %q it, %q it by leaving it in the cluster, or %q it only if it was imported. Defaults to %q.", deletionPolicyDelete, deletionPolicyOrphan, deletionPolicyOrphanIfImported, deletionPolicyDelete),
+		ValidateFunc: validation.StringInSlice([]string{deletionPolicyDelete, deletionPolicyOrphan, deletionPolicyOrphanIfImported}, false),
+	}
+
+	if r.UpdateContext == nil {
+		// the policy is only used on destroy, so changing it only needs to
+		// record it in the state
+		r.UpdateContext = schema.UpdateContextFunc(r.ReadContext)
+	}
+
+	del := r.DeleteContext
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if !shouldOrphan(d.Get("deletion_policy").(string), isImportedContext(ctx)) {
+			return del(ctx, d, meta)
+		}
+		name := d.Get("metadata.0.name").(string)
+
+		log.Printf("[INFO] Orphaning %s %q", gk.Kind, name)
+		rs, err := deletionPolicyResourceInterface(d, meta, gk)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = util.RemoveFieldManager(ctx, rs, name, typedResourceFieldManagerName)
+		if err != nil && !errors.IsNotFound(err) {
+			return diag.Errorf("Failed to orphan %s %q: %s", gk.Kind, name, err)
+		}
+		log.Printf("[INFO] %s %q orphaned", gk.Kind, name)
+		d.SetId("")
+		return nil
+	}
+}
+
+// importedPrivateKey marks the private state of the resources whose object
+// was imported. Unlike an attribute, the private state does not show in plans
+// nor in the state that import is verified against.
+const importedPrivateKey = "kubernetes_imported"
+
+type importedContextKey struct{}
+
+// isImportedContext reports whether ctx is the context of a change to a
+// resource whose object was imported, see deferredConfigServer.ApplyResourceChange.
+func isImportedContext(ctx context.Context) bool {
+	imported, _ := ctx.Value(importedContextKey{}).(bool)
+	return imported
+}
+
+// markImported adds the import marker to the private state of a resource.
+func markImported(private []byte) ([]byte, error) {
+	m := map[string]interface{}{}
+	if len(private) > 0 {
+		if err := json.Unmarshal(private, &m); err != nil {
+			return private, err
+		}
+	}
+	m[importedPrivateKey] = true
+	return json.Marshal(m)
+}
+
+// isImported reports whether the private state of a resource has the import
+// marker.
+func isImported(private []byte) bool {
+	m := map[string]interface{}{}
+	if len(private) == 0 || json.Unmarshal(private, &m) != nil {
+		return false
+	}
+	imported, _ := m[importedPrivateKey].(bool)
+	return imported
+}
+
+// ImportResourceState marks the private state of the imported resources that
+// support the deletion_policy attribute.
+func (s *deferredConfigServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if _, ok := deletionPolicyKinds[req.TypeName]; !ok {
+		return resp, nil
+	}
+	for _, r := range resp.ImportedResources {
+		if r.Private, err = markImported(r.Private); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// ApplyResourceChange passes the import marker of the planned private state
+// to the resource in the context, and keeps it in the new private state. The
+// SDK only keeps its own entries of the private state through apply.
+func (s *deferredConfigServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	imported := isImported(req.PlannedPrivate)
+	if imported {
+		ctx = context.WithValue(ctx, importedContextKey{}, true)
+	}
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if err != nil || resp == nil || !imported {
+		return resp, err
+	}
+	resp.Private, err = markImported(resp.Private)
+	return resp, err
+}
+
+// planImported keeps the import marker of the prior private state in the
+// planned one, unless the object is replaced by a new one.
+func planImported(req *tfprotov5.PlanResourceChangeRequest, resp *tfprotov5.PlanResourceChangeResponse) error {
+	if !isImported(req.PriorPrivate) || len(resp.RequiresReplace) > 0 {
+		return nil
+	}
+	var err error
+	resp.PlannedPrivate, err = markImported(resp.PlannedPrivate)
+	return err
+}
+
+func shouldOrphan(policy string, imported bool) bool {
+	return policy == deletionPolicyOrphan || (policy == deletionPolicyOrphanIfImported && imported)
+}
+
+func deletionPolicyResourceInterface(d *schema.ResourceData, m interface{}, gk k8sschema.GroupKind) (dynamic.ResourceInterface, error) {
+	dc, err := m.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	agr, err := restmapper.GetAPIGroupResources(dc)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := restmapper.NewDiscoveryRESTMapper(agr).RESTMapping(gk)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := m.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return conn.Resource(mapping.Resource).Namespace(d.Get("metadata.0.namespace").(string)), nil
+	}
+	return conn.Resource(mapping.Resource), nil
+}
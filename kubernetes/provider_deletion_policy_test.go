@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDeletionPolicyKinds(t *testing.T) {
+	p := Provider()
+	for name := range deletionPolicyKinds {
+		r, ok := p.ResourcesMap[name]
+		if !ok {
+			t.Errorf("resource %q with a deletion policy is not registered", name)
+			continue
+		}
+		if _, ok := r.Schema["deletion_policy"]; !ok {
+			t.Errorf("resource %q is missing the deletion_policy attribute", name)
+		}
+	}
+}
+
+func TestShouldOrphan(t *testing.T) {
+	cases := []struct {
+		Policy   string
+		Imported bool
+		Expected bool
+	}{
+		{"", false, false},
+		{deletionPolicyDelete, true, false},
+		{deletionPolicyOrphan, false, true},
+		{deletionPolicyOrphanIfImported, false, false},
+		{deletionPolicyOrphanIfImported, true, true},
+	}
+	for _, tc := range cases {
+		if orphan := shouldOrphan(tc.Policy, tc.Imported); orphan != tc.Expected {
+			t.Errorf("policy %q, imported %t: expected orphan %t, got %t", tc.Policy, tc.Imported, tc.Expected, orphan)
+		}
+	}
+}
+
+func TestImportedPrivate(t *testing.T) {
+	if isImported(nil) {
+		t.Errorf("expected an empty private state not to be imported")
+	}
+	private, err := markImported([]byte(`{"schema_version":"1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isImported(private) {
+		t.Errorf("expected the private state %s to be imported", private)
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(private, &m); err != nil || m["schema_version"] != "1" {
+		t.Errorf("expected the entries of the SDK to be kept, got %s", private)
+	}
+
+	if isImportedContext(context.Background()) {
+		t.Errorf("expected a plain context not to be imported")
+	}
+	if !isImportedContext(context.WithValue(context.Background(), importedContextKey{}, true)) {
+		t.Errorf("expected the context to be imported")
+	}
+}
+
+func TestProviderServer_importedPrivate(t *testing.T) {
+	s := ProviderServer()
+	resp, err := s.ImportResourceState(context.Background(), &tfprotov5.ImportResourceStateRequest{
+		TypeName: "kubernetes_config_map_v1",
+		ID:       "default/test",
+	})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("failed to import: %v %v", err, resp.Diagnostics)
+	}
+	if len(resp.ImportedResources) != 1 || !isImported(resp.ImportedResources[0].Private) {
+		t.Fatalf("expected the imported resource to be marked in its private state, got %v", resp.ImportedResources)
+	}
+
+	plan := &tfprotov5.PlanResourceChangeResponse{}
+	if err := planImported(&tfprotov5.PlanResourceChangeRequest{PriorPrivate: resp.ImportedResources[0].Private}, plan); err != nil {
+		t.Fatal(err)
+	}
+	if !isImported(plan.PlannedPrivate) {
+		t.Errorf("expected the plan to keep the import marker")
+	}
+	plan = &tfprotov5.PlanResourceChangeResponse{RequiresReplace: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("metadata")}}
+	if err := planImported(&tfprotov5.PlanResourceChangeRequest{PriorPrivate: resp.ImportedResources[0].Private}, plan); err != nil {
+		t.Fatal(err)
+	}
+	if isImported(plan.PlannedPrivate) {
+		t.Errorf("expected the replacing object not to be marked as imported")
+	}
+}
@@ -2,8 +2,11 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,11 +14,16 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
 	gversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	api "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 )
 
 // Global constants for testing images (reduces the number of docker pulls).
@@ -101,6 +109,343 @@ func TestProvider_configure_paths(t *testing.T) {
 	}
 }
 
+func TestProvider_configure_env_precedence(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := func(name string) string {
+		path := filepath.Join(dir, name)
+		err := os.WriteFile(path, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: https://%[1]s.example.com
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+current-context: %[1]s
+`, name)), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	a, b := kubeconfig("a"), kubeconfig("b")
+
+	cases := map[string]struct {
+		config map[string]interface{}
+		env    map[string]string
+		host   string
+	}{
+		"config_path over KUBE_CONFIG_PATH": {
+			config: map[string]interface{}{"config_path": b},
+			env:    map[string]string{"KUBE_CONFIG_PATH": a},
+			host:   "https://b.example.com",
+		},
+		"KUBE_CONFIG_PATH over KUBE_CONFIG_PATHS": {
+			env:  map[string]string{"KUBE_CONFIG_PATH": a, "KUBE_CONFIG_PATHS": b},
+			host: "https://a.example.com",
+		},
+		"config_paths over KUBE_CONFIG_PATHS": {
+			config: map[string]interface{}{"config_paths": []interface{}{b}},
+			env:    map[string]string{"KUBE_CONFIG_PATHS": a},
+			host:   "https://b.example.com",
+		},
+		"host over KUBE_HOST": {
+			config: map[string]interface{}{"host": "https://attribute.example.com"},
+			env:    map[string]string{"KUBE_HOST": "https://env.example.com"},
+			host:   "https://attribute.example.com",
+		},
+		"KUBE_HOST over kubeconfig": {
+			env:  map[string]string{"KUBE_CONFIG_PATH": a, "KUBE_HOST": "https://env.example.com"},
+			host: "https://env.example.com",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resetEnv := unsetEnv(t)
+			defer resetEnv()
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			config := tc.config
+			if config == nil {
+				config = map[string]interface{}{}
+			}
+			p := Provider()
+			diags := p.Configure(context.TODO(), terraform.NewResourceConfigRaw(config))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if host := p.Meta().(kubeClientsets).config.Host; host != tc.host {
+				t.Errorf("expected host %q, got %q", tc.host, host)
+			}
+		})
+	}
+}
+
+func TestProvider_env_defaults(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+	t.Setenv("KUBE_HOST", "https://env.example.com")
+	t.Setenv("KUBE_CONFIG_PATH", "test-fixtures/kube-config.yaml")
+
+	// the environment variables are the defaults of their attributes, so
+	// that they are shown in the plan
+	p := Provider()
+	for attr, expected := range map[string]string{
+		"host":        "https://env.example.com",
+		"config_path": "test-fixtures/kube-config.yaml",
+	} {
+		v, err := p.Schema[attr].DefaultValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != expected {
+			t.Errorf("expected default %q for %s, got %v", expected, attr, v)
+		}
+	}
+
+	// KUBE_CONFIG_PATH sets config_path, which conflicts with config_paths
+	diags := p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"config_paths": []interface{}{"test-fixtures/kube-config-secondary.yaml"},
+	}))
+	if !diags.HasError() {
+		t.Errorf("expected KUBE_CONFIG_PATH to conflict with config_paths")
+	}
+}
+
+func TestProvider_configure_invalid(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	p := Provider()
+	diags := p.Configure(context.TODO(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"host":            "https://example.com",
+		"request_timeout": "soon",
+	}))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diags)
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("request_timeout")) {
+		t.Errorf("expected the diagnostic to point at request_timeout, got %#v", diags[0].AttributePath)
+	}
+}
+
+func TestProvider_configure_clusters(t *testing.T) {
+	ctx := context.TODO()
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	// Environment variables only apply to the top level cluster.
+	os.Setenv("KUBE_TOKEN", "top-level-token")
+
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"host": "https://default.example.com",
+		"clusters": []interface{}{
+			map[string]interface{}{
+				"name":  "east",
+				"host":  "https://east.example.com",
+				"token": "east-token",
+			},
+			map[string]interface{}{
+				"name":           "west",
+				"config_path":    "test-fixtures/kube-config.yaml",
+				"config_context": "gcp",
+				"insecure":       true,
+			},
+		},
+	})
+	p := Provider()
+	diags := p.Configure(ctx, rc)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	meta := p.Meta().(kubeClientsets)
+
+	for name, host := range map[string]string{"east": "https://east.example.com", "west": "https://127.0.0.1"} {
+		m, err := clusterMeta(name, meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := m.(kubeClientsets)
+		if k.config.Host != host {
+			t.Errorf("cluster %s: expected host %q, got %q", name, host, k.config.Host)
+		}
+		if name == "west" && k.config.BearerToken != "" {
+			t.Errorf("cluster %s: KUBE_TOKEN should not apply", name)
+		}
+		again, _ := clusterMeta(name, meta)
+		if again.(kubeClientsets).mainClientset != k.mainClientset {
+			t.Errorf("cluster %s: clients were not cached", name)
+		}
+	}
+	if m, _ := clusterMeta("", meta); m.(kubeClientsets).config.Host != "https://default.example.com" {
+		t.Errorf("an empty cluster should select the top level cluster")
+	}
+	if _, err := clusterMeta("north", meta); err == nil {
+		t.Errorf("expected an error for an undefined cluster")
+	}
+}
+
+func TestProvider_configure_clusters_insecure(t *testing.T) {
+	ctx := context.TODO()
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: insecure
+  cluster:
+    server: https://insecure.example.com
+    insecure-skip-tls-verify: true
+contexts:
+- name: insecure
+  context:
+    cluster: insecure
+current-context: insecure
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"host": "https://default.example.com",
+		"clusters": []interface{}{
+			map[string]interface{}{
+				"name":        "inherited",
+				"config_path": kubeconfig,
+			},
+			map[string]interface{}{
+				"name":        "verified",
+				"config_path": kubeconfig,
+				"insecure":    false,
+			},
+		},
+	})
+	p := Provider()
+	diags := p.Configure(ctx, rc)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	meta := p.Meta().(kubeClientsets)
+
+	for name, insecure := range map[string]bool{"inherited": true, "verified": false} {
+		m, err := clusterMeta(name, meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.(kubeClientsets).config.Insecure != insecure {
+			t.Errorf("cluster %s: expected insecure %t", name, insecure)
+		}
+	}
+}
+
+func TestUseAdmissionregistrationV1beta1(t *testing.T) {
+	server := func(version string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var out interface{}
+			switch r.URL.Path {
+			case "/api":
+				out = &metav1.APIVersions{Versions: []string{"v1"}}
+			case "/apis":
+				out = &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+					Name: "admissionregistration.k8s.io",
+					Versions: []metav1.GroupVersionForDiscovery{{
+						GroupVersion: "admissionregistration.k8s.io/" + version,
+						Version:      version,
+					}},
+				}}}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(out)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	// The result of one cluster must not be reused for another.
+	for _, tc := range []struct {
+		version  string
+		expected bool
+	}{
+		{"v1", false},
+		{"v1beta1", true},
+	} {
+		conn := kubernetes.NewForConfigOrDie(&restclient.Config{Host: server(tc.version).URL})
+		for i := 0; i < 2; i++ {
+			v1beta1, err := useAdmissionregistrationV1beta1(conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v1beta1 != tc.expected {
+				t.Errorf("served %s: expected v1beta1=%t, got %t", tc.version, tc.expected, v1beta1)
+			}
+		}
+	}
+}
+
+func TestProvider_cluster_attribute(t *testing.T) {
+	p := Provider()
+	for name, r := range p.ResourcesMap {
+		if s, ok := r.Schema["cluster"]; !ok || !s.ForceNew {
+			t.Errorf("resource %s has no cluster attribute forcing replacement", name)
+		}
+	}
+	for name, r := range p.DataSourcesMap {
+		if _, ok := r.Schema["cluster"]; !ok {
+			t.Errorf("data source %s has no cluster attribute", name)
+		}
+	}
+}
+
+func TestProviderServer_configUnknown(t *testing.T) {
+	s := ProviderServer().(*deferredConfigServer)
+	ty := schema.InternalMap(s.provider.Schema).CoreConfigSchema().ImpliedType()
+	attrs := map[string]cty.Value{}
+	for name, at := range ty.AttributeTypes() {
+		attrs[name] = cty.NullVal(at)
+	}
+	attrs["host"] = cty.UnknownVal(cty.String)
+	b, err := msgpack.Marshal(cty.ObjectVal(attrs), ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+		Config: &tfprotov5.DynamicValue{MsgPack: b},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	meta := s.provider.Meta()
+	if k, ok := meta.(kubeClientsets); !ok || !k.configUnknown {
+		t.Fatalf("expected the provider to be configured in deferred mode, got %#v", meta)
+	}
+
+	r := s.provider.ResourcesMap["kubernetes_config_map_v1"]
+	d := r.TestResourceData()
+	d.SetId("default/test")
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Errorf("expected resources to keep their state, got %v", diags)
+	}
+	if diags := r.CreateContext(context.Background(), d, meta); !diags.HasError() {
+		t.Errorf("expected resources to fail when created without configuration")
+	}
+	ds := s.provider.DataSourcesMap["kubernetes_config_map_v1"]
+	if diags := ds.ReadContext(context.Background(), ds.TestResourceData(), meta); !diags.HasError() {
+		t.Errorf("expected data sources to fail without configuration")
+	}
+}
+
 func unsetEnv(t *testing.T) func() {
 	e := getEnv()
 
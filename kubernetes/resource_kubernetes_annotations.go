@@ -26,6 +26,7 @@ func resourceKubernetesAnnotations() *schema.Resource {
 		ReadContext:   resourceKubernetesAnnotationsRead,
 		UpdateContext: resourceKubernetesAnnotationsUpdate,
 		DeleteContext: resourceKubernetesAnnotationsDelete,
+		CustomizeDiff: metadataSelectorCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"api_version": {
 				Type:        schema.TypeString,
@@ -40,9 +41,10 @@ func resourceKubernetesAnnotations() *schema.Resource {
 				ForceNew:    true,
 			},
 			"metadata": {
-				Type:     schema.TypeList,
-				Required: true,
-				MaxItems: 1,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"metadata", "selector"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -65,6 +67,8 @@ func resourceKubernetesAnnotations() *schema.Resource {
 				Description: "A map of annotations to apply to the resource.",
 				Required:    true,
 			},
+			"selector": metadataSelectorSchema("annotations"),
+			"objects":  metadataSelectorObjectsSchema("annotations"),
 			"force": {
 				Type:        schema.TypeBool,
 				Description: "Force overwriting annotations that were created or edited outside of Terraform.",
@@ -82,10 +86,14 @@ func resourceKubernetesAnnotations() *schema.Resource {
 }
 
 func resourceKubernetesAnnotationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	d.SetId(buildIdWithVersionKind(metadata,
-		d.Get("api_version").(string),
-		d.Get("kind").(string)))
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorCreate(ctx, d, m, "annotations")
+	}
+	id, err := patchTargetId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
 	diag := resourceKubernetesAnnotationsUpdate(ctx, d, m)
 	if diag.HasError() {
 		d.SetId("")
@@ -94,6 +102,10 @@ func resourceKubernetesAnnotationsCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceKubernetesAnnotationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorRead(ctx, d, m, "annotations")
+	}
+
 	conn, err := m.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
@@ -123,7 +135,7 @@ func resourceKubernetesAnnotationsRead(ctx context.Context, d *schema.ResourceDa
 	var r dynamic.ResourceInterface
 	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
 		if namespace == "" {
-			namespace = "default"
+			namespace = defaultNamespace(m)
 		}
 		r = conn.Resource(mapping.Resource).Namespace(namespace)
 	} else {
@@ -185,6 +197,10 @@ func getManagedAnnotations(managedFields []v1.ManagedFieldsEntry, manager string
 }
 
 func resourceKubernetesAnnotationsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorUpdate(ctx, d, m, "annotations")
+	}
+
 	conn, err := m.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
@@ -192,7 +208,7 @@ func resourceKubernetesAnnotationsUpdate(ctx context.Context, d *schema.Resource
 
 	apiVersion := d.Get("api_version").(string)
 	kind := d.Get("kind").(string)
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	name := metadata.GetName()
 	namespace := metadata.GetNamespace()
 
@@ -221,7 +237,7 @@ func resourceKubernetesAnnotationsUpdate(ctx context.Context, d *schema.Resource
 	namespacedResource := mapping.Scope.Name() == meta.RESTScopeNameNamespace
 	if namespacedResource {
 		if namespace == "" {
-			namespace = "default"
+			namespace = defaultNamespace(m)
 		}
 		r = conn.Resource(mapping.Resource).Namespace(namespace)
 	} else {
@@ -293,6 +309,9 @@ func resourceKubernetesAnnotationsUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceKubernetesAnnotationsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorDelete(ctx, d, m, "annotations")
+	}
 	d.SetId("")
 	return resourceKubernetesAnnotationsUpdate(ctx, d, m)
 }
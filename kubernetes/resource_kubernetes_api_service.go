@@ -107,7 +107,7 @@ func resourceKubernetesAPIServiceCreate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	svc := v1.APIService{
 		ObjectMeta: metadata,
 		Spec:       expandAPIServiceSpec(d.Get("spec").([]interface{})),
@@ -89,7 +89,7 @@ func resourceKubernetesCertificateSigningRequestCreate(ctx context.Context, d *s
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandCertificateSigningRequestSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	certificates "k8s.io/api/certificates/v1"
 	v1 "k8s.io/api/core/v1"
@@ -24,6 +25,7 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKubernetesCertificateSigningRequestV1Create,
 		ReadContext:   resourceKubernetesCertificateSigningRequestV1Read,
+		UpdateContext: resourceKubernetesCertificateSigningRequestV1Update,
 		DeleteContext: resourceKubernetesCertificateSigningRequestV1Delete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -31,6 +33,21 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 		},
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if diff.Id() == "" {
+				return nil
+			}
+			renew, err := certificateSigningRequestV1NeedsRenewal(diff.Get("certificate").(string), diff.Get("renew_before").(string))
+			if err != nil {
+				return err
+			}
+			if renew || diff.Get("ready_for_renewal").(bool) {
+				// certificate is ForceNew, so planning a new certificate replaces the resource.
+				log.Printf("[INFO] Certificate %s is due for renewal, planning replacement", diff.Id())
+				return diff.SetNewComputed("certificate")
+			}
+			return nil
+		},
 		Schema: map[string]*schema.Schema{
 			"auto_approve": {
 				Type:        schema.TypeBool,
@@ -43,8 +60,37 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: apiDocStatus["certificate"],
 				Computed:    true,
+				ForceNew:    true,
 			},
 			"metadata": metadataSchemaForceNew(metadataSchema("certificate signing request", true)),
+			"generate_request": {
+				Type:         schema.TypeList,
+				Description:  "Generate the private key and certificate request instead of providing `spec.request`. The private key is stored in the `private_key_pem` attribute.",
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"generate_request", "spec.0.request"},
+				Elem: &schema.Resource{
+					Schema: certificateSigningRequestV1GenerateRequestFields(),
+				},
+			},
+			"private_key_pem": {
+				Type:        schema.TypeString,
+				Description: "PEM-encoded PKCS#8 private key generated for the certificate request, when `generate_request` is used.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"renew_before": {
+				Type:         schema.TypeString,
+				Description:  "Plan the replacement of this resource when the issued certificate expires within this duration, for example `720h`.",
+				Optional:     true,
+				ValidateFunc: validateRenewBefore,
+			},
+			"ready_for_renewal": {
+				Type:        schema.TypeBool,
+				Description: "Whether the issued certificate expires within the `renew_before` window.",
+				Computed:    true,
+			},
 			"spec": {
 				ForceNew:    true,
 				Type:        schema.TypeList,
@@ -56,7 +102,8 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 						"request": {
 							Type:        schema.TypeString,
 							Description: apiDocSpec["request"],
-							Required:    true,
+							Optional:    true,
+							Computed:    true,
 							ForceNew:    true,
 						},
 						"signer_name": {
@@ -89,12 +136,22 @@ func resourceKubernetesCertificateSigningRequestV1Create(ctx context.Context, d
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandCertificateSigningRequestV1Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if v, ok := d.GetOk("generate_request"); ok {
+		request, privateKey, err := generateCertificateSigningRequestV1(v.([]interface{}))
+		if err != nil {
+			return diag.Errorf("Failed to generate certificate request: %s", err)
+		}
+		spec.Request = []byte(request)
+		d.Set("private_key_pem", privateKey)
+		d.Set("spec", flattenCertificateSigningRequestV1Spec(*spec))
+	}
+
 	csr := certificates.CertificateSigningRequest{
 		ObjectMeta: metadata,
 		Spec:       *spec,
@@ -175,13 +232,99 @@ func resourceKubernetesCertificateSigningRequestV1Create(ctx context.Context, d
 	return resourceKubernetesCertificateSigningRequestV1Read(ctx, d, meta)
 }
 
-// resourceKubernetesCertificateSigningRequestV1Read does not return any data, because Read functions exist to
-// sync the local state with the remote state. Since this data is local-only, there is nothing to read.
-func resourceKubernetesCertificateSigningRequestV1Read(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+// resourceKubernetesCertificateSigningRequestV1Read does not read any remote data, because the CSR only exists
+// momentarily in the cluster while the certificate is issued. It only checks whether the locally stored certificate
+// is due for renewal.
+func resourceKubernetesCertificateSigningRequestV1Read(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	renew, err := certificateSigningRequestV1NeedsRenewal(d.Get("certificate").(string), d.Get("renew_before").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("ready_for_renewal", renew)
 	return diag.Diagnostics{}
 }
 
+// resourceKubernetesCertificateSigningRequestV1Update only handles changes to local-only attributes.
+func resourceKubernetesCertificateSigningRequestV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceKubernetesCertificateSigningRequestV1Read(ctx, d, meta)
+}
+
 func resourceKubernetesCertificateSigningRequestV1Delete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	d.SetId("")
 	return diag.Diagnostics{}
 }
+
+func certificateSigningRequestV1NeedsRenewal(certificate, renewBefore string) (bool, error) {
+	if certificate == "" || renewBefore == "" {
+		return false, nil
+	}
+	rb, err := time.ParseDuration(renewBefore)
+	if err != nil {
+		return false, err
+	}
+	return certificateNeedsRenewal(certificate, rb, time.Now())
+}
+
+func certificateSigningRequestV1GenerateRequestFields() map[string]*schema.Schema {
+	stringList := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Description: description,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+	return map[string]*schema.Schema{
+		"subject": {
+			Type:        schema.TypeList,
+			Description: "The subject of the certificate request.",
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"common_name": {
+						Type:        schema.TypeString,
+						Description: "Common name (CN). For client certificates, this is the name of the user.",
+						Optional:    true,
+						ForceNew:    true,
+					},
+					"organization":        stringList("Organizations (O). For client certificates, these are the groups of the user."),
+					"organizational_unit": stringList("Organizational units (OU)."),
+					"country":             stringList("Countries (C)."),
+					"province":            stringList("Provinces or states (ST)."),
+					"locality":            stringList("Localities or cities (L)."),
+					"street_address":      stringList("Street addresses."),
+					"postal_code":         stringList("Postal codes."),
+				},
+			},
+		},
+		"dns_names":    stringList("DNS subject alternative names."),
+		"ip_addresses": stringList("IP address subject alternative names."),
+		"key_algorithm": {
+			Type:         schema.TypeString,
+			Description:  "Algorithm of the generated private key: RSA, ECDSA or ED25519.",
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "ECDSA",
+			ValidateFunc: validation.StringInSlice([]string{"RSA", "ECDSA", "ED25519"}, false),
+		},
+		"rsa_bits": {
+			Type:         schema.TypeInt,
+			Description:  "Size of the generated RSA key in bits, when key_algorithm is RSA.",
+			Optional:     true,
+			ForceNew:     true,
+			Default:      2048,
+			ValidateFunc: validation.IntAtLeast(2048),
+		},
+		"ecdsa_curve": {
+			Type:         schema.TypeString,
+			Description:  "Elliptic curve of the generated ECDSA key, when key_algorithm is ECDSA: P224, P256, P384 or P521.",
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "P256",
+			ValidateFunc: validation.StringInSlice([]string{"P224", "P256", "P384", "P521"}, false),
+		},
+	}
+}
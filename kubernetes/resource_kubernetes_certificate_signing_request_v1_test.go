@@ -2,9 +2,17 @@ package kubernetes
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -62,6 +70,98 @@ func TestAccKubernetesCertificateSigningRequestV1_generateName(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesCertificateSigningRequestV1_generateRequest(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.22.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesCertificateSigningRequestV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesCertificateSigningRequestV1Config_generateRequest(name, "1h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCertificateSigningRequestV1Valid,
+					resource.TestCheckResourceAttrSet("kubernetes_certificate_signing_request_v1.test", "certificate"),
+					resource.TestCheckResourceAttrSet("kubernetes_certificate_signing_request_v1.test", "spec.0.request"),
+					resource.TestCheckResourceAttrSet("kubernetes_certificate_signing_request_v1.test", "private_key_pem"),
+					resource.TestCheckResourceAttr("kubernetes_certificate_signing_request_v1.test", "ready_for_renewal", "false"),
+				),
+			},
+			{
+				// Certificates issued by the cluster signer are valid for less than 100 years,
+				// so a renew_before of 100 years must plan a replacement.
+				Config:             testAccKubernetesCertificateSigningRequestV1Config_generateRequest(name, "876000h"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceKubernetesCertificateSigningRequestV1_renewalDiff(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(48 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	cases := []struct {
+		renewBefore     string
+		readyForRenewal string
+		expected        bool
+	}{
+		{"1h", "false", false},
+		// renew_before changed since the last refresh, so ready_for_renewal is still false
+		{"72h", "false", true},
+		{"72h", "true", true},
+	}
+	for _, tc := range cases {
+		state := &terraform.InstanceState{
+			ID: "test",
+			Attributes: map[string]string{
+				"id":                 "test",
+				"auto_approve":       "true",
+				"certificate":        certificate,
+				"metadata.#":         "1",
+				"metadata.0.name":    "test",
+				"ready_for_renewal":  tc.readyForRenewal,
+				"renew_before":       "1h",
+				"spec.#":             "1",
+				"spec.0.request":     "request",
+				"spec.0.signer_name": "example.com/signer",
+			},
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"metadata":     []interface{}{map[string]interface{}{"name": "test"}},
+			"renew_before": tc.renewBefore,
+			"spec": []interface{}{map[string]interface{}{
+				"request":     "request",
+				"signer_name": "example.com/signer",
+			}},
+		})
+		diff, err := resourceKubernetesCertificateSigningRequestV1().Diff(context.Background(), state, config, nil)
+		if err != nil {
+			t.Fatalf("renew_before %s: %s", tc.renewBefore, err)
+		}
+		if replace := diff != nil && diff.RequiresNew(); replace != tc.expected {
+			t.Fatalf("renew_before %s, ready_for_renewal %s: expected replacement %t, got %t", tc.renewBefore, tc.readyForRenewal, tc.expected, replace)
+		}
+	}
+}
+
 // testAccCheckKubernetesCertificateSigningRequestV1Valid checks to see that the locally-stored certificate
 // contains a valid PEM preamble. It also checks that the CSR resource has been deleted from Kubernetes, since
 // the CSR is only supposed to exist momentarily as the certificate is generated. (CSR resources are ephemeral
@@ -146,3 +246,25 @@ EOT
 }
 `, generateName)
 }
+
+func testAccKubernetesCertificateSigningRequestV1Config_generateRequest(name, renewBefore string) string {
+	return fmt.Sprintf(`resource "kubernetes_certificate_signing_request_v1" "test" {
+  metadata {
+    name = %q
+  }
+  auto_approve = true
+  renew_before = %q
+  generate_request {
+    subject {
+      common_name  = "jane"
+      organization = ["developers"]
+    }
+    key_algorithm = "ECDSA"
+  }
+  spec {
+    signer_name = "kubernetes.io/kube-apiserver-client"
+    usages      = ["client auth"]
+  }
+}
+`, name, renewBefore)
+}
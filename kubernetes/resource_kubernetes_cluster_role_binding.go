@@ -54,7 +54,7 @@ func resourceKubernetesClusterRoleBindingCreate(ctx context.Context, d *schema.R
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	binding := &api.ClusterRoleBinding{
 		ObjectMeta: metadata,
 		RoleRef:    expandRBACRoleRef(d.Get("role_ref").([]interface{})),
@@ -237,6 +237,27 @@ func TestAccKubernetesConfigMap_immutable(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesConfigMap_deletionPolicyOrphan(t *testing.T) {
+	var conf api.ConfigMap
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_config_map.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesConfigMapOrphaned(name),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapConfig_deletionPolicy(name, "orphan"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "deletion_policy", "orphan"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckConfigMapData(m *api.ConfigMap, expected map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.Data) == 0 {
@@ -277,6 +298,30 @@ func testAccCheckKubernetesConfigMapDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccCheckKubernetesConfigMapOrphaned checks that the config map was left
+// in the cluster without the managed fields of the provider, and deletes it.
+func testAccCheckKubernetesConfigMapOrphaned(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		cm, err := conn.CoreV1().ConfigMaps("default").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Config Map was not orphaned: %s", err)
+		}
+		defer conn.CoreV1().ConfigMaps("default").Delete(ctx, name, metav1.DeleteOptions{})
+		for _, mf := range cm.ManagedFields {
+			if mf.Manager == typedResourceFieldManagerName {
+				return fmt.Errorf("Config Map still has managed fields of %q", mf.Manager)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckKubernetesConfigMapExists(n string, obj *api.ConfigMap) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -478,3 +523,18 @@ func testAccKubernetesConfigMapConfig_immutable(name string, immutable bool, dat
 }
 `, name, immutable, data)
 }
+
+func testAccKubernetesConfigMapConfig_deletionPolicy(name, policy string) string {
+	return fmt.Sprintf(`resource "kubernetes_config_map" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data = {
+    one = "first"
+  }
+
+  deletion_policy = "%s"
+}
+`, name, policy)
+}
@@ -35,10 +35,10 @@ func resourceKubernetesConfigMapV1Data() *schema.Resource {
 						},
 						"namespace": {
 							Type:        schema.TypeString,
-							Description: "The namespace of the ConfigMap.",
+							Description: "The namespace of the ConfigMap. Defaults to the namespace of the provider.",
 							Optional:    true,
+							Computed:    true,
 							ForceNew:    true,
-							Default:     "default",
 						},
 					},
 				},
@@ -64,7 +64,7 @@ func resourceKubernetesConfigMapV1Data() *schema.Resource {
 }
 
 func resourceKubernetesConfigMapV1DataCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	d.SetId(buildId(metadata))
 	diag := resourceKubernetesConfigMapV1DataUpdate(ctx, d, m)
 	if diag.HasError() {
@@ -143,7 +143,7 @@ func resourceKubernetesConfigMapV1DataUpdate(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	name := metadata.GetName()
 	namespace := metadata.GetNamespace()
 
@@ -0,0 +1,301 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+func resourceKubernetesContainerImage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesContainerImageCreate,
+		ReadContext:   resourceKubernetesContainerImageRead,
+		UpdateContext: resourceKubernetesContainerImageUpdate,
+		DeleteContext: resourceKubernetesContainerImageDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"api_version": {
+				Type:        schema.TypeString,
+				Description: "The apiVersion of the workload.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "The kind of the workload.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Deployment", "StatefulSet", "DaemonSet", "Job", "CronJob"}, false),
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the workload.",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "The namespace of the workload.",
+							Optional:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"container": {
+				Type:         schema.TypeString,
+				Description:  "Name of the container whose image is managed.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"container", "init_container"},
+			},
+			"init_container": {
+				Type:         schema.TypeString,
+				Description:  "Name of the init container whose image is managed.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"container", "init_container"},
+			},
+			"image": {
+				Type:        schema.TypeString,
+				Description: "Container image name.",
+				Required:    true,
+			},
+			"image_pull_policy": {
+				Type:         schema.TypeString,
+				Description:  "Image pull policy. One of Always, Never, IfNotPresent. Only managed when set.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Always", "Never", "IfNotPresent"}, false),
+			},
+			"wait_for_rollout": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the rollout of the workload to complete after the image is changed. Only supported for Deployments, StatefulSets and DaemonSets.",
+				Optional:    true,
+			},
+			"force": {
+				Type:        schema.TypeBool,
+				Description: "Force overwriting the image if it is managed by another field manager.",
+				Optional:    true,
+			},
+			"field_manager": {
+				Type:        schema.TypeString,
+				Description: "Set the name of the field manager for the image.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     defaultFieldManagerName,
+			},
+		},
+	}
+}
+
+func resourceKubernetesContainerImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, err := patchTargetId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+	diag := resourceKubernetesContainerImageUpdate(ctx, d, m)
+	if diag.HasError() {
+		d.SetId("")
+	}
+	return diag
+}
+
+func resourceKubernetesContainerImageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	gvk, name, namespace, err := util.ParseResourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	r, _, err := patchResourceInterface(m, apiVersion, kind, namespace)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := r.Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Resource deleted",
+				Detail:   fmt.Sprintf("The underlying resource %q has been deleted. You should recreate the underlying resource, or remove it from your configuration.", name),
+			}}
+		}
+		return diag.FromErr(err)
+	}
+
+	specPath, err := podSpecPath(kind, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	list, containerName := containerTarget(d)
+	container, err := findContainer(res.Object, specPath, list, containerName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	image, _ := container["image"].(string)
+	d.Set("image", image)
+
+	// the pull policy always has a value, so only read it back while we
+	// manage it
+	set, err := managedFieldSet(res.GetManagedFields(), d.Get("field_manager").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pullPolicy := ""
+	if set.Has(containerFieldPath(specPath, list, containerName, "imagePullPolicy")) {
+		pullPolicy, _ = container["imagePullPolicy"].(string)
+	}
+	d.Set("image_pull_policy", pullPolicy)
+	return nil
+}
+
+func resourceKubernetesContainerImageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiVersion := d.Get("api_version").(string)
+	kind := d.Get("kind").(string)
+	metadata := expandResourceMetadata(d)
+	name := metadata.GetName()
+	r, namespace, err := patchResourceInterface(m, apiVersion, kind, metadata.GetNamespace())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// check the resource exists before we try and patch it
+	_, err = r.Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return diag.Errorf("The resource %q does not exist", name)
+	}
+
+	specPath, err := podSpecPath(kind, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	list, containerName := containerTarget(d)
+	container := map[string]interface{}{
+		"name":  containerName,
+		"image": d.Get("image").(string),
+	}
+	if p := d.Get("image_pull_policy").(string); p != "" {
+		container["imagePullPolicy"] = p
+	}
+	patch := unstructured.Unstructured{}
+	patch.SetAPIVersion(apiVersion)
+	patch.SetKind(kind)
+	patch.SetName(name)
+	patch.SetNamespace(namespace)
+	err = unstructured.SetNestedSlice(patch.Object, []interface{}{container}, append(append([]string{}, specPath...), list)...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	patchbytes, err := patch.MarshalJSON()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Setting the image of container %q of %s %q to %q", containerName, kind, name, container["image"])
+	_, err = r.Patch(ctx,
+		name,
+		types.ApplyPatchType,
+		patchbytes,
+		v1.PatchOptions{
+			FieldManager: d.Get("field_manager").(string),
+			Force:        ptrToBool(d.Get("force").(bool)),
+		},
+	)
+	if err != nil {
+		if errors.IsConflict(err) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Field manager conflict",
+				Detail:   fmt.Sprintf(`Another client is managing a field Terraform tried to update. Set "force" to true to override: %v`, err),
+			}}
+		}
+		return diag.FromErr(err)
+	}
+
+	if d.Get("wait_for_rollout").(bool) {
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		if d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutCreate)
+		}
+		if err := waitForContainerImageRollout(ctx, m, kind, namespace, name, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKubernetesContainerImageRead(ctx, d, m)
+}
+
+func waitForContainerImageRollout(ctx context.Context, m interface{}, kind, namespace, name string, timeout time.Duration) error {
+	conn, err := m.(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	var f resource.RetryFunc
+	switch kind {
+	case "Deployment":
+		f = waitForDeploymentReplicasFunc(ctx, conn, namespace, name)
+	case "StatefulSet":
+		f = retryUntilStatefulSetRolloutComplete(ctx, conn, namespace, name)
+	case "DaemonSet":
+		f = waitForDaemonSetReplicasFunc(ctx, conn, namespace, name)
+	default:
+		log.Printf("[WARN] Waiting for the rollout of a %s is not supported", kind)
+		return nil
+	}
+	log.Printf("[INFO] Waiting for %s %s/%s to rollout", kind, namespace, name)
+	return resource.RetryContext(ctx, timeout, f)
+}
+
+func resourceKubernetesContainerImageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiVersion := d.Get("api_version").(string)
+	kind := d.Get("kind").(string)
+	metadata := expandResourceMetadata(d)
+	name := metadata.GetName()
+	r, _, err := patchResourceInterface(m, apiVersion, kind, metadata.GetNamespace())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// leave the image in place and only give up its ownership, as removing
+	// it would make the workload invalid
+	specPath, err := podSpecPath(kind, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	list, containerName := containerTarget(d)
+	fields := fieldpath.NewSet(
+		containerFieldPath(specPath, list, containerName),
+		containerFieldPath(specPath, list, containerName, "name"),
+		containerFieldPath(specPath, list, containerName, "image"),
+		containerFieldPath(specPath, list, containerName, "imagePullPolicy"),
+	)
+	err = util.ReleaseFields(ctx, r, name, d.Get("field_manager").(string), fields)
+	if err != nil && !errors.IsNotFound(err) {
+		return diag.Errorf("Failed to release the image of container %q of %s %q: %s", containerName, kind, name, err)
+	}
+	d.SetId("")
+	return nil
+}
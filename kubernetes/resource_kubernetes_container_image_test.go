@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesContainerImage_deployment(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "default"
+	resourceName := "kubernetes_container_image.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createEnv(t, name, namespace)
+		},
+		IDRefreshName:     resourceName,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			// the image is left in place on destroy
+			if err := testAccCheckKubernetesContainerImage(name, namespace, nginxImageVersion1); err != nil {
+				return err
+			}
+			return destroyEnv(name, namespace)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesContainerImageConfig(name, namespace, nginxImageVersion),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "kind", "Deployment"),
+					resource.TestCheckResourceAttr(resourceName, "container", "nginx"),
+					resource.TestCheckResourceAttr(resourceName, "image", nginxImageVersion),
+					resource.TestCheckResourceAttr(resourceName, "image_pull_policy", "IfNotPresent"),
+					func(s *terraform.State) error {
+						return testAccCheckKubernetesContainerImage(name, namespace, nginxImageVersion)
+					},
+				),
+			},
+			{
+				Config: testAccKubernetesContainerImageConfig(name, namespace, nginxImageVersion1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "image", nginxImageVersion1),
+					func(s *terraform.State) error {
+						return testAccCheckKubernetesContainerImage(name, namespace, nginxImageVersion1)
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesContainerImage(name, namespace, image string) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	deploy, err := conn.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if got := deploy.Spec.Template.Spec.Containers[0].Image; got != image {
+		return fmt.Errorf("expected the image of deployment %q to be %q, got %q", name, image, got)
+	}
+	return nil
+}
+
+func testAccKubernetesContainerImageConfig(name, namespace, image string) string {
+	return fmt.Sprintf(`resource "kubernetes_container_image" "test" {
+  api_version = "apps/v1"
+  kind        = "Deployment"
+  metadata {
+    name      = %q
+    namespace = %q
+  }
+  container         = "nginx"
+  image             = %q
+  image_pull_policy = "IfNotPresent"
+  wait_for_rollout  = true
+  force             = true
+  field_manager     = "tftest"
+}
+`, name, namespace, image)
+}
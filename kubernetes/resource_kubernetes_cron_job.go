@@ -60,7 +60,7 @@ func resourceKubernetesCronJobCreate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandCronJobSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -95,7 +95,7 @@ func resourceKubernetesCronJobUpdate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandCronJobSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -71,7 +71,7 @@ func resourceKubernetesCSIDriverCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	CSIDriver := storage.CSIDriver{
-		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		ObjectMeta: expandResourceMetadata(d),
 		Spec:       expandCSIDriverSpec(d.Get("spec").([]interface{})),
 	}
 
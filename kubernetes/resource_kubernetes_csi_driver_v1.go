@@ -74,7 +74,7 @@ func resourceKubernetesCSIDriverV1Create(ctx context.Context, d *schema.Resource
 	}
 
 	CSIDriver := storage.CSIDriver{
-		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		ObjectMeta: expandResourceMetadata(d),
 		Spec:       expandCSIDriverV1Spec(d.Get("spec").([]interface{})),
 	}
 
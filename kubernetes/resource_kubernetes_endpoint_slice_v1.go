@@ -0,0 +1,341 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesEndpointSliceV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesEndpointSliceV1Create,
+		ReadContext:   resourceKubernetesEndpointSliceV1Read,
+		UpdateContext: resourceKubernetesEndpointSliceV1Update,
+		DeleteContext: resourceKubernetesEndpointSliceV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("endpoint slice", true),
+			"address_type": {
+				Type:        schema.TypeString,
+				Description: "Type of address carried by this endpoint slice. All addresses in the slice must be of the same type. Supported values are IPv4, IPv6 and FQDN. This field is immutable after creation.",
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(discovery.AddressTypeIPv4),
+					string(discovery.AddressTypeIPv6),
+					string(discovery.AddressTypeFQDN),
+				}, false),
+			},
+			"endpoint": {
+				Type:        schema.TypeList,
+				Description: "List of unique endpoints in this slice. Each slice may include a maximum of 1000 endpoints.",
+				Optional:    true,
+				MaxItems:    1000,
+				Elem:        schemaEndpointSliceV1Endpoint(),
+			},
+			"port": {
+				Type:        schema.TypeList,
+				Description: "List of network ports exposed by each endpoint in this slice. When no ports are defined, the endpoints do not expose any ports. Each slice may include a maximum of 100 ports.",
+				Optional:    true,
+				MaxItems:    100,
+				Elem:        schemaEndpointSliceV1Port(),
+			},
+		},
+	}
+}
+
+func schemaEndpointSliceV1Endpoint() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "Addresses of this endpoint, interpreted according to the address type of the slice. Consumers must handle different types of addresses in the context of their own capabilities.",
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    100,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"condition": {
+				Type:        schema.TypeList,
+				Description: "Current state of the endpoint.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ready": {
+							Type:        schema.TypeBool,
+							Description: "Indicates that this endpoint is prepared to receive traffic. Unset means unknown, which consumers should interpret as ready.",
+							Optional:    true,
+						},
+						"serving": {
+							Type:        schema.TypeBool,
+							Description: "Identical to ready, except that it is set regardless of the terminating state of the endpoint.",
+							Optional:    true,
+						},
+						"terminating": {
+							Type:        schema.TypeBool,
+							Description: "Indicates that this endpoint is terminating. Unset means unknown, which consumers should interpret as not terminating.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "Hostname of this endpoint. Consumers may use it to distinguish endpoints from each other, for example in DNS names.",
+				Optional:    true,
+			},
+			"node_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the node hosting this endpoint.",
+				Optional:    true,
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Description: "Name of the zone this endpoint exists in.",
+				Optional:    true,
+			},
+			"target_ref": {
+				Type:        schema.TypeList,
+				Description: "Reference to the Kubernetes object that represents this endpoint.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "Kind of the referent.",
+							Optional:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the referent.",
+							Optional:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the referent.",
+							Required:    true,
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Description: "UID of the referent.",
+							Optional:    true,
+						},
+						"resource_version": {
+							Type:        schema.TypeString,
+							Description: "Specific resource version to which this reference is made, if any.",
+							Optional:    true,
+						},
+						"field_path": {
+							Type:        schema.TypeString,
+							Description: "If referring to a piece of an object instead of an entire object, this string should contain a valid JSON/Go field access statement, such as `spec.containers{name}`.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaEndpointSliceV1Port() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of this port. All ports in a slice must have a unique name. If the slice is derived from a Service, this corresponds to the name of the port of the Service.",
+				Optional:    true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Description:  "Port number of the endpoint.",
+				Required:     true,
+				ValidateFunc: validatePortNum,
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Description: "IP protocol for this port. Must be UDP, TCP, or SCTP. Defaults to TCP.",
+				Optional:    true,
+				Default:     string(api.ProtocolTCP),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(api.ProtocolTCP),
+					string(api.ProtocolUDP),
+					string(api.ProtocolSCTP),
+				}, false),
+			},
+			"app_protocol": {
+				Type:        schema.TypeString,
+				Description: "Application protocol for this port, such as a IANA standard service name or a domain prefixed name like `example.com/protocol`.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesEndpointSliceV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+	slice := discovery.EndpointSlice{
+		ObjectMeta:  metadata,
+		AddressType: discovery.AddressType(d.Get("address_type").(string)),
+		Endpoints:   expandEndpointSliceV1Endpoints(d.Get("endpoint").([]interface{})),
+		Ports:       expandEndpointSliceV1Ports(d.Get("port").([]interface{})),
+	}
+	log.Printf("[INFO] Creating new endpoint slice: %#v", slice)
+	out, err := conn.DiscoveryV1().EndpointSlices(metadata.Namespace).Create(ctx, &slice, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create endpoint slice because: %s", err)
+	}
+	log.Printf("[INFO] Submitted new endpoint slice: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEndpointSliceV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesEndpointSliceV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesEndpointSliceV1Exists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.Errorf("Failed to read endpoint slice because: %s", err)
+	}
+
+	log.Printf("[INFO] Reading endpoint slice %s", name)
+	slice, err := conn.DiscoveryV1().EndpointSlices(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.Errorf("Failed to read endpoint slice because: %s", err)
+	}
+	log.Printf("[INFO] Received endpoint slice: %#v", slice)
+
+	err = d.Set("metadata", flattenMetadata(slice.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.Errorf("Failed to read endpoint slice because: %s", err)
+	}
+	err = d.Set("address_type", string(slice.AddressType))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("endpoint", flattenEndpointSliceV1Endpoints(slice.Endpoints))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("port", flattenEndpointSliceV1Ports(slice.Ports))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesEndpointSliceV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.Errorf("Failed to update endpoint slice because: %s", err)
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("endpoint") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/endpoints",
+			Value: expandEndpointSliceV1Endpoints(d.Get("endpoint").([]interface{})),
+		})
+	}
+	if d.HasChange("port") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/ports",
+			Value: expandEndpointSliceV1Ports(d.Get("port").([]interface{})),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating endpoint slice %q: %v", name, string(data))
+	out, err := conn.DiscoveryV1().EndpointSlices(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update endpoint slice: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated endpoint slice: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEndpointSliceV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesEndpointSliceV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.Errorf("Failed to delete endpoint slice because: %s", err)
+	}
+	log.Printf("[INFO] Deleting endpoint slice: %#v", name)
+	err = conn.DiscoveryV1().EndpointSlices(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.Errorf("Failed to delete endpoint slice because: %s", err)
+	}
+	log.Printf("[INFO] Endpoint slice %s deleted", name)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceKubernetesEndpointSliceV1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return false, err
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking endpoint slice %s", name)
+	_, err = conn.DiscoveryV1().EndpointSlices(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
@@ -0,0 +1,182 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	api "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesEndpointSliceV1_basic(t *testing.T) {
+	var conf api.EndpointSlice
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_endpoint_slice_v1.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesEndpointSliceV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEndpointSliceV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesEndpointSliceV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "address_type", "IPv4"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint.0.addresses.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint.0.addresses.0", "10.0.0.1"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint.0.condition.0.ready", "true"),
+					resource.TestCheckResourceAttr(resourceName, "port.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "port.0.name", "http"),
+					resource.TestCheckResourceAttr(resourceName, "port.0.port", "80"),
+					resource.TestCheckResourceAttr(resourceName, "port.0.protocol", "TCP"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesEndpointSliceV1Config_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesEndpointSliceV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "endpoint.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint.1.addresses.0", "10.0.0.2"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint.1.condition.0.ready", "false"),
+					resource.TestCheckResourceAttr(resourceName, "port.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "port.1.name", "https"),
+					resource.TestCheckResourceAttr(resourceName, "port.1.port", "443"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesEndpointSliceV1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_endpoint_slice_v1" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.DiscoveryV1().EndpointSlices(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Endpoint slice still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesEndpointSliceV1Exists(n string, obj *api.EndpointSlice) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.DiscoveryV1().EndpointSlices(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesEndpointSliceV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_endpoint_slice_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  address_type = "IPv4"
+
+  endpoint {
+    addresses = ["10.0.0.1"]
+
+    condition {
+      ready = true
+    }
+  }
+
+  port {
+    name = "http"
+    port = 80
+  }
+}
+`, name)
+}
+
+func testAccKubernetesEndpointSliceV1Config_modified(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_endpoint_slice_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  address_type = "IPv4"
+
+  endpoint {
+    addresses = ["10.0.0.1"]
+
+    condition {
+      ready = true
+    }
+  }
+
+  endpoint {
+    addresses = ["10.0.0.2"]
+
+    condition {
+      ready = false
+    }
+  }
+
+  port {
+    name = "http"
+    port = 80
+  }
+
+  port {
+    name = "https"
+    port = 443
+  }
+}
+`, name)
+}
@@ -42,7 +42,7 @@ func resourceKubernetesEndpointsCreate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	ep := api.Endpoints{
 		ObjectMeta: metadata,
 		Subsets:    expandEndpointsSubsets(d.Get("subset").(*schema.Set)),
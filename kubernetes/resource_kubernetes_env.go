@@ -2,23 +2,24 @@ package kubernetes
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-kubernetes/util"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
 )
 
 func resourceKubernetesEnv() *schema.Resource {
@@ -30,6 +31,7 @@ func resourceKubernetesEnv() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceKubernetesEnvCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"metadata": {
 				Type:     schema.TypeList,
@@ -53,9 +55,21 @@ func resourceKubernetesEnv() *schema.Resource {
 				},
 			},
 			"container": {
+				Type:         schema.TypeString,
+				Description:  "Name of the container for which we are updating the environment variables.",
+				Optional:     true,
+				ExactlyOneOf: []string{"container", "init_container"},
+			},
+			"init_container": {
+				Type:         schema.TypeString,
+				Description:  "Name of the init container for which we are updating the environment variables.",
+				Optional:     true,
+				ExactlyOneOf: []string{"container", "init_container"},
+			},
+			"pod_spec_path": {
 				Type:        schema.TypeString,
-				Description: "Name of the container for which we are updating the environment variables.",
-				Required:    true,
+				Description: "Path of the pod spec in the resource, as field names separated by dots (e.g. spec.template.spec). Required for kinds other than Pod, PodTemplate, Deployment, DaemonSet, StatefulSet, ReplicaSet, ReplicationController, Job and CronJob.",
+				Optional:    true,
 			},
 			"api_version": {
 				Type:        schema.TypeString,
@@ -63,15 +77,15 @@ func resourceKubernetesEnv() *schema.Resource {
 				Required:    true,
 			},
 			"kind": {
-				Type:         schema.TypeString,
-				Description:  "Resource Kind",
-				ValidateFunc: validation.StringInSlice([]string{"CronJob", "Deployment", "Pod", "DaemonSet", "replicationcontroller", "StatefulSet", "ReplicaSet"}, true),
-				Required:     true,
+				Type:        schema.TypeString,
+				Description: "Resource Kind",
+				Required:    true,
 			},
 			"env": {
-				Type:        schema.TypeList,
-				Description: "List of custom values used to represent environment variables",
-				Required:    true,
+				Type:         schema.TypeList,
+				Description:  "List of custom values used to represent environment variables",
+				Optional:     true,
+				AtLeastOneOf: []string{"env", "env_from"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -194,6 +208,61 @@ func resourceKubernetesEnv() *schema.Resource {
 					},
 				},
 			},
+			"env_from": {
+				Type:         schema.TypeList,
+				Description:  "List of sources to populate environment variables in the container. The list is replaced as a whole, so it should hold all the sources of the container.",
+				Optional:     true,
+				AtLeastOneOf: []string{"env", "env_from"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"config_map_ref": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The ConfigMap to select from",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+									},
+									"optional": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Description: "Specify whether the ConfigMap must be defined",
+									},
+								},
+							},
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "An optional identifer to prepend to each key in the ConfigMap. Must be a C_IDENTIFIER.",
+						},
+						"secret_ref": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The Secret to select from",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+									},
+									"optional": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Description: "Specify whether the Secret must be defined",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"force": {
 				Type:        schema.TypeBool,
 				Description: "Force overwriting environments that were created or edited outside of Terraform.",
@@ -210,10 +279,11 @@ func resourceKubernetesEnv() *schema.Resource {
 }
 
 func resourceKubernetesEnvCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	d.SetId(buildIdWithVersionKind(metadata,
-		d.Get("api_version").(string),
-		d.Get("kind").(string)))
+	id, err := patchTargetId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
 	diag := resourceKubernetesEnvUpdate(ctx, d, m)
 	if diag.HasError() {
 		d.SetId("")
@@ -251,7 +321,7 @@ func resourceKubernetesEnvRead(ctx context.Context, d *schema.ResourceData, m in
 	var r dynamic.ResourceInterface
 	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
 		if namespace == "" {
-			namespace = "default"
+			namespace = defaultNamespace(m)
 		}
 		r = conn.Resource(mapping.Resource).Namespace(namespace)
 	} else {
@@ -271,6 +341,16 @@ func resourceKubernetesEnvRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	specPath, err := podSpecPath(d.Get("kind").(string), d.Get("pod_spec_path").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	list, containerName := containerTarget(d)
+	container, err := findContainer(res.Object, specPath, list, containerName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// store names of environment variables into map
 	configuredEnvs := make(map[string]interface{})
 	envList := d.Get("env").([]interface{})
@@ -280,19 +360,16 @@ func resourceKubernetesEnvRead(ctx context.Context, d *schema.ResourceData, m in
 
 	// strip out envs not managed by Terraform
 	fieldManagerName := d.Get("field_manager").(string)
-	managedEnvs, err := getManagedEnvs(res.GetManagedFields(), fieldManagerName, d, res)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	responseEnvs, err := getResponseEnvs(res, d.Get("container").(string), d.Get("kind").(string))
+	managedFields, err := managedFieldSet(res.GetManagedFields(), fieldManagerName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	responseEnvs, _ := container["env"].([]interface{})
 
 	env := []interface{}{}
 	for _, e := range responseEnvs {
 		envName := e.(map[string]interface{})["name"].(string)
-		_, managed := managedEnvs[fmt.Sprintf(`k:{"name":%q}`, envName)]
+		managed := managedFields.Has(containerFieldPath(specPath, list, containerName, "env", fieldpath.KeyByFields("name", envName)))
 		_, configured := configuredEnvs[envName]
 		if !managed && !configured {
 			continue
@@ -302,57 +379,27 @@ func resourceKubernetesEnvRead(ctx context.Context, d *schema.ResourceData, m in
 
 	env = flattenEnv(env)
 	d.Set("env", env)
-	return nil
-}
 
-func getResponseEnvs(u *unstructured.Unstructured, containerName string, kind string) ([]interface{}, error) {
-	var containers []interface{}
-
-	containers, _, _ = unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
-
-	if kind == "CronJob" {
-		containers, _, _ = unstructured.NestedSlice(u.Object, "spec", "jobTemplate", "spec", "template", "spec", "containers")
-	}
-
-	for _, c := range containers {
-		container := c.(map[string]interface{})
-		if container["name"].(string) == containerName {
-			return container["env"].([]interface{}), nil
+	// envFrom is replaced as a whole, so read it back only while we manage it
+	envFrom := []interface{}{}
+	if managedFields.Has(containerFieldPath(specPath, list, containerName, "envFrom")) {
+		var c corev1.Container
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(container, &c)
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		envFrom = flattenContainerEnvFroms(c.EnvFrom)
 	}
-	return nil, fmt.Errorf("could not find container with name %q", containerName)
+	d.Set("env_from", envFrom)
+	return nil
 }
 
-// getManagedEnvs reads the field manager metadata to discover which environment variables we're managing
-func getManagedEnvs(managedFields []v1.ManagedFieldsEntry, manager string, d *schema.ResourceData, u *unstructured.Unstructured) (map[string]interface{}, error) {
-	var envs map[string]interface{}
-	kind := d.Get("kind").(string)
-	for _, m := range managedFields {
-		if m.Manager != manager {
-			continue
-		}
-		var mm map[string]interface{}
-		err := json.Unmarshal(m.FieldsV1.Raw, &mm)
-		if err != nil {
-			return nil, err
-		}
-
-		spec, _, err := unstructured.NestedMap(u.Object, "f:spec", "f:template", "f:spec")
-		if "CronJob" == kind {
-			spec, _, err = unstructured.NestedMap(u.Object, "f:spec", "f:jobTemplate", "f:spec", "f:template", "f:spec")
-		}
-		if err == nil {
-			return nil, err
-		}
-
-		containers := spec["f:containers"].(map[string]interface{})
-		containerName := fmt.Sprintf(`k:{"name":%q}`, d.Get("container").(string))
-		k := containers[containerName].(map[string]interface{})
-		if e, ok := k["f:env"].(map[string]interface{}); ok {
-			envs = e
-		}
+func resourceKubernetesEnvCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("kind") || !d.NewValueKnown("pod_spec_path") {
+		return nil
 	}
-	return envs, nil
+	_, err := podSpecPath(d.Get("kind").(string), d.Get("pod_spec_path").(string))
+	return err
 }
 
 func resourceKubernetesEnvUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -363,7 +410,7 @@ func resourceKubernetesEnvUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	apiVersion := d.Get("api_version").(string)
 	kind := d.Get("kind").(string)
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	name := metadata.GetName()
 	namespace := metadata.GetNamespace()
 
@@ -391,7 +438,7 @@ func resourceKubernetesEnvUpdate(ctx context.Context, d *schema.ResourceData, m
 	namespacedResource := mapping.Scope.Name() == meta.RESTScopeNameNamespace
 	if namespacedResource {
 		if namespace == "" {
-			namespace = "default"
+			namespace = defaultNamespace(m)
 		}
 		r = conn.Resource(mapping.Resource).Namespace(namespace)
 	} else {
@@ -422,46 +469,38 @@ func resourceKubernetesEnvUpdate(ctx context.Context, d *schema.ResourceData, m
 		env = []map[string]interface{}{}
 	}
 
-	spec := map[string]interface{}{
-		"template": map[string]interface{}{
-			"spec": map[string]interface{}{
-				"containers": []interface{}{
-					map[string]interface{}{
-						"name": d.Get("container").(string),
-						"env":  env,
-					},
-				},
-			},
-		},
+	specPath, err := podSpecPath(kind, d.Get("pod_spec_path").(string))
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	if kind == "CronJob" {
-		// patch for CronJob
-		spec = map[string]interface{}{
-			"jobTemplate": map[string]interface{}{
-				"spec": map[string]interface{}{
-					"template": map[string]interface{}{
-						"spec": map[string]interface{}{
-							"containers": []interface{}{
-								map[string]interface{}{
-									"name": d.Get("container").(string),
-									"env":  env,
-								},
-							},
-						},
-					},
-				},
-			},
+	list, containerName := containerTarget(d)
+	container := map[string]interface{}{
+		"name": containerName,
+		"env":  env,
+	}
+	if d.Id() != "" {
+		// envFrom is an atomic list, only claim it when configured
+		if v := d.Get("env_from").([]interface{}); len(v) > 0 {
+			envFrom, err := expandContainerEnvFrom(v)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			container["envFrom"] = envFrom
 		}
 	}
+	var spec interface{} = map[string]interface{}{
+		list: []interface{}{container},
+	}
+	for i := len(specPath) - 1; i > 0; i-- {
+		spec = map[string]interface{}{specPath[i]: spec}
+	}
 	patchObj := map[string]interface{}{
 		"apiVersion": apiVersion,
 		"kind":       kind,
 		"metadata":   patchmeta,
-		"spec":       spec,
+		specPath[0]:  spec,
 	}
 
-	// structure for a Deployment kind
-
 	patch := unstructured.Unstructured{}
 	patch.Object = patchObj
 	patchbytes, err := patch.MarshalJSON()
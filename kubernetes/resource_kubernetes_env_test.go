@@ -130,6 +130,38 @@ func TestAccKubernetesEnv_CronJobBasic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesEnv_DeploymentInitContainer(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "default"
+	configMapName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "kubernetes_env.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createInitContainerEnv(t, name, namespace)
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return destroyEnv(name, namespace)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEnv_DeploymentInitContainer(configMapName, name, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "init_container", "init"),
+					resource.TestCheckResourceAttr(resourceName, "env.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "env.0.name", "INIT_MODE"),
+					resource.TestCheckResourceAttr(resourceName, "env.0.value", "fast"),
+					resource.TestCheckResourceAttr(resourceName, "env_from.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "env_from.0.config_map_ref.0.name", configMapName),
+					resource.TestCheckResourceAttr(resourceName, "env_from.0.prefix", "CFG_"),
+				),
+			},
+		},
+	})
+}
+
 func createEnv(t *testing.T, name, namespace string) error {
 	conn, err := testAccProvider.Meta().(kubeClientsets).MainClientset()
 	if err != nil {
@@ -180,6 +212,57 @@ func createEnv(t *testing.T, name, namespace string) error {
 	return err
 }
 
+func createInitContainerEnv(t *testing.T, name, namespace string) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	deploy := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "terraform",
+				},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": "terraform",
+					},
+				},
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{
+						{
+							Name:    "init",
+							Image:   "busybox",
+							Command: []string{"/bin/sh", "-c", "true"},
+						},
+					},
+					Containers: []v1.Container{
+						{
+							Name:  "nginx",
+							Image: "nginx",
+						},
+					},
+				},
+			},
+		},
+	}
+	_, err = conn.AppsV1().Deployments(namespace).Create(ctx, &deploy, metav1.CreateOptions{})
+	if err != nil {
+		t.Error("could not create test deployment")
+		t.Fatal(err)
+	}
+
+	return err
+}
+
 func createCronJobEnv(t *testing.T, name, namespace string) error {
 	conn, err := testAccProvider.Meta().(kubeClientsets).MainClientset()
 	if err != nil {
@@ -546,3 +629,38 @@ resource "kubernetes_env" "demo" {
 }
 	`, secretName, configMapName, name, namespace)
 }
+
+func testAccKubernetesEnv_DeploymentInitContainer(configMapName, name, namespace string) string {
+	return fmt.Sprintf(`resource "kubernetes_config_map" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data = {
+    one = "ONE"
+  }
+}
+
+resource "kubernetes_env" "test" {
+  init_container = "init"
+  api_version    = "apps/v1"
+  kind           = "Deployment"
+  metadata {
+    name      = %q
+    namespace = %q
+  }
+
+  env {
+    name  = "INIT_MODE"
+    value = "fast"
+  }
+
+  env_from {
+    config_map_ref {
+      name = kubernetes_config_map.test.metadata.0.name
+    }
+    prefix = "CFG_"
+  }
+}
+`, configMapName, name, namespace)
+}
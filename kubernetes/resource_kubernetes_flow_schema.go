@@ -0,0 +1,357 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesFlowSchema() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesFlowSchemaCreate,
+		ReadContext:   resourceKubernetesFlowSchemaRead,
+		UpdateContext: resourceKubernetesFlowSchemaUpdate,
+		DeleteContext: resourceKubernetesFlowSchemaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("flow schema", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Specification of the requests that the flow schema classifies. More info: https://kubernetes.io/docs/concepts/cluster-administration/flow-control/",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: flowSchemaSpecFields(),
+				},
+			},
+		},
+	}
+}
+
+func flowSchemaSpecFields() map[string]*schema.Schema {
+	namedSubject := func(kind string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Description: "The " + kind + " that the subject matches. Must be set when `kind` is `" + kind + "`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the " + kind + ", or `*` to match all of them.",
+						Required:    true,
+					},
+				},
+			},
+		}
+	}
+	return map[string]*schema.Schema{
+		"priority_level_configuration": {
+			Type:        schema.TypeList,
+			Description: "Priority level of the requests classified by the flow schema. A flow schema that refers to a priority level that does not exist is ignored.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the priority level configuration.",
+						Required:    true,
+					},
+				},
+			},
+		},
+		"matching_precedence": {
+			Type:         schema.TypeInt,
+			Description:  "Precedence of the flow schema among the flow schemas that match a request. The flow schema with the lowest value is used. Must be between 1 and 10000, defaults to 1000.",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1, 10000),
+		},
+		"distinguisher_method": {
+			Type:        schema.TypeList,
+			Description: "How the flows of the matched requests are identified. When not set, all requests matched by the flow schema are considered the same flow.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:         schema.TypeString,
+						Description:  "Identifies the flows by the user (`ByUser`) or by the namespace (`ByNamespace`) of the requests.",
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"ByUser", "ByNamespace"}, false),
+					},
+				},
+			},
+		},
+		"rule": {
+			Type:        schema.TypeList,
+			Description: "Rules that select the requests classified by the flow schema. A request is matched when any rule matches it. When not set, no request is matched.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"subject": {
+						Type:        schema.TypeList,
+						Description: "Users, groups or service accounts that the rule applies to. The rule matches a request when any subject matches it.",
+						Required:    true,
+						MinItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"kind": {
+									Type:         schema.TypeString,
+									Description:  "Kind of the subject, one of `User`, `Group` or `ServiceAccount`.",
+									Required:     true,
+									ValidateFunc: validation.StringInSlice([]string{"User", "Group", "ServiceAccount"}, false),
+								},
+								"user":  namedSubject("User"),
+								"group": namedSubject("Group"),
+								"service_account": {
+									Type:        schema.TypeList,
+									Description: "The service account that the subject matches. Must be set when `kind` is `ServiceAccount`.",
+									Optional:    true,
+									MaxItems:    1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"namespace": {
+												Type:        schema.TypeString,
+												Description: "Namespace of the service account.",
+												Required:    true,
+											},
+											"name": {
+												Type:        schema.TypeString,
+												Description: "Name of the service account, or `*` to match all the service accounts of the namespace.",
+												Required:    true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					"resource_rule": {
+						Type:        schema.TypeList,
+						Description: "Resource requests that the rule applies to.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"verbs": {
+									Type:        schema.TypeList,
+									Description: "Verbs of the requests, or `*` for all verbs.",
+									Required:    true,
+									MinItems:    1,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+								"api_groups": {
+									Type:        schema.TypeList,
+									Description: "API groups of the resources, or `*` for all groups.",
+									Required:    true,
+									MinItems:    1,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+								"resources": {
+									Type:        schema.TypeList,
+									Description: "Resources of the requests, such as `pods` or `pods/log`, or `*` for all resources.",
+									Required:    true,
+									MinItems:    1,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+								"cluster_scope": {
+									Type:        schema.TypeBool,
+									Description: "Whether the rule matches requests that do not specify a namespace, such as requests for cluster scoped resources.",
+									Optional:    true,
+								},
+								"namespaces": {
+									Type:        schema.TypeList,
+									Description: "Namespaces of the requests, or `*` for all namespaces.",
+									Optional:    true,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+							},
+						},
+					},
+					"non_resource_rule": {
+						Type:        schema.TypeList,
+						Description: "Non-resource requests that the rule applies to.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"verbs": {
+									Type:        schema.TypeList,
+									Description: "Verbs of the requests, or `*` for all verbs.",
+									Required:    true,
+									MinItems:    1,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+								"non_resource_urls": {
+									Type:        schema.TypeList,
+									Description: "URL paths of the requests, such as `/healthz`. A path ending with `/*` matches all the paths under it, and `*` matches all paths.",
+									Required:    true,
+									MinItems:    1,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesFlowSchemaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, gv, err := flowcontrolResource(meta, "flowschemas")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fs := flowSchema{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gv.String(),
+			Kind:       "FlowSchema",
+		},
+		ObjectMeta: expandResourceMetadata(d),
+		Spec:       expandFlowSchemaSpec(d.Get("spec").([]interface{})),
+	}
+	obj, err := toUnstructuredObject(&fs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating new flow schema: %#v", fs)
+	out, err := client.Create(ctx, &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create flow schema: %s", err)
+	}
+	log.Printf("[INFO] Submitted new flow schema: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesFlowSchemaRead(ctx, d, meta)
+}
+
+func resourceKubernetesFlowSchemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesFlowSchemaExists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	fs, err := getFlowSchema(ctx, d.Id(), meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("metadata", flattenMetadata(fs.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenFlowSchemaSpec(fs.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getFlowSchema(ctx context.Context, name string, meta interface{}) (*flowSchema, error) {
+	client, _, err := flowcontrolResource(meta, "flowschemas")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Reading flow schema %s", name)
+	out, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return nil, err
+	}
+	fs := &flowSchema{}
+	if err := fromUnstructuredObject(out.Object, fs); err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] Received flow schema: %#v", fs)
+	return fs, nil
+}
+
+func resourceKubernetesFlowSchemaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := flowcontrolResource(meta, "flowschemas")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: expandFlowSchemaSpec(d.Get("spec").([]interface{})),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating flow schema %q: %v", name, string(data))
+	out, err := client.Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update flow schema: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated flow schema: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesFlowSchemaRead(ctx, d, meta)
+}
+
+func resourceKubernetesFlowSchemaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := flowcontrolResource(meta, "flowschemas")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Deleting flow schema: %#v", name)
+	err = client.Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Flow schema %s deleted", name)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceKubernetesFlowSchemaExists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, _, err := flowcontrolResource(meta, "flowschemas")
+	if err != nil {
+		return false, err
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Checking flow schema %s", name)
+	_, err = client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
@@ -0,0 +1,189 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesFlowSchema_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_flow_schema.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesFlowSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesFlowSchemaConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesFlowSchemaExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.priority_level_configuration.0.name", name),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.matching_precedence", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.rule.0.subject.0.kind", "ServiceAccount"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.rule.0.subject.0.service_account.0.namespace", "default"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.rule.0.resource_rule.0.resources.0", "configmaps"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesFlowSchemaConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesFlowSchemaExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.matching_precedence", "500"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.distinguisher_method.0.type", "ByUser"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.rule.0.subject.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.rule.0.subject.1.group.0.name", "system:authenticated"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.rule.0.non_resource_rule.0.non_resource_urls.0", "/healthz"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesFlowSchemaDestroy(s *terraform.State) error {
+	client, _, err := flowcontrolResource(testAccProvider.Meta(), "flowschemas")
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_flow_schema" {
+			continue
+		}
+
+		name := rs.Primary.ID
+
+		resp, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if resp.GetName() == name {
+				return fmt.Errorf("Flow schema still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesFlowSchemaExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client, _, err := flowcontrolResource(testAccProvider.Meta(), "flowschemas")
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		_, err = client.Get(ctx, rs.Primary.ID, metav1.GetOptions{})
+		return err
+	}
+}
+
+func testAccKubernetesFlowSchemaConfig_basic(name string) string {
+	return testAccKubernetesPriorityLevelConfigurationConfig_basic(name) + fmt.Sprintf(`
+resource "kubernetes_flow_schema" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    priority_level_configuration {
+      name = kubernetes_priority_level_configuration.test.metadata.0.name
+    }
+
+    rule {
+      subject {
+        kind = "ServiceAccount"
+
+        service_account {
+          namespace = "default"
+          name      = "*"
+        }
+      }
+
+      resource_rule {
+        verbs      = ["get", "list"]
+        api_groups = [""]
+        resources  = ["configmaps"]
+        namespaces = ["*"]
+      }
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesFlowSchemaConfig_modified(name string) string {
+	return testAccKubernetesPriorityLevelConfigurationConfig_basic(name) + fmt.Sprintf(`
+resource "kubernetes_flow_schema" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    priority_level_configuration {
+      name = kubernetes_priority_level_configuration.test.metadata.0.name
+    }
+
+    matching_precedence = 500
+
+    distinguisher_method {
+      type = "ByUser"
+    }
+
+    rule {
+      subject {
+        kind = "ServiceAccount"
+
+        service_account {
+          namespace = "default"
+          name      = "*"
+        }
+      }
+
+      subject {
+        kind = "Group"
+
+        group {
+          name = "system:authenticated"
+        }
+      }
+
+      resource_rule {
+        verbs      = ["get", "list"]
+        api_groups = [""]
+        resources  = ["configmaps"]
+        namespaces = ["*"]
+      }
+
+      non_resource_rule {
+        verbs             = ["get"]
+        non_resource_urls = ["/healthz"]
+      }
+    }
+  }
+}
+`, name)
+}
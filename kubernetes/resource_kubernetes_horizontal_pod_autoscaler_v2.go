@@ -33,7 +33,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Create(ctx context.Context, d *s
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandHorizontalPodAutoscalerV2Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
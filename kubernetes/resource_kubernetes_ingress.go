@@ -168,7 +168,7 @@ func resourceKubernetesIngressCreate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	ing := &v1beta1.Ingress{
 		Spec: expandIngressSpec(d.Get("spec").([]interface{})),
 	}
@@ -276,11 +276,11 @@ func resourceKubernetesIngressUpdate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec := expandIngressSpec(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
-		metadata.Namespace = "default"
+		metadata.Namespace = defaultNamespace(meta)
 	}
 
 	ingress := &v1beta1.Ingress{
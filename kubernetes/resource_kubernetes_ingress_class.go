@@ -97,7 +97,7 @@ func resourceKubernetesIngressClassCreate(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	ing := &networking.IngressClass{
 		Spec: expandIngressClassSpec(d.Get("spec").([]interface{})),
 	}
@@ -157,7 +157,7 @@ func resourceKubernetesIngressClassUpdate(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec := expandIngressClassSpec(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
@@ -176,7 +176,7 @@ func resourceKubernetesIngressV1Create(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	ing := &networking.Ingress{
 		Spec: expandIngressV1Spec(d.Get("spec").([]interface{})),
 	}
@@ -284,11 +284,11 @@ func resourceKubernetesIngressV1Update(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec := expandIngressV1Spec(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
-		metadata.Namespace = "default"
+		metadata.Namespace = defaultNamespace(meta)
 	}
 
 	ingress := &networking.Ingress{
@@ -71,7 +71,7 @@ func resourceKubernetesJobCreate(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandJobSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -26,6 +26,7 @@ func resourceKubernetesLabels() *schema.Resource {
 		ReadContext:   resourceKubernetesLabelsRead,
 		UpdateContext: resourceKubernetesLabelsUpdate,
 		DeleteContext: resourceKubernetesLabelsDelete,
+		CustomizeDiff: metadataSelectorCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"api_version": {
 				Type:        schema.TypeString,
@@ -40,9 +41,10 @@ func resourceKubernetesLabels() *schema.Resource {
 				ForceNew:    true,
 			},
 			"metadata": {
-				Type:     schema.TypeList,
-				Required: true,
-				MaxItems: 1,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"metadata", "selector"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -65,6 +67,8 @@ func resourceKubernetesLabels() *schema.Resource {
 				Description: "A map of labels to apply to the resource.",
 				Required:    true,
 			},
+			"selector": metadataSelectorSchema("labels"),
+			"objects":  metadataSelectorObjectsSchema("labels"),
 			"force": {
 				Type:        schema.TypeBool,
 				Description: "Force overwriting labels that were created or edited outside of Terraform.",
@@ -82,10 +86,14 @@ func resourceKubernetesLabels() *schema.Resource {
 }
 
 func resourceKubernetesLabelsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	d.SetId(buildIdWithVersionKind(metadata,
-		d.Get("api_version").(string),
-		d.Get("kind").(string)))
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorCreate(ctx, d, m, "labels")
+	}
+	id, err := patchTargetId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
 	diag := resourceKubernetesLabelsUpdate(ctx, d, m)
 	if diag.HasError() {
 		d.SetId("")
@@ -94,6 +102,10 @@ func resourceKubernetesLabelsCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceKubernetesLabelsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorRead(ctx, d, m, "labels")
+	}
+
 	conn, err := m.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
@@ -123,7 +135,7 @@ func resourceKubernetesLabelsRead(ctx context.Context, d *schema.ResourceData, m
 	var r dynamic.ResourceInterface
 	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
 		if namespace == "" {
-			namespace = "default"
+			namespace = defaultNamespace(m)
 		}
 		r = conn.Resource(mapping.Resource).Namespace(namespace)
 	} else {
@@ -185,6 +197,10 @@ func getManagedLabels(managedFields []v1.ManagedFieldsEntry, manager string) (ma
 }
 
 func resourceKubernetesLabelsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorUpdate(ctx, d, m, "labels")
+	}
+
 	conn, err := m.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
@@ -192,7 +208,7 @@ func resourceKubernetesLabelsUpdate(ctx context.Context, d *schema.ResourceData,
 
 	apiVersion := d.Get("api_version").(string)
 	kind := d.Get("kind").(string)
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	name := metadata.GetName()
 	namespace := metadata.GetNamespace()
 
@@ -221,7 +237,7 @@ func resourceKubernetesLabelsUpdate(ctx context.Context, d *schema.ResourceData,
 	namespacedResource := mapping.Scope.Name() == meta.RESTScopeNameNamespace
 	if namespacedResource {
 		if namespace == "" {
-			namespace = "default"
+			namespace = defaultNamespace(m)
 		}
 		r = conn.Resource(mapping.Resource).Namespace(namespace)
 	} else {
@@ -293,6 +309,9 @@ func resourceKubernetesLabelsUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceKubernetesLabelsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isMetadataSelectorMode(d) {
+		return resourceKubernetesMetadataSelectorDelete(ctx, d, m, "labels")
+	}
 	d.SetId("")
 	return resourceKubernetesLabelsUpdate(ctx, d, m)
 }
@@ -0,0 +1,219 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	coordination "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesLeaseV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesLeaseV1Create,
+		ReadContext:   resourceKubernetesLeaseV1Read,
+		UpdateContext: resourceKubernetesLeaseV1Update,
+		DeleteContext: resourceKubernetesLeaseV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("lease", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Specification of the lease. More info: https://kubernetes.io/docs/concepts/architecture/leases/",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"holder_identity": {
+							Type:        schema.TypeString,
+							Description: "Identity of the holder of the lease.",
+							Optional:    true,
+						},
+						"lease_duration_seconds": {
+							Type:         schema.TypeInt,
+							Description:  "Duration that candidates for the lease need to wait to force acquire it, measured against the time of the last observed renew time.",
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"acquire_time": {
+							Type:             schema.TypeString,
+							Description:      "Time at which the current lease was acquired, in RFC 3339 format.",
+							Optional:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppressEquivalentRFC3339Time,
+						},
+						"renew_time": {
+							Type:             schema.TypeString,
+							Description:      "Time at which the current holder of the lease last updated it, in RFC 3339 format.",
+							Optional:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppressEquivalentRFC3339Time,
+						},
+						"lease_transitions": {
+							Type:         schema.TypeInt,
+							Description:  "Number of transitions of the lease between holders.",
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesLeaseV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+	spec, err := expandLeaseV1Spec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	lease := coordination.Lease{
+		ObjectMeta: metadata,
+		Spec:       spec,
+	}
+	log.Printf("[INFO] Creating new lease: %#v", lease)
+	out, err := conn.CoordinationV1().Leases(metadata.Namespace).Create(ctx, &lease, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create lease: %s", err)
+	}
+	log.Printf("[INFO] Submitted new lease: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesLeaseV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesLeaseV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesLeaseV1Exists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading lease %s", name)
+	lease, err := conn.CoordinationV1().Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received lease: %#v", lease)
+
+	err = d.Set("metadata", flattenMetadata(lease.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenLeaseV1Spec(lease.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesLeaseV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		spec, err := expandLeaseV1Spec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: spec,
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating lease %q: %v", name, string(data))
+	out, err := conn.CoordinationV1().Leases(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update lease: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated lease: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesLeaseV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesLeaseV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Deleting lease: %#v", name)
+	err = conn.CoordinationV1().Leases(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Lease %s deleted", name)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceKubernetesLeaseV1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return false, err
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking lease %s", name)
+	_, err = conn.CoordinationV1().Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
@@ -0,0 +1,144 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	api "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesLeaseV1_basic(t *testing.T) {
+	var conf api.Lease
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_lease_v1.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesLeaseV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesLeaseV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesLeaseV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.holder_identity", "terraform"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.lease_duration_seconds", "30"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.acquire_time", "2024-01-01T00:00:00Z"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesLeaseV1Config_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesLeaseV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.holder_identity", "someone-else"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.lease_duration_seconds", "60"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.lease_transitions", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesLeaseV1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_lease_v1" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.CoordinationV1().Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Lease still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesLeaseV1Exists(n string, obj *api.Lease) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.CoordinationV1().Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesLeaseV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_lease_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    holder_identity        = "terraform"
+    lease_duration_seconds = 30
+    acquire_time           = "2024-01-01T00:00:00Z"
+  }
+}
+`, name)
+}
+
+func testAccKubernetesLeaseV1Config_modified(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_lease_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    holder_identity        = "someone-else"
+    lease_duration_seconds = 60
+    acquire_time           = "2024-01-01T00:00:00Z"
+    renew_time             = "2024-01-01T00:01:00.123456Z"
+    lease_transitions      = 1
+  }
+}
+`, name)
+}
@@ -0,0 +1,414 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// The kubernetes_labels and kubernetes_annotations resources either target a
+// single object named in their metadata, or every object of their kind
+// matched by their selector. The functions in this file implement the
+// selector mode for both resources, field being "labels" or "annotations".
+
+func metadataSelectorSchema(field string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Description:  fmt.Sprintf("Apply the %s to every object of the kind matched by this selector, instead of a single object.", field),
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: []string{"metadata", "selector"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"label_selector": {
+					Type:        schema.TypeList,
+					Description: "A label query over the objects. Matches all the objects of the kind when empty.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: labelSelectorFields(true),
+					},
+				},
+				"namespace_selector": {
+					Type:        schema.TypeList,
+					Description: "A label query over the namespaces of the objects. Matches all the namespaces when empty.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: labelSelectorFields(true),
+					},
+				},
+			},
+		},
+	}
+}
+
+func metadataSelectorObjectsSchema(field string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: fmt.Sprintf("The objects matched by the selector that hold the %s, as namespace/name or name for cluster-scoped objects.", field),
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+	}
+}
+
+func isMetadataSelectorMode(d resourceGetter) bool {
+	return len(d.Get("selector").([]interface{})) > 0
+}
+
+// resourceGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff.
+type resourceGetter interface {
+	Get(string) interface{}
+}
+
+func metadataSelectorResource(m interface{}, apiVersion, kind string) (dynamic.NamespaceableResourceInterface, bool, error) {
+	conn, err := m.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return nil, false, err
+	}
+	dc, err := m.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return nil, false, err
+	}
+	agr, err := restmapper.GetAPIGroupResources(dc)
+	if err != nil {
+		return nil, false, err
+	}
+	gv, err := k8sschema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, false, err
+	}
+	mapping, err := restmapper.NewDiscoveryRESTMapper(agr).RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	return conn.Resource(mapping.Resource), mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+func metadataSelectorObjectKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func parseMetadataSelectorObjectKey(key string) (string, string) {
+	if i := strings.Index(key, "/"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// listMetadataSelectorObjects returns the keys of the objects matched by the
+// selector of the resource.
+func listMetadataSelectorObjects(ctx context.Context, d resourceGetter, m interface{}) ([]string, error) {
+	objects, err := matchMetadataSelectorObjects(ctx, d, m)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(objects))
+	for k := range objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// matchMetadataSelectorObjects returns the objects matched by the selector of
+// the resource, by key.
+func matchMetadataSelectorObjects(ctx context.Context, d resourceGetter, m interface{}) (map[string]*unstructured.Unstructured, error) {
+	r, namespaced, err := metadataSelectorResource(m, d.Get("api_version").(string), d.Get("kind").(string))
+	if err != nil {
+		return nil, err
+	}
+	var selector map[string]interface{}
+	if s := d.Get("selector").([]interface{}); len(s) > 0 && s[0] != nil {
+		selector = s[0].(map[string]interface{})
+	}
+	labelSelector, err := v1.LabelSelectorAsSelector(expandLabelSelector(selectorField(selector, "label_selector")))
+	if err != nil {
+		return nil, err
+	}
+	opts := v1.ListOptions{LabelSelector: labelSelector.String()}
+
+	namespaces := []string{v1.NamespaceAll}
+	if nsSelector := selectorField(selector, "namespace_selector"); namespaced && len(nsSelector) > 0 {
+		s, err := v1.LabelSelectorAsSelector(expandLabelSelector(nsSelector))
+		if err != nil {
+			return nil, err
+		}
+		conn, err := m.(KubeClientsets).MainClientset()
+		if err != nil {
+			return nil, err
+		}
+		nsList, err := conn.CoreV1().Namespaces().List(ctx, v1.ListOptions{LabelSelector: s.String()})
+		if err != nil {
+			return nil, err
+		}
+		namespaces = []string{}
+		for _, ns := range nsList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	objects := map[string]*unstructured.Unstructured{}
+	for _, ns := range namespaces {
+		var list *unstructured.UnstructuredList
+		if namespaced {
+			list, err = r.Namespace(ns).List(ctx, opts)
+		} else {
+			list, err = r.List(ctx, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			o := &list.Items[i]
+			objects[metadataSelectorObjectKey(o.GetNamespace(), o.GetName())] = o
+		}
+	}
+	return objects, nil
+}
+
+func selectorField(selector map[string]interface{}, name string) []interface{} {
+	if selector == nil {
+		return nil
+	}
+	l, _ := selector[name].([]interface{})
+	return l
+}
+
+// metadataSelectorApplied tells whether all the given labels or annotations
+// are set on obj and managed by manager.
+func metadataSelectorApplied(obj *unstructured.Unstructured, field string, values map[string]interface{}, manager string) (bool, error) {
+	set, err := managedFieldSet(obj.GetManagedFields(), manager)
+	if err != nil {
+		return false, err
+	}
+	current, _, _ := unstructured.NestedStringMap(obj.Object, "metadata", field)
+	for k, v := range values {
+		if current[k] != v {
+			return false, nil
+		}
+		if !set.Has(fieldpath.MakePathOrDie("metadata", field, k)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func resourceKubernetesMetadataSelectorCreate(ctx context.Context, d *schema.ResourceData, m interface{}, field string) diag.Diagnostics {
+	d.SetId(fmt.Sprintf("apiVersion=%v,kind=%v,selector=%s",
+		d.Get("api_version").(string),
+		d.Get("kind").(string),
+		resource.UniqueId()))
+	diag := resourceKubernetesMetadataSelectorUpdate(ctx, d, m, field)
+	if diag.HasError() {
+		d.SetId("")
+	}
+	return diag
+}
+
+// resourceKubernetesMetadataSelectorRead records the objects that hold the
+// labels or annotations of the resource: the objects matched by the
+// selector, and the objects that were matched before and still hold them.
+// A matched object missing some of them is left out, so that the plan
+// applies them again. The listed objects carry their managed fields, so only
+// the objects that stopped matching are fetched.
+func resourceKubernetesMetadataSelectorRead(ctx context.Context, d *schema.ResourceData, m interface{}, field string) diag.Diagnostics {
+	candidates, err := matchMetadataSelectorObjects(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var r dynamic.NamespaceableResourceInterface
+	var namespaced bool
+	for _, k := range d.Get("objects").(*schema.Set).List() {
+		key := k.(string)
+		if _, ok := candidates[key]; ok {
+			continue
+		}
+		if r == nil {
+			r, namespaced, err = metadataSelectorResource(m, d.Get("api_version").(string), d.Get("kind").(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		namespace, name := parseMetadataSelectorObjectKey(key)
+		var res *unstructured.Unstructured
+		if namespaced {
+			res, err = r.Namespace(namespace).Get(ctx, name, v1.GetOptions{})
+		} else {
+			res, err = r.Get(ctx, name, v1.GetOptions{})
+		}
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		candidates[key] = res
+	}
+
+	values := d.Get(field).(map[string]interface{})
+	manager := d.Get("field_manager").(string)
+	objects := []string{}
+	for k, res := range candidates {
+		applied, err := metadataSelectorApplied(res, field, values, manager)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if applied {
+			objects = append(objects, k)
+		}
+	}
+	d.Set("objects", objects)
+	return nil
+}
+
+// metadataSelectorCustomizeDiff plans the objects currently matched by the
+// selector, so that newly matched objects and objects that stopped matching
+// show up as a change.
+func metadataSelectorCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !isMetadataSelectorMode(d) {
+		return nil
+	}
+	if d.Id() == "" || !d.NewValueKnown("selector") || !d.NewValueKnown("api_version") || !d.NewValueKnown("kind") {
+		return d.SetNewComputed("objects")
+	}
+	matched, err := listMetadataSelectorObjects(ctx, d, m)
+	if err != nil {
+		return err
+	}
+	current := []string{}
+	for _, k := range d.Get("objects").(*schema.Set).List() {
+		current = append(current, k.(string))
+	}
+	sort.Strings(current)
+	if reflect.DeepEqual(current, matched) {
+		return nil
+	}
+	return d.SetNew("objects", matched)
+}
+
+func resourceKubernetesMetadataSelectorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, field string) diag.Diagnostics {
+	apiVersion := d.Get("api_version").(string)
+	kind := d.Get("kind").(string)
+	r, namespaced, err := metadataSelectorResource(m, apiVersion, kind)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	o, _ := d.GetChange("objects")
+	previous := map[string]bool{}
+	for _, k := range o.(*schema.Set).List() {
+		previous[k.(string)] = true
+	}
+	matched := []string{}
+	if d.Id() != "" {
+		matched, err = listMetadataSelectorObjects(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	apply := func(key string, values interface{}) error {
+		namespace, name := parseMetadataSelectorObjectKey(key)
+		patchmeta := map[string]interface{}{
+			"name": name,
+			field:  values,
+		}
+		ri := dynamic.ResourceInterface(r)
+		if namespaced {
+			patchmeta["namespace"] = namespace
+			ri = r.Namespace(namespace)
+		}
+		patch := unstructured.Unstructured{}
+		patch.Object = map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata":   patchmeta,
+		}
+		patchbytes, err := patch.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		_, err = ri.Patch(ctx,
+			name,
+			types.ApplyPatchType,
+			patchbytes,
+			v1.PatchOptions{
+				FieldManager: d.Get("field_manager").(string),
+				Force:        ptrToBool(d.Get("force").(bool)),
+			},
+		)
+		return err
+	}
+
+	var diags diag.Diagnostics
+	applied := []string{}
+	for _, k := range matched {
+		log.Printf("[INFO] Applying %s to %s %q", field, kind, k)
+		err := apply(k, d.Get(field))
+		if err != nil {
+			if errors.IsConflict(err) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Field manager conflict",
+					Detail:   fmt.Sprintf(`Another client is managing a field Terraform tried to update on %s %q. Set "force" to true to override: %v`, kind, k, err),
+				})
+				continue
+			}
+			// the object may have been deleted since it was listed
+			if errors.IsNotFound(err) {
+				continue
+			}
+			diags = append(diags, diag.Errorf("Failed to apply %s to %s %q: %s", field, kind, k, err)...)
+			continue
+		}
+		applied = append(applied, k)
+		delete(previous, k)
+	}
+
+	// remove the ownership of the objects that stopped matching, or of all
+	// the objects if we're deleting
+	for k := range previous {
+		log.Printf("[INFO] Removing %s from %s %q", field, kind, k)
+		err := apply(k, map[string]interface{}{})
+		if err != nil && !errors.IsNotFound(err) {
+			diags = append(diags, diag.Errorf("Failed to remove %s from %s %q: %s", field, kind, k, err)...)
+			if d.Id() != "" {
+				// keep the object so that the removal is retried
+				applied = append(applied, k)
+			}
+		}
+	}
+
+	if d.Id() == "" {
+		return diags
+	}
+	d.Set("objects", applied)
+	return diags
+}
+
+func resourceKubernetesMetadataSelectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}, field string) diag.Diagnostics {
+	d.SetId("")
+	return resourceKubernetesMetadataSelectorUpdate(ctx, d, m, field)
+}
@@ -0,0 +1,231 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestMetadataSelectorApplied(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetLabels(map[string]string{
+		"team":        "platform",
+		"cost-center": "1234",
+	})
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:    "Terraform",
+			Operation:  metav1.ManagedFieldsOperationApply,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}}}`)},
+		},
+		{
+			Manager:    "kubectl",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:cost-center":{}}}}`)},
+		},
+	})
+
+	cases := []struct {
+		Values   map[string]interface{}
+		Expected bool
+	}{
+		{map[string]interface{}{"team": "platform"}, true},
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"team": "apps"}, false},
+		{map[string]interface{}{"cost-center": "1234"}, false},
+		{map[string]interface{}{"team": "platform", "env": "prod"}, false},
+	}
+	for _, tc := range cases {
+		applied, err := metadataSelectorApplied(obj, "labels", tc.Values, "Terraform")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if applied != tc.Expected {
+			t.Errorf("%v: expected %t, got %t", tc.Values, tc.Expected, applied)
+		}
+	}
+}
+
+func TestResourceKubernetesMetadataSelectorRead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var out interface{}
+		switch r.URL.Path {
+		case "/api":
+			out = &metav1.APIVersions{Versions: []string{"v1"}}
+		case "/apis":
+			out = &metav1.APIGroupList{}
+		case "/api/v1":
+			out = &metav1.APIResourceList{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list"}}},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(out)
+	}))
+	defer srv.Close()
+
+	configMap := func(name string, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		obj.SetLabels(labels)
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+			Manager:    defaultFieldManagerName,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}}}`)},
+		}})
+		return obj
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[k8sschema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+		configMap("matched", map[string]string{"app": "web", "team": "platform"}),
+		// matched before, and still holds the labels
+		configMap("unmatched", map[string]string{"app": "db", "team": "platform"}),
+	)
+	meta := kubeClientsets{
+		config:        &restclient.Config{Host: srv.URL},
+		dynamicClient: client,
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceKubernetesLabels().Schema, map[string]interface{}{
+		"api_version": "v1",
+		"kind":        "ConfigMap",
+		"labels":      map[string]interface{}{"team": "platform"},
+		"selector": []interface{}{map[string]interface{}{
+			"label_selector": []interface{}{map[string]interface{}{
+				"match_labels": map[string]interface{}{"app": "web"},
+			}},
+		}},
+	})
+	d.SetId("test")
+	d.Set("objects", []interface{}{"default/matched", "default/unmatched", "default/deleted"})
+
+	if diags := resourceKubernetesMetadataSelectorRead(context.Background(), d, meta, "labels"); diags.HasError() {
+		t.Fatal(diags)
+	}
+	objects := d.Get("objects").(*schema.Set)
+	if objects.Len() != 2 || !objects.Contains("default/matched") || !objects.Contains("default/unmatched") {
+		t.Errorf("expected the matched and the unmatched objects, got %v", objects.List())
+	}
+	// the matched object is read from the list
+	gets := 0
+	for _, a := range client.Actions() {
+		if a.GetVerb() == "get" {
+			gets++
+		}
+	}
+	if gets != 2 {
+		t.Errorf("expected the objects that stopped matching to be fetched only, got %d requests", gets)
+	}
+}
+
+func TestMetadataSelectorObjectKey(t *testing.T) {
+	for _, tc := range []struct{ Namespace, Name string }{
+		{"default", "test"},
+		{"", "cluster-wide"},
+	} {
+		namespace, name := parseMetadataSelectorObjectKey(metadataSelectorObjectKey(tc.Namespace, tc.Name))
+		if namespace != tc.Namespace || name != tc.Name {
+			t.Errorf("expected %q %q, got %q %q", tc.Namespace, tc.Name, namespace, name)
+		}
+	}
+}
+
+func TestAccKubernetesLabels_selector(t *testing.T) {
+	prefix := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "default"
+	names := []string{prefix + "-one", prefix + "-two"}
+	resourceName := "kubernetes_labels.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			for _, name := range names {
+				createLabelledConfigMap(t, name, namespace, prefix)
+			}
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			for _, name := range names {
+				if err := destroyConfigMap(name, namespace); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesLabels_selector(prefix),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "objects.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "objects.*", namespace+"/"+names[0]),
+					resource.TestCheckTypeSetElemAttr(resourceName, "objects.*", namespace+"/"+names[1]),
+					resource.TestCheckResourceAttr(resourceName, "labels.cost-center", "1234"),
+				),
+			},
+		},
+	})
+}
+
+func createLabelledConfigMap(t *testing.T, name, namespace, group string) {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := v1.ConfigMap{}
+	cm.SetName(name)
+	cm.SetNamespace(namespace)
+	cm.SetLabels(map[string]string{"tf-acc-test": group})
+	_, err = conn.CoreV1().ConfigMaps(namespace).Create(context.Background(), &cm, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testAccKubernetesLabels_selector(group string) string {
+	return fmt.Sprintf(`resource "kubernetes_labels" "test" {
+  api_version = "v1"
+  kind        = "ConfigMap"
+  selector {
+    label_selector {
+      match_labels = {
+        "tf-acc-test" = %q
+      }
+    }
+    namespace_selector {
+      match_labels = {
+        "kubernetes.io/metadata.name" = "default"
+      }
+    }
+  }
+  labels = {
+    "cost-center" = "1234"
+  }
+  field_manager = "tftest"
+}
+`, group)
+}
@@ -146,7 +146,7 @@ func resourceKubernetesMutatingWebhookConfigurationCreate(ctx context.Context, d
 	}
 
 	cfg := admissionregistrationv1.MutatingWebhookConfiguration{
-		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		ObjectMeta: expandResourceMetadata(d),
 		Webhooks:   expandMutatingWebhooks(d.Get("webhook").([]interface{})),
 	}
 
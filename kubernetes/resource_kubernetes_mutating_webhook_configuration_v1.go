@@ -144,7 +144,7 @@ func resourceKubernetesMutatingWebhookConfigurationV1Create(ctx context.Context,
 	}
 
 	cfg := admissionregistrationv1.MutatingWebhookConfiguration{
-		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		ObjectMeta: expandResourceMetadata(d),
 		Webhooks:   expandMutatingWebhooks(d.Get("webhook").([]interface{})),
 	}
 
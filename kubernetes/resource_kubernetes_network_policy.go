@@ -241,7 +241,7 @@ func resourceKubernetesNetworkPolicyCreate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandNetworkPolicySpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
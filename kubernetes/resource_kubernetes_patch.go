@@ -0,0 +1,362 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	patchTypeApply     = "apply"
+	patchTypeStrategic = "strategic"
+	patchTypeJSON      = "json"
+)
+
+var patchTypes = map[string]types.PatchType{
+	patchTypeApply:     types.ApplyPatchType,
+	patchTypeStrategic: types.StrategicMergePatchType,
+	patchTypeJSON:      types.JSONPatchType,
+}
+
+func resourceKubernetesPatch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesPatchCreate,
+		ReadContext:   resourceKubernetesPatchRead,
+		UpdateContext: resourceKubernetesPatchUpdate,
+		DeleteContext: resourceKubernetesPatchDelete,
+		CustomizeDiff: resourceKubernetesPatchCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"api_version": {
+				Type:        schema.TypeString,
+				Description: "The apiVersion of the resource to patch.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Description: "The kind of the resource to patch.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the resource.",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "The namespace of the resource.",
+							Optional:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the patch: a partial manifest applied with server-side apply (`apply`), a strategic merge patch (`strategic`) or a JSON patch (`json`).",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      patchTypeApply,
+				ValidateFunc: validation.StringInSlice([]string{patchTypeApply, patchTypeStrategic, patchTypeJSON}, false),
+			},
+			"patch": {
+				Type:             schema.TypeString,
+				Description:      "The patch, as a JSON document. For `apply` patches, a partial manifest of the fields to manage, without apiVersion, kind and name.",
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentPatch,
+			},
+			"inverse_patch": {
+				Type:             schema.TypeString,
+				Description:      "A patch of the same type applied when the resource is destroyed, to revert the changes of `patch`. By default, the fields managed by an `apply` patch are removed on destroy, and the other types of patches are not reverted.",
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentPatch,
+			},
+			"force": {
+				Type:        schema.TypeBool,
+				Description: "Force applying an `apply` patch over fields managed by other field managers.",
+				Optional:    true,
+			},
+			"field_manager": {
+				Type:         schema.TypeString,
+				Description:  "Set the name of the field manager for the patch.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultFieldManagerName,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"managed_fields": {
+				Type:        schema.TypeString,
+				Description: "The fields of the resource managed by the field manager of the patch, with their current values, as a JSON document.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func suppressEquivalentPatch(k, old, new string, d *schema.ResourceData) bool {
+	return patchDocumentsEqual(old, new)
+}
+
+func resourceKubernetesPatchCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	patchType := diff.Get("type").(string)
+	for _, k := range []string{"patch", "inverse_patch"} {
+		if !diff.NewValueKnown(k) {
+			continue
+		}
+		p := diff.Get(k).(string)
+		if p == "" {
+			continue
+		}
+		var doc interface{}
+		if err := json.Unmarshal([]byte(p), &doc); err != nil {
+			return fmt.Errorf("%s: %s", k, err)
+		}
+		switch doc.(type) {
+		case []interface{}:
+			if patchType != patchTypeJSON {
+				return fmt.Errorf("%s: a %s patch must be a JSON object", k, patchType)
+			}
+		case map[string]interface{}:
+			if patchType == patchTypeJSON {
+				return fmt.Errorf("%s: a JSON patch must be a list of operations", k)
+			}
+		default:
+			return fmt.Errorf("%s: the patch must be a JSON object or list", k)
+		}
+	}
+	return nil
+}
+
+func resourceKubernetesPatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, err := patchTargetId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+	diag := resourceKubernetesPatchUpdate(ctx, d, m)
+	if diag.HasError() {
+		d.SetId("")
+	}
+	return diag
+}
+
+// patchResourceInterface returns the client of the resource targeted by the
+// patch, and its namespace, which defaults to the namespace of the provider
+// for namespaced kinds.
+func patchResourceInterface(m interface{}, apiVersion, kind, namespace string) (dynamic.ResourceInterface, string, error) {
+	conn, err := m.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return nil, "", err
+	}
+	mapper, err := m.(KubeClientsets).RESTMapper()
+	if err != nil {
+		return nil, "", err
+	}
+	gv, err := k8sschema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, "", err
+	}
+	mapping, err := mapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, "", err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return conn.Resource(mapping.Resource), "", nil
+	}
+	if namespace == "" {
+		namespace = defaultNamespace(m)
+	}
+	return conn.Resource(mapping.Resource).Namespace(namespace), namespace, nil
+}
+
+// patchTargetId returns the ID of a resource that changes the existing object
+// described by d. It includes the namespace the object defaults to, so it
+// keeps pointing at the same object if the namespace of the provider changes.
+func patchTargetId(d *schema.ResourceData, m interface{}) (string, error) {
+	apiVersion := d.Get("api_version").(string)
+	kind := d.Get("kind").(string)
+	metadata := expandResourceMetadata(d)
+	_, namespace, err := patchResourceInterface(m, apiVersion, kind, metadata.GetNamespace())
+	if err != nil {
+		return "", err
+	}
+	metadata.SetNamespace(namespace)
+	return buildIdWithVersionKind(metadata, apiVersion, kind), nil
+}
+
+func resourceKubernetesPatchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	gvk, name, namespace, err := util.ParseResourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	r, _, err := patchResourceInterface(m, apiVersion, kind, namespace)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := r.Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Resource deleted",
+				Detail:   fmt.Sprintf("The underlying resource %q has been deleted. You should recreate the underlying resource, or remove it from your configuration.", name),
+			}}
+		}
+		return diag.FromErr(err)
+	}
+
+	// only read back the fields managed by the patch
+	set, err := managedFieldSet(res.GetManagedFields(), d.Get("field_manager").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managed := extractManagedFields(res.Object, set)
+	mf, err := json.Marshal(managed)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("managed_fields", string(mf))
+
+	// fields of an apply patch that were changed or taken over by other
+	// field managers show up as a difference with the configuration
+	if d.Get("type").(string) == patchTypeApply {
+		var p map[string]interface{}
+		if err := json.Unmarshal([]byte(d.Get("patch").(string)), &p); err != nil {
+			return diag.FromErr(err)
+		}
+		delete(p, "apiVersion")
+		delete(p, "kind")
+		if md, ok := p["metadata"].(map[string]interface{}); ok {
+			delete(md, "name")
+			delete(md, "namespace")
+			if len(md) == 0 {
+				delete(p, "metadata")
+			}
+		}
+		pp, err := json.Marshal(p)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !patchDocumentsEqual(string(pp), string(mf)) {
+			d.Set("patch", string(mf))
+		}
+	}
+	return nil
+}
+
+func resourceKubernetesPatchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiVersion := d.Get("api_version").(string)
+	kind := d.Get("kind").(string)
+	metadata := expandResourceMetadata(d)
+	name := metadata.GetName()
+	r, namespace, err := patchResourceInterface(m, apiVersion, kind, metadata.GetNamespace())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// check the resource exists before we try and patch it
+	_, err = r.Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		if d.Id() == "" {
+			// if we are deleting then there is nothing to do
+			// if the resource is gone
+			return nil
+		}
+		return diag.Errorf("The resource %q does not exist", name)
+	}
+
+	patchType := d.Get("type").(string)
+	patch := d.Get("patch").(string)
+	if d.Id() == "" {
+		// if we're deleting then we apply the inverse patch, or an empty
+		// manifest to remove the fields managed by an apply patch
+		patch = d.Get("inverse_patch").(string)
+		if patch == "" {
+			if patchType != patchTypeApply {
+				return diag.Diagnostics{{
+					Severity: diag.Warning,
+					Summary:  "Patch not reverted",
+					Detail:   fmt.Sprintf("The %s patch of %q was not reverted, as no inverse_patch is set.", patchType, name),
+				}}
+			}
+			patch = "{}"
+		}
+	}
+
+	body := []byte(patch)
+	if patchType == patchTypeApply {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return diag.FromErr(err)
+		}
+		md, _ := obj["metadata"].(map[string]interface{})
+		if md == nil {
+			md = map[string]interface{}{}
+		}
+		md["name"] = name
+		if namespace != "" {
+			md["namespace"] = namespace
+		}
+		obj["apiVersion"] = apiVersion
+		obj["kind"] = kind
+		obj["metadata"] = md
+		body, err = json.Marshal(obj)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	opts := v1.PatchOptions{
+		FieldManager: d.Get("field_manager").(string),
+	}
+	if patchType == patchTypeApply {
+		opts.Force = ptrToBool(d.Get("force").(bool))
+	}
+	log.Printf("[INFO] Applying %s patch to %q: %s", patchType, name, body)
+	_, err = r.Patch(ctx, name, patchTypes[patchType], body, opts)
+	if err != nil {
+		if errors.IsConflict(err) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Field manager conflict",
+				Detail:   fmt.Sprintf(`Another client is managing a field Terraform tried to update. Set "force" to true to override: %v`, err),
+			}}
+		}
+		return diag.FromErr(err)
+	}
+
+	if d.Id() == "" {
+		// don't try to read if we're deleting
+		return nil
+	}
+	return resourceKubernetesPatchRead(ctx, d, m)
+}
+
+func resourceKubernetesPatchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return resourceKubernetesPatchUpdate(ctx, d, m)
+}
@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestPatchResourceInterface_discovery(t *testing.T) {
+	var discoveries int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var out interface{}
+		switch r.URL.Path {
+		case "/api":
+			discoveries++
+			out = &metav1.APIVersions{Versions: []string{"v1"}}
+		case "/apis":
+			out = &metav1.APIGroupList{}
+		case "/api/v1":
+			out = &metav1.APIResourceList{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "patch"}},
+					{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "patch"}},
+				},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(out)
+	}))
+	defer srv.Close()
+
+	m := kubeClientsets{
+		config:     &restclient.Config{Host: srv.URL},
+		restMapper: &cachedRESTMapper{},
+		Namespace:  "apps",
+	}
+	// Create resolves the target of the patch twice, and every further
+	// operation once more.
+	for i := 0; i < 3; i++ {
+		_, namespace, err := patchResourceInterface(m, "v1", "ConfigMap", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if namespace != "apps" {
+			t.Errorf("expected the namespace of the provider, got %q", namespace)
+		}
+		_, namespace, err = patchResourceInterface(m, "v1", "Namespace", "apps")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if namespace != "" {
+			t.Errorf("expected no namespace for a cluster scoped kind, got %q", namespace)
+		}
+	}
+	if discoveries != 1 {
+		t.Errorf("expected the resources to be discovered once, got %d", discoveries)
+	}
+}
+
+func TestAccKubernetesPatch_apply(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "default"
+	resourceName := "kubernetes_patch.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createConfigMap(name, namespace)
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckKubernetesPatchConfigMapData(name, namespace, map[string]string{}); err != nil {
+				return err
+			}
+			return destroyConfigMap(name, namespace)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPatch_apply(name, `{"data":{"one":"1"}}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "api_version", "v1"),
+					resource.TestCheckResourceAttr(resourceName, "kind", "ConfigMap"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "apply"),
+					resource.TestCheckResourceAttr(resourceName, "managed_fields", `{"data":{"one":"1"}}`),
+					resource.TestCheckResourceAttr(resourceName, "field_manager", "tftest"),
+				),
+			},
+			{
+				Config: testAccKubernetesPatch_apply(name, `{"data":{"one":"1","two":"2"}}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "managed_fields", `{"data":{"one":"1","two":"2"}}`),
+					func(s *terraform.State) error {
+						return testAccCheckKubernetesPatchConfigMapData(name, namespace, map[string]string{"one": "1", "two": "2"})
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPatch_json(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	namespace := "default"
+	resourceName := "kubernetes_patch.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createConfigMap(name, namespace)
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckKubernetesPatchConfigMapData(name, namespace, map[string]string{}); err != nil {
+				return err
+			}
+			return destroyConfigMap(name, namespace)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPatch_json(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "json"),
+					resource.TestCheckResourceAttr(resourceName, "managed_fields", `{"data":{"one":"1"}}`),
+					func(s *terraform.State) error {
+						return testAccCheckKubernetesPatchConfigMapData(name, namespace, map[string]string{"one": "1"})
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPatchConfigMapData(name, namespace string, expected map[string]string) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	cm, err := conn.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if len(cm.Data) != len(expected) {
+		return fmt.Errorf("expected the data of config map %q to be %v, got %v", name, expected, cm.Data)
+	}
+	for k, v := range expected {
+		if cm.Data[k] != v {
+			return fmt.Errorf("expected the data of config map %q to be %v, got %v", name, expected, cm.Data)
+		}
+	}
+	return nil
+}
+
+func testAccKubernetesPatch_apply(name, patch string) string {
+	return fmt.Sprintf(`resource "kubernetes_patch" "test" {
+  api_version = "v1"
+  kind        = "ConfigMap"
+  metadata {
+    name = %q
+  }
+  patch         = %q
+  field_manager = "tftest"
+}
+`, name, patch)
+}
+
+func testAccKubernetesPatch_json(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_patch" "test" {
+  api_version = "v1"
+  kind        = "ConfigMap"
+  metadata {
+    name = %q
+  }
+  type = "json"
+  patch = jsonencode([
+    { op = "add", path = "/data", value = { one = "1" } },
+  ])
+  inverse_patch = jsonencode([
+    { op = "remove", path = "/data" },
+  ])
+  field_manager = "tftest"
+}
+`, name)
+}
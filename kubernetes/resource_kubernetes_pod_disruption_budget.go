@@ -110,7 +110,7 @@ func resourceKubernetesPodDisruptionBudgetCreate(ctx context.Context, d *schema.
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandPodDisruptionBudgetSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -110,7 +110,7 @@ func resourceKubernetesPodDisruptionBudgetV1Create(ctx context.Context, d *schem
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandPodDisruptionBudgetV1Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
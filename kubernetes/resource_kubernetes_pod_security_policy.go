@@ -370,7 +370,7 @@ func resourceKubernetesPodSecurityPolicyCreate(ctx context.Context, d *schema.Re
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	spec, err := expandPodSecurityPolicySpec(d.Get("spec").([]interface{}))
 
 	if err != nil {
@@ -0,0 +1,190 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesPodTemplateV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesPodTemplateV1Create,
+		ReadContext:   resourceKubernetesPodTemplateV1Read,
+		UpdateContext: resourceKubernetesPodTemplateV1Update,
+		DeleteContext: resourceKubernetesPodTemplateV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("pod template", true),
+			"template": {
+				Type:        schema.TypeList,
+				Description: "Describes the pods created from this template, for example by controllers that read pod templates from the API. More info: https://kubernetes.io/docs/concepts/workloads/pods/#pod-templates",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: podTemplateFields("pod template"),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesPodTemplateV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandResourceMetadata(d)
+	template, err := expandPodTemplate(d.Get("template").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pt := corev1.PodTemplate{
+		ObjectMeta: metadata,
+		Template:   *template,
+	}
+	log.Printf("[INFO] Creating new pod template: %#v", pt)
+	out, err := conn.CoreV1().PodTemplates(metadata.Namespace).Create(ctx, &pt, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create pod template: %s", err)
+	}
+	log.Printf("[INFO] Submitted new pod template: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesPodTemplateV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesPodTemplateV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesPodTemplateV1Exists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading pod template %s", name)
+	pt, err := conn.CoreV1().PodTemplates(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received pod template: %#v", pt)
+
+	err = d.Set("metadata", flattenMetadata(pt.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	template, err := flattenPodTemplateSpec(pt.Template, d, meta, "template.0.")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("template", template)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesPodTemplateV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("template") {
+		template, err := expandPodTemplate(d.Get("template").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/template",
+			Value: template,
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating pod template %q: %v", name, string(data))
+	out, err := conn.CoreV1().PodTemplates(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update pod template: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated pod template: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesPodTemplateV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesPodTemplateV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Deleting pod template: %#v", name)
+	err = conn.CoreV1().PodTemplates(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Pod template %s deleted", name)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceKubernetesPodTemplateV1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return false, err
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking pod template %s", name)
+	_, err = conn.CoreV1().PodTemplates(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
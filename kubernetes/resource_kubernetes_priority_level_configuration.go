@@ -0,0 +1,341 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesPriorityLevelConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesPriorityLevelConfigurationCreate,
+		ReadContext:   resourceKubernetesPriorityLevelConfigurationRead,
+		UpdateContext: resourceKubernetesPriorityLevelConfigurationUpdate,
+		DeleteContext: resourceKubernetesPriorityLevelConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceKubernetesPriorityLevelConfigurationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("priority level configuration", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Specification of the priority level. More info: https://kubernetes.io/docs/concepts/cluster-administration/flow-control/",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: priorityLevelConfigurationSpecFields(),
+				},
+			},
+		},
+	}
+}
+
+func priorityLevelConfigurationSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Description:  "Whether the requests of the priority level are subject to limits (`Limited`) or not (`Exempt`).",
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"Limited", "Exempt"}, false),
+		},
+		"limited": {
+			Type:        schema.TypeList,
+			Description: "Limits of the requests of the priority level. Must be set when `type` is `Limited`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"nominal_concurrency_shares": {
+						Type:         schema.TypeInt,
+						Description:  "Share of the concurrency limit of the API server that the priority level gets. Defaults to 30. Can only be 0 with v1 of the API. Called assured concurrency shares before v1beta3.",
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"lendable_percent": {
+						Type:         schema.TypeInt,
+						Description:  "Percentage of the nominal concurrency limit that other priority levels can borrow. Only supported by v1beta3 and later versions of the API.",
+						Optional:     true,
+						ValidateFunc: validation.IntBetween(0, 100),
+					},
+					"borrowing_limit_percent": {
+						Type:         schema.TypeInt,
+						Description:  "Limit of the concurrency that the priority level can borrow from other levels, as a percentage of its nominal concurrency limit. When not set, there is no limit. Only supported by v1beta3 and later versions of the API.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"limit_response": {
+						Type:        schema.TypeList,
+						Description: "What to do with the requests that cannot be executed right away.",
+						Required:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"type": {
+									Type:         schema.TypeString,
+									Description:  "Whether the requests are queued (`Queue`) or rejected (`Reject`).",
+									Required:     true,
+									ValidateFunc: validation.StringInSlice([]string{"Queue", "Reject"}, false),
+								},
+								"queuing": {
+									Type:        schema.TypeList,
+									Description: "Configuration of the queues. Only used when `type` is `Queue`.",
+									Optional:    true,
+									Computed:    true,
+									MaxItems:    1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"queues": {
+												Type:         schema.TypeInt,
+												Description:  "Number of queues of the priority level. Defaults to 64.",
+												Optional:     true,
+												Computed:     true,
+												ValidateFunc: validation.IntAtLeast(1),
+											},
+											"hand_size": {
+												Type:         schema.TypeInt,
+												Description:  "Number of queues that each flow is assigned to, out of which a request is queued to the shortest. Defaults to 8.",
+												Optional:     true,
+												Computed:     true,
+												ValidateFunc: validation.IntAtLeast(1),
+											},
+											"queue_length_limit": {
+												Type:         schema.TypeInt,
+												Description:  "Maximum number of requests in each queue. Defaults to 50.",
+												Optional:     true,
+												Computed:     true,
+												ValidateFunc: validation.IntAtLeast(1),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"exempt": {
+			Type:        schema.TypeList,
+			Description: "Configuration of an exempt priority level, only supported by v1beta3 and later versions of the API.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"nominal_concurrency_shares": {
+						Type:         schema.TypeInt,
+						Description:  "Share of the concurrency limit of the API server that the priority level accounts for, although its requests are not limited.",
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"lendable_percent": {
+						Type:         schema.TypeInt,
+						Description:  "Percentage of the nominal concurrency limit that other priority levels can borrow. Only supported by v1beta3 and later versions of the API.",
+						Optional:     true,
+						ValidateFunc: validation.IntBetween(0, 100),
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceKubernetesPriorityLevelConfigurationCustomizeDiff rejects the
+// attributes that the version of the API served by the cluster does not have.
+// The API server would drop them, which would show as a diff on every plan.
+func resourceKubernetesPriorityLevelConfigurationCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	config := diff.GetRawConfig()
+	var unsupported []string
+	for _, attr := range []string{"lendable_percent", "borrowing_limit_percent"} {
+		if priorityLevelAttributeConfigured(config, "limited", attr) {
+			unsupported = append(unsupported, "spec.0.limited.0."+attr)
+		}
+	}
+	for _, attr := range []string{"nominal_concurrency_shares", "lendable_percent"} {
+		if priorityLevelAttributeConfigured(config, "exempt", attr) {
+			unsupported = append(unsupported, "spec.0.exempt.0."+attr)
+		}
+	}
+	// older versions replace zero shares with the default
+	shares := "spec.0.limited.0.nominal_concurrency_shares"
+	_, sharesSet := diff.GetOk(shares)
+	zeroShares := !sharesSet && diff.NewValueKnown(shares) && priorityLevelAttributeConfigured(config, "limited", "nominal_concurrency_shares")
+	if len(unsupported) == 0 && !zeroShares {
+		return nil
+	}
+
+	dc, err := meta.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return err
+	}
+	gv, err := flowcontrolGroupVersion(dc)
+	if err != nil {
+		return err
+	}
+	if !supportsBorrowing(gv) && len(unsupported) > 0 {
+		return fmt.Errorf("%s: not supported by %s, the version of the API served by the cluster", strings.Join(unsupported, ", "), gv)
+	}
+	if gv.Version != "v1" && zeroShares {
+		return fmt.Errorf("%s: must be at least 1 with %s, the version of the API served by the cluster", shares, gv)
+	}
+	return nil
+}
+
+func resourceKubernetesPriorityLevelConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, gv, err := flowcontrolResource(meta, "prioritylevelconfigurations")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	plc := priorityLevelConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gv.String(),
+			Kind:       "PriorityLevelConfiguration",
+		},
+		ObjectMeta: expandResourceMetadata(d),
+		Spec:       expandPriorityLevelConfigurationSpec(d.Get("spec").([]interface{}), d.GetRawConfig(), gv),
+	}
+	obj, err := toUnstructuredObject(&plc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating new priority level configuration: %#v", plc)
+	out, err := client.Create(ctx, &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create priority level configuration: %s", err)
+	}
+	log.Printf("[INFO] Submitted new priority level configuration: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesPriorityLevelConfigurationRead(ctx, d, meta)
+}
+
+func resourceKubernetesPriorityLevelConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesPriorityLevelConfigurationExists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	plc, err := getPriorityLevelConfiguration(ctx, d.Id(), meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("metadata", flattenMetadata(plc.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenPriorityLevelConfigurationSpec(plc.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getPriorityLevelConfiguration(ctx context.Context, name string, meta interface{}) (*priorityLevelConfiguration, error) {
+	client, _, err := flowcontrolResource(meta, "prioritylevelconfigurations")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Reading priority level configuration %s", name)
+	out, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return nil, err
+	}
+	plc := &priorityLevelConfiguration{}
+	if err := fromUnstructuredObject(out.Object, plc); err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] Received priority level configuration: %#v", plc)
+	return plc, nil
+}
+
+func resourceKubernetesPriorityLevelConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, gv, err := flowcontrolResource(meta, "prioritylevelconfigurations")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: expandPriorityLevelConfigurationSpec(d.Get("spec").([]interface{}), d.GetRawConfig(), gv),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating priority level configuration %q: %v", name, string(data))
+	out, err := client.Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update priority level configuration: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated priority level configuration: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesPriorityLevelConfigurationRead(ctx, d, meta)
+}
+
+func resourceKubernetesPriorityLevelConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := flowcontrolResource(meta, "prioritylevelconfigurations")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Deleting priority level configuration: %#v", name)
+	err = client.Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Priority level configuration %s deleted", name)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceKubernetesPriorityLevelConfigurationExists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, _, err := flowcontrolResource(meta, "prioritylevelconfigurations")
+	if err != nil {
+		return false, err
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Checking priority level configuration %s", name)
+	_, err = client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
@@ -0,0 +1,140 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesPriorityLevelConfiguration_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_priority_level_configuration.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesPriorityLevelConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPriorityLevelConfigurationConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPriorityLevelConfigurationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.type", "Limited"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.nominal_concurrency_shares", "30"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.limit_response.0.type", "Queue"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.limit_response.0.queuing.0.queues", "64"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.limit_response.0.queuing.0.hand_size", "8"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.limit_response.0.queuing.0.queue_length_limit", "50"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesPriorityLevelConfigurationConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPriorityLevelConfigurationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.nominal_concurrency_shares", "10"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.limit_response.0.type", "Reject"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.limited.0.limit_response.0.queuing.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPriorityLevelConfigurationDestroy(s *terraform.State) error {
+	client, _, err := flowcontrolResource(testAccProvider.Meta(), "prioritylevelconfigurations")
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_priority_level_configuration" {
+			continue
+		}
+
+		name := rs.Primary.ID
+
+		resp, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if resp.GetName() == name {
+				return fmt.Errorf("Priority level configuration still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesPriorityLevelConfigurationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client, _, err := flowcontrolResource(testAccProvider.Meta(), "prioritylevelconfigurations")
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		_, err = client.Get(ctx, rs.Primary.ID, metav1.GetOptions{})
+		return err
+	}
+}
+
+func testAccKubernetesPriorityLevelConfigurationConfig_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_priority_level_configuration" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    type = "Limited"
+
+    limited {
+      limit_response {
+        type = "Queue"
+      }
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesPriorityLevelConfigurationConfig_modified(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_priority_level_configuration" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    type = "Limited"
+
+    limited {
+      nominal_concurrency_shares = 10
+
+      limit_response {
+        type = "Reject"
+      }
+    }
+  }
+}
+`, name)
+}
@@ -72,7 +72,7 @@ func resourceKubernetesRoleCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	rules := expandRules(d.Get("rule").([]interface{}))
 
 	role := v1.Role{
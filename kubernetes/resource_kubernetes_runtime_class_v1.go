@@ -0,0 +1,243 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	node "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesRuntimeClassV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesRuntimeClassV1Create,
+		ReadContext:   resourceKubernetesRuntimeClassV1Read,
+		UpdateContext: resourceKubernetesRuntimeClassV1Update,
+		DeleteContext: resourceKubernetesRuntimeClassV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("runtime class", true),
+			"handler": {
+				Type:        schema.TypeString,
+				Description: "Name of the configuration of the CRI implementation that handles pods of this class, such as `runsc` for gVisor or `kata` for Kata Containers. Must be a lowercase DNS label. This field is immutable.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"overhead": {
+				Type:        schema.TypeList,
+				Description: "Overhead of the resources associated with running a pod of this class, which is added to the resource requests of the pod. More info: https://kubernetes.io/docs/concepts/scheduling-eviction/pod-overhead/",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pod_fixed": {
+							Type:             schema.TypeMap,
+							Description:      "Fixed resource overhead of a pod of this class, such as `cpu = \"250m\"` or `memory = \"120Mi\"`.",
+							Optional:         true,
+							Elem:             &schema.Schema{Type: schema.TypeString},
+							ValidateFunc:     validateResourceList,
+							DiffSuppressFunc: suppressEquivalentResourceQuantity,
+						},
+					},
+				},
+			},
+			"scheduling": {
+				Type:        schema.TypeList,
+				Description: "Constraints that ensure pods of this class are scheduled to nodes that support it. When not set, all nodes are assumed to support the class.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_selector": {
+							Type:        schema.TypeMap,
+							Description: "Labels that nodes must have to run pods of this class. It is merged with the node selector of the pod, and conflicting selectors cause the pod to be rejected.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"toleration": podSpecFields(true, false)["toleration"],
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesRuntimeClassV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rc := node.RuntimeClass{
+		ObjectMeta: expandResourceMetadata(d),
+		Handler:    d.Get("handler").(string),
+	}
+	rc.Overhead, err = expandRuntimeClassV1Overhead(d.Get("overhead").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rc.Scheduling, err = expandRuntimeClassV1Scheduling(d.Get("scheduling").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating new runtime class: %#v", rc)
+	out, err := conn.NodeV1().RuntimeClasses().Create(ctx, &rc, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create runtime class: %s", err)
+	}
+	log.Printf("[INFO] Submitted new runtime class: %#v", out)
+	d.SetId(out.Name)
+
+	return resourceKubernetesRuntimeClassV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesRuntimeClassV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesRuntimeClassV1Exists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+	log.Printf("[INFO] Reading runtime class %s", name)
+	rc, err := conn.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received runtime class: %#v", rc)
+
+	err = d.Set("metadata", flattenMetadata(rc.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("handler", rc.Handler)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("overhead", flattenRuntimeClassV1Overhead(rc.Overhead))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("scheduling", flattenRuntimeClassV1Scheduling(rc.Scheduling))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesRuntimeClassV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("overhead") {
+		overhead, err := expandRuntimeClassV1Overhead(d.Get("overhead").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/overhead",
+			Value: overhead,
+		})
+	}
+	if d.HasChange("scheduling") {
+		scheduling, err := expandRuntimeClassV1Scheduling(d.Get("scheduling").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/scheduling",
+			Value: scheduling,
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating runtime class %q: %v", name, string(data))
+	out, err := conn.NodeV1().RuntimeClasses().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update runtime class: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated runtime class: %#v", out)
+	d.SetId(out.ObjectMeta.Name)
+
+	return resourceKubernetesRuntimeClassV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesRuntimeClassV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+	log.Printf("[INFO] Deleting runtime class: %s", name)
+	err = conn.NodeV1().RuntimeClasses().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := conn.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		e := fmt.Errorf("Runtime class (%s) still exists", name)
+		return resource.RetryableError(e)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Runtime class %s deleted", name)
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesRuntimeClassV1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return false, err
+	}
+
+	name := d.Id()
+	log.Printf("[INFO] Checking runtime class %s", name)
+	_, err = conn.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
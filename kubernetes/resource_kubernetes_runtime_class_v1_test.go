@@ -0,0 +1,149 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	api "k8s.io/api/node/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesRuntimeClassV1_basic(t *testing.T) {
+	var conf api.RuntimeClass
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_runtime_class_v1.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesRuntimeClassV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesRuntimeClassV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesRuntimeClassV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "handler", "runc"),
+					resource.TestCheckResourceAttr(resourceName, "overhead.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "scheduling.#", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesRuntimeClassV1Config_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesRuntimeClassV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "handler", "runc"),
+					resource.TestCheckResourceAttr(resourceName, "overhead.0.pod_fixed.cpu", "250m"),
+					resource.TestCheckResourceAttr(resourceName, "overhead.0.pod_fixed.memory", "120Mi"),
+					resource.TestCheckResourceAttr(resourceName, "scheduling.0.node_selector.runtime", "runc"),
+					resource.TestCheckResourceAttr(resourceName, "scheduling.0.toleration.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "scheduling.0.toleration.0.key", "runtime"),
+					resource.TestCheckResourceAttr(resourceName, "scheduling.0.toleration.0.effect", "NoSchedule"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesRuntimeClassV1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_runtime_class_v1" {
+			continue
+		}
+
+		name := rs.Primary.ID
+
+		resp, err := conn.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if resp.Name == name {
+				return fmt.Errorf("Runtime class still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesRuntimeClassV1Exists(n string, obj *api.RuntimeClass) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		out, err := conn.NodeV1().RuntimeClasses().Get(ctx, rs.Primary.ID, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesRuntimeClassV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_runtime_class_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  handler = "runc"
+}
+`, name)
+}
+
+func testAccKubernetesRuntimeClassV1Config_modified(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_runtime_class_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  handler = "runc"
+
+  overhead {
+    pod_fixed = {
+      cpu    = "250m"
+      memory = "120Mi"
+    }
+  }
+
+  scheduling {
+    node_selector = {
+      runtime = "runc"
+    }
+
+    toleration {
+      key      = "runtime"
+      operator = "Exists"
+      effect   = "NoSchedule"
+    }
+  }
+}
+`, name)
+}
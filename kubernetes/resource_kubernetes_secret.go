@@ -95,7 +95,7 @@ func resourceKubernetesSecretCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandResourceMetadata(d)
 	secret := corev1.Secret{
 		ObjectMeta: metadata,
 	}
@@ -0,0 +1,248 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesValidatingAdmissionPolicyBindingV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesValidatingAdmissionPolicyBindingV1Create,
+		ReadContext:   resourceKubernetesValidatingAdmissionPolicyBindingV1Read,
+		UpdateContext: resourceKubernetesValidatingAdmissionPolicyBindingV1Update,
+		DeleteContext: resourceKubernetesValidatingAdmissionPolicyBindingV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("validating admission policy binding", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Specification of the binding of a validating admission policy to its parameters and resources.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy_name": {
+							Type:        schema.TypeString,
+							Description: "Name of the validating admission policy that the binding applies. A binding of a policy that does not exist is invalid and has no effect.",
+							Required:    true,
+						},
+						"param_ref": {
+							Type:        schema.TypeList,
+							Description: "Parameter resources that configure the policy. Must be set when the policy has a `param_kind`.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the parameter resource. Mutually exclusive with `selector`.",
+										Optional:    true,
+									},
+									"namespace": {
+										Type:        schema.TypeString,
+										Description: "Namespace of the parameter resources. When not set and the parameter kind is namespaced, the namespace of the validated object is used.",
+										Optional:    true,
+									},
+									"selector": {
+										Type:        schema.TypeList,
+										Description: "Selects the parameter resources by their labels. The policy is evaluated once for each matching resource. Mutually exclusive with `name`.",
+										Optional:    true,
+										MaxItems:    1,
+										Elem: &schema.Resource{
+											Schema: labelSelectorFields(true),
+										},
+									},
+									"parameter_not_found_action": {
+										Type:        schema.TypeString,
+										Description: "Action taken when no parameter resource is found. With `Allow`, the request is allowed. With `Deny`, the failure policy of the policy is applied.",
+										Optional:    true,
+										Default:     "Deny",
+										ValidateFunc: validation.StringInSlice([]string{
+											"Allow",
+											"Deny",
+										}, false),
+									},
+								},
+							},
+						},
+						"match_resources": {
+							Type:        schema.TypeList,
+							Description: "Narrows the resources that the policy validates for this binding. When not set, the match constraints of the policy are used.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: matchResourcesV1Fields(),
+							},
+						},
+						"validation_actions": {
+							Type:        schema.TypeList,
+							Description: "Actions taken when a validation fails. `Deny` rejects the request, `Warn` returns a warning to the client and `Audit` adds the failure to the audit event. `Deny` and `Warn` cannot be used together.",
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"Deny",
+									"Warn",
+									"Audit",
+								}, false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesValidatingAdmissionPolicyBindingV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	binding := validatingAdmissionPolicyBindingV1{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: validatingAdmissionPolicyBindingV1GVR.GroupVersion().String(),
+			Kind:       "ValidatingAdmissionPolicyBinding",
+		},
+		ObjectMeta: expandResourceMetadata(d),
+		Spec:       expandValidatingAdmissionPolicyBindingV1Spec(d.Get("spec").([]interface{})),
+	}
+	obj, err := toUnstructuredObject(&binding)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating new validating admission policy binding: %#v", binding)
+	out, err := conn.Resource(validatingAdmissionPolicyBindingV1GVR).Create(ctx, &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create validating admission policy binding: %s", err)
+	}
+	log.Printf("[INFO] Submitted new validating admission policy binding: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesValidatingAdmissionPolicyBindingV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesValidatingAdmissionPolicyBindingV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesValidatingAdmissionPolicyBindingV1Exists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Reading validating admission policy binding %s", name)
+	out, err := conn.Resource(validatingAdmissionPolicyBindingV1GVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	binding := validatingAdmissionPolicyBindingV1{}
+	if err := fromUnstructuredObject(out.Object, &binding); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received validating admission policy binding: %#v", binding)
+
+	err = d.Set("metadata", flattenMetadata(binding.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenValidatingAdmissionPolicyBindingV1Spec(binding.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesValidatingAdmissionPolicyBindingV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: expandValidatingAdmissionPolicyBindingV1Spec(d.Get("spec").([]interface{})),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating validating admission policy binding %q: %v", name, string(data))
+	out, err := conn.Resource(validatingAdmissionPolicyBindingV1GVR).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update validating admission policy binding: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated validating admission policy binding: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesValidatingAdmissionPolicyBindingV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesValidatingAdmissionPolicyBindingV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Deleting validating admission policy binding: %#v", name)
+	err = conn.Resource(validatingAdmissionPolicyBindingV1GVR).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Validating admission policy binding %s deleted", name)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceKubernetesValidatingAdmissionPolicyBindingV1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return false, err
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Checking validating admission policy binding %s", name)
+	_, err = conn.Resource(validatingAdmissionPolicyBindingV1GVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
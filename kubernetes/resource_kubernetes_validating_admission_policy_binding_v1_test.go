@@ -0,0 +1,166 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesValidatingAdmissionPolicyBindingV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_validating_admission_policy_binding_v1.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.30.0")
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesValidatingAdmissionPolicyBindingV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesValidatingAdmissionPolicyBindingV1Config_basic(name, "Deny"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesValidatingAdmissionPolicyBindingV1Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.policy_name", name),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.param_ref.0.name", name),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.param_ref.0.parameter_not_found_action", "Deny"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.match_resources.0.namespace_selector.0.match_labels.environment", "test"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation_actions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation_actions.0", "Deny"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesValidatingAdmissionPolicyBindingV1Config_basic(name, "Warn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesValidatingAdmissionPolicyBindingV1Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation_actions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation_actions.0", "Warn"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesValidatingAdmissionPolicyBindingV1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).DynamicClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_validating_admission_policy_binding_v1" {
+			continue
+		}
+
+		name := rs.Primary.ID
+
+		resp, err := conn.Resource(validatingAdmissionPolicyBindingV1GVR).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if resp.GetName() == name {
+				return fmt.Errorf("Validating admission policy binding still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesValidatingAdmissionPolicyBindingV1Exists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn, err := testAccProvider.Meta().(KubeClientsets).DynamicClient()
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		_, err = conn.Resource(validatingAdmissionPolicyBindingV1GVR).Get(ctx, rs.Primary.ID, metav1.GetOptions{})
+		return err
+	}
+}
+
+func testAccKubernetesValidatingAdmissionPolicyBindingV1Config_basic(name, action string) string {
+	return fmt.Sprintf(`resource "kubernetes_config_map_v1" "test" {
+  metadata {
+    name = "%[1]s"
+  }
+
+  data = {
+    maxReplicas = "5"
+  }
+}
+
+resource "kubernetes_validating_admission_policy_v1" "test" {
+  metadata {
+    name = "%[1]s"
+  }
+
+  spec {
+    param_kind {
+      api_version = "v1"
+      kind        = "ConfigMap"
+    }
+
+    match_constraints {
+      resource_rule {
+        api_groups   = ["apps"]
+        api_versions = ["v1"]
+        operations   = ["CREATE", "UPDATE"]
+        resources    = ["deployments"]
+      }
+    }
+
+    validation {
+      expression = "object.spec.replicas <= int(params.data.maxReplicas)"
+    }
+  }
+}
+
+resource "kubernetes_validating_admission_policy_binding_v1" "test" {
+  metadata {
+    name = "%[1]s"
+  }
+
+  spec {
+    policy_name = kubernetes_validating_admission_policy_v1.test.metadata.0.name
+
+    param_ref {
+      name      = kubernetes_config_map_v1.test.metadata.0.name
+      namespace = kubernetes_config_map_v1.test.metadata.0.namespace
+    }
+
+    match_resources {
+      namespace_selector {
+        match_labels = {
+          environment = "test"
+        }
+      }
+    }
+
+    validation_actions = ["%[2]s"]
+  }
+}
+`, name, action)
+}
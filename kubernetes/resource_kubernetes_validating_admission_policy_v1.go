@@ -0,0 +1,318 @@
+package kubernetes
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesValidatingAdmissionPolicyV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesValidatingAdmissionPolicyV1Create,
+		ReadContext:   resourceKubernetesValidatingAdmissionPolicyV1Read,
+		UpdateContext: resourceKubernetesValidatingAdmissionPolicyV1Update,
+		DeleteContext: resourceKubernetesValidatingAdmissionPolicyV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("validating admission policy", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Specification of the validation performed by the policy. More info: https://kubernetes.io/docs/reference/access-authn-authz/validating-admission-policy/",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"param_kind": {
+							Type:        schema.TypeList,
+							Description: "Kind of the resources that configure the policy, which the bindings refer to with their `param_ref`. When not set, the policy takes no parameters.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"api_version": {
+										Type:        schema.TypeString,
+										Description: "API group and version of the parameter resources, such as `v1` or `example.com/v1`.",
+										Required:    true,
+									},
+									"kind": {
+										Type:        schema.TypeString,
+										Description: "Kind of the parameter resources, such as `ConfigMap`.",
+										Required:    true,
+									},
+								},
+							},
+						},
+						"match_constraints": {
+							Type:        schema.TypeList,
+							Description: "Resources that the policy validates. A binding can narrow them further but not widen them.",
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: matchResourcesV1Fields(),
+							},
+						},
+						"validation": {
+							Type:        schema.TypeList,
+							Description: "CEL expressions that validate the matched requests. A request is rejected when any expression evaluates to false.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"expression": {
+										Type:         schema.TypeString,
+										Description:  "CEL expression that evaluates to true for valid requests. It can refer to `object`, `oldObject`, `request`, `params`, `namespaceObject`, `variables` and `authorizer`.",
+										Required:     true,
+										ValidateFunc: validateCELExpression,
+									},
+									"message": {
+										Type:        schema.TypeString,
+										Description: "Message returned when the validation fails.",
+										Optional:    true,
+									},
+									"message_expression": {
+										Type:         schema.TypeString,
+										Description:  "CEL expression that evaluates to the message returned when the validation fails. It takes precedence over `message`.",
+										Optional:     true,
+										ValidateFunc: validateCELExpression,
+									},
+									"reason": {
+										Type:        schema.TypeString,
+										Description: "Reason returned to the client when the validation fails. Defaults to `Invalid`.",
+										Optional:    true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(metav1.StatusReasonUnauthorized),
+											string(metav1.StatusReasonForbidden),
+											string(metav1.StatusReasonInvalid),
+											string(metav1.StatusReasonRequestEntityTooLarge),
+										}, false),
+									},
+								},
+							},
+						},
+						"failure_policy": {
+							Type:        schema.TypeString,
+							Description: "How errors of the policy, such as a misconfigured parameter or an expression that fails to evaluate, are handled. One of `Fail` or `Ignore`.",
+							Optional:    true,
+							Default:     string(admissionregistrationv1.Fail),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(admissionregistrationv1.Fail),
+								string(admissionregistrationv1.Ignore),
+							}, false),
+						},
+						"audit_annotation": {
+							Type:        schema.TypeList,
+							Description: "Annotations added to the audit event of the matched requests.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:        schema.TypeString,
+										Description: "Key of the audit annotation, which is prefixed with the name of the policy.",
+										Required:    true,
+									},
+									"value_expression": {
+										Type:         schema.TypeString,
+										Description:  "CEL expression that evaluates to the value of the audit annotation. The annotation is not added when it evaluates to null.",
+										Required:     true,
+										ValidateFunc: validateCELExpression,
+									},
+								},
+							},
+						},
+						"match_condition": {
+							Type:        schema.TypeList,
+							Description: "CEL expressions that narrow the requests validated by the policy. A request is only validated when all conditions evaluate to true.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the match condition, unique within the policy.",
+										Required:    true,
+									},
+									"expression": {
+										Type:         schema.TypeString,
+										Description:  "CEL expression that evaluates to true for the requests validated by the policy.",
+										Required:     true,
+										ValidateFunc: validateCELExpression,
+									},
+								},
+							},
+						},
+						"variable": {
+							Type:        schema.TypeList,
+							Description: "CEL expressions whose values other expressions of the policy can refer to as `variables.<name>`. A variable can refer to the variables defined before it.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the variable.",
+										Required:    true,
+									},
+									"expression": {
+										Type:         schema.TypeString,
+										Description:  "CEL expression that evaluates to the value of the variable.",
+										Required:     true,
+										ValidateFunc: validateCELExpression,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesValidatingAdmissionPolicyV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy := validatingAdmissionPolicyV1{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: validatingAdmissionPolicyV1GVR.GroupVersion().String(),
+			Kind:       "ValidatingAdmissionPolicy",
+		},
+		ObjectMeta: expandResourceMetadata(d),
+		Spec:       expandValidatingAdmissionPolicyV1Spec(d.Get("spec").([]interface{})),
+	}
+	obj, err := toUnstructuredObject(&policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating new validating admission policy: %#v", policy)
+	out, err := conn.Resource(validatingAdmissionPolicyV1GVR).Create(ctx, &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create validating admission policy: %s", err)
+	}
+	log.Printf("[INFO] Submitted new validating admission policy: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesValidatingAdmissionPolicyV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesValidatingAdmissionPolicyV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesValidatingAdmissionPolicyV1Exists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Reading validating admission policy %s", name)
+	out, err := conn.Resource(validatingAdmissionPolicyV1GVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return diag.FromErr(err)
+	}
+	policy := validatingAdmissionPolicyV1{}
+	if err := fromUnstructuredObject(out.Object, &policy); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Received validating admission policy: %#v", policy)
+
+	err = d.Set("metadata", flattenMetadata(policy.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenValidatingAdmissionPolicyV1Spec(policy.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesValidatingAdmissionPolicyV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: expandValidatingAdmissionPolicyV1Spec(d.Get("spec").([]interface{})),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating validating admission policy %q: %v", name, string(data))
+	out, err := conn.Resource(validatingAdmissionPolicyV1GVR).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update validating admission policy: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated validating admission policy: %#v", out)
+	d.SetId(out.GetName())
+
+	return resourceKubernetesValidatingAdmissionPolicyV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesValidatingAdmissionPolicyV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Deleting validating admission policy: %#v", name)
+	err = conn.Resource(validatingAdmissionPolicyV1GVR).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Validating admission policy %s deleted", name)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceKubernetesValidatingAdmissionPolicyV1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return false, err
+	}
+
+	name := d.Id()
+
+	log.Printf("[INFO] Checking validating admission policy %s", name)
+	_, err = conn.Resource(validatingAdmissionPolicyV1GVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
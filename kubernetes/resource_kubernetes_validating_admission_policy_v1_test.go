@@ -0,0 +1,214 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesValidatingAdmissionPolicyV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_validating_admission_policy_v1.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.30.0")
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesValidatingAdmissionPolicyV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesValidatingAdmissionPolicyV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesValidatingAdmissionPolicyV1Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.failure_policy", "Fail"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.match_constraints.0.match_policy", "Equivalent"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.match_constraints.0.resource_rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.match_constraints.0.resource_rule.0.resources.0", "deployments"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation.0.expression", "object.spec.replicas <= 5"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesValidatingAdmissionPolicyV1Config_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesValidatingAdmissionPolicyV1Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.failure_policy", "Ignore"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.param_kind.0.kind", "ConfigMap"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.0.name", "replicas"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation.0.expression", "variables.replicas <= int(params.data.maxReplicas)"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.validation.0.reason", "Forbidden"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.audit_annotation.0.key", "high-replica-count"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesValidatingAdmissionPolicyV1_invalidExpression(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesValidatingAdmissionPolicyV1Config_invalidExpression(name),
+				ExpectError: regexp.MustCompile("is not a valid CEL expression"),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesValidatingAdmissionPolicyV1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).DynamicClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_validating_admission_policy_v1" {
+			continue
+		}
+
+		name := rs.Primary.ID
+
+		resp, err := conn.Resource(validatingAdmissionPolicyV1GVR).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if resp.GetName() == name {
+				return fmt.Errorf("Validating admission policy still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesValidatingAdmissionPolicyV1Exists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn, err := testAccProvider.Meta().(KubeClientsets).DynamicClient()
+		if err != nil {
+			return err
+		}
+		ctx := context.TODO()
+
+		_, err = conn.Resource(validatingAdmissionPolicyV1GVR).Get(ctx, rs.Primary.ID, metav1.GetOptions{})
+		return err
+	}
+}
+
+func testAccKubernetesValidatingAdmissionPolicyV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_validating_admission_policy_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    match_constraints {
+      resource_rule {
+        api_groups   = ["apps"]
+        api_versions = ["v1"]
+        operations   = ["CREATE", "UPDATE"]
+        resources    = ["deployments"]
+      }
+    }
+
+    validation {
+      expression = "object.spec.replicas <= 5"
+      message    = "at most 5 replicas are allowed"
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesValidatingAdmissionPolicyV1Config_modified(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_validating_admission_policy_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    failure_policy = "Ignore"
+
+    param_kind {
+      api_version = "v1"
+      kind        = "ConfigMap"
+    }
+
+    match_constraints {
+      resource_rule {
+        api_groups   = ["apps"]
+        api_versions = ["v1"]
+        operations   = ["CREATE", "UPDATE"]
+        resources    = ["deployments"]
+      }
+    }
+
+    variable {
+      name       = "replicas"
+      expression = "object.spec.replicas"
+    }
+
+    validation {
+      expression         = "variables.replicas <= int(params.data.maxReplicas)"
+      message_expression = "'at most ' + params.data.maxReplicas + ' replicas are allowed'"
+      reason             = "Forbidden"
+    }
+
+    audit_annotation {
+      key              = "high-replica-count"
+      value_expression = "variables.replicas > 3 ? string(variables.replicas) : null"
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesValidatingAdmissionPolicyV1Config_invalidExpression(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_validating_admission_policy_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    match_constraints {
+      resource_rule {
+        api_groups   = ["apps"]
+        api_versions = ["v1"]
+        operations   = ["CREATE"]
+        resources    = ["deployments"]
+      }
+    }
+
+    validation {
+      expression = "object.spec.replicas <="
+    }
+  }
+}
+`, name)
+}
@@ -136,7 +136,7 @@ func resourceKubernetesValidatingWebhookConfigurationCreate(ctx context.Context,
 	}
 
 	cfg := admissionregistrationv1.ValidatingWebhookConfiguration{
-		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		ObjectMeta: expandResourceMetadata(d),
 		Webhooks:   expandValidatingWebhooks(d.Get("webhook").([]interface{})),
 	}
 
@@ -0,0 +1,54 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// flowcontrolStatusSchema is the schema of the status of the API Priority and
+// Fairness objects, which the API server reports through conditions such as
+// Dangling for a flow schema that refers to a missing priority level.
+func flowcontrolStatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Current status of the object, as reported by the API server.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"condition": {
+					Type:        schema.TypeList,
+					Description: "Current conditions of the object.",
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:        schema.TypeString,
+								Description: "Type of the condition, such as `Dangling` or `ConcurrencyShared`.",
+								Computed:    true,
+							},
+							"status": {
+								Type:        schema.TypeString,
+								Description: "Status of the condition, one of `True`, `False` or `Unknown`.",
+								Computed:    true,
+							},
+							"last_transition_time": {
+								Type:        schema.TypeString,
+								Description: "Last time the condition changed status, in RFC 3339 format.",
+								Computed:    true,
+							},
+							"reason": {
+								Type:        schema.TypeString,
+								Description: "Machine readable reason of the last transition of the condition.",
+								Computed:    true,
+							},
+							"message": {
+								Type:        schema.TypeString,
+								Description: "Human readable details of the last transition of the condition.",
+								Computed:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -1,5 +1,9 @@
 package kubernetes
 
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
 func conditionalDefault(condition bool, defaultValue interface{}) interface{} {
 	if !condition {
 		return nil
@@ -7,3 +11,35 @@ func conditionalDefault(condition bool, defaultValue interface{}) interface{} {
 
 	return defaultValue
 }
+
+// datasourceSchemaFromResourceSchema returns a copy of a resource schema where
+// every attribute is computed, so that data sources can expose the same
+// structure as the corresponding resource without accepting it as input.
+func datasourceSchemaFromResourceSchema(rs map[string]*schema.Schema) map[string]*schema.Schema {
+	ds := make(map[string]*schema.Schema, len(rs))
+	for k, v := range rs {
+		ds[k] = datasourceSchemaFromSchema(v)
+	}
+	return ds
+}
+
+func datasourceSchemaFromSchema(s *schema.Schema) *schema.Schema {
+	ds := &schema.Schema{
+		Type:        s.Type,
+		Description: s.Description,
+		Sensitive:   s.Sensitive,
+		Computed:    true,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		ds.Elem = &schema.Resource{
+			Schema: datasourceSchemaFromResourceSchema(elem.Schema),
+		}
+	case *schema.Schema:
+		ds.Elem = &schema.Schema{Type: elem.Type}
+	}
+	if s.Type == schema.TypeSet {
+		ds.Set = s.Set
+	}
+	return ds
+}
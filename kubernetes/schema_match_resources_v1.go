@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// matchResourcesV1Fields is the schema of the resources matched by an
+// admission policy or by one of its bindings.
+func matchResourcesV1Fields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"namespace_selector": {
+			Type:        schema.TypeList,
+			Description: "Selects the namespaces of the objects that the policy applies to, based on the labels of the namespace. Cluster scoped objects are always matched. When not set, objects of all namespaces are matched.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+		},
+		"object_selector": {
+			Type:        schema.TypeList,
+			Description: "Selects the objects that the policy applies to, based on the labels of the object. When not set, all objects are matched.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+		},
+		"resource_rule": {
+			Type:        schema.TypeList,
+			Description: "Operations and resources that the policy applies to. An operation is matched when any of the rules matches it.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: namedRuleWithOperationsV1Fields(),
+			},
+		},
+		"exclude_resource_rule": {
+			Type:        schema.TypeList,
+			Description: "Operations and resources that the policy does not apply to. Exclusions take precedence over inclusions.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: namedRuleWithOperationsV1Fields(),
+			},
+		},
+		"match_policy": {
+			Type:        schema.TypeString,
+			Description: "How the rules are used to match requests. With `Exact`, only requests for the exact group, version and resource of a rule are matched. With `Equivalent`, requests for the same resource through another group or version are matched too.",
+			Optional:    true,
+			Default:     string(admissionregistrationv1.Equivalent),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(admissionregistrationv1.Equivalent),
+				string(admissionregistrationv1.Exact),
+			}, false),
+		},
+	}
+}
+
+func namedRuleWithOperationsV1Fields() map[string]*schema.Schema {
+	s := ruleWithOperationsFields()
+	s["resource_names"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Names of the objects that the rule applies to. When not set, all objects are matched.",
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	return s
+}
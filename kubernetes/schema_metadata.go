@@ -90,7 +90,12 @@ func namespacedMetadataSchemaIsTemplate(objectName string, generatableName, isTe
 		Description: fmt.Sprintf("Namespace defines the space within which name of the %s must be unique.", objectName),
 		Optional:    true,
 		ForceNew:    true,
-		Default:     conditionalDefault(!isTemplate, "default"),
+	}
+	if !isTemplate {
+		// set to the namespace of the provider when omitted, see
+		// withDefaultNamespace
+		fields["namespace"].Description += " Defaults to the namespace of the provider."
+		fields["namespace"].Computed = true
 	}
 	if generatableName {
 		fields["generate_name"] = &schema.Schema{
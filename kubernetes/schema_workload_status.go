@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func workloadConditionsSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The latest available observations of the " + objectName + "'s current state.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:        schema.TypeString,
+					Description: "Type of the condition.",
+					Computed:    true,
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Description: "Status of the condition, one of True, False, Unknown.",
+					Computed:    true,
+				},
+				"reason": {
+					Type:        schema.TypeString,
+					Description: "The reason for the condition's last transition.",
+					Computed:    true,
+				},
+				"message": {
+					Type:        schema.TypeString,
+					Description: "A human readable message indicating details about the transition.",
+					Computed:    true,
+				},
+				"last_transition_time": {
+					Type:        schema.TypeString,
+					Description: "Last time the condition transitioned from one status to another, in RFC 3339 format.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func computedIntField(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: description,
+		Computed:    true,
+	}
+}
+
+func computedStringField(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: description,
+		Computed:    true,
+	}
+}
+
+func workloadStatusSchema(objectName string, fields map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The most recently observed status of the " + objectName + ".",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+func deploymentStatusSchema() *schema.Schema {
+	return workloadStatusSchema("deployment", map[string]*schema.Schema{
+		"observed_generation":  computedIntField("The generation observed by the deployment controller."),
+		"replicas":             computedIntField("Total number of non-terminated pods targeted by this deployment."),
+		"updated_replicas":     computedIntField("Total number of non-terminated pods targeted by this deployment that have the desired template spec."),
+		"ready_replicas":       computedIntField("Number of pods targeted by this deployment with a Ready condition."),
+		"available_replicas":   computedIntField("Total number of available pods targeted by this deployment."),
+		"unavailable_replicas": computedIntField("Total number of unavailable pods targeted by this deployment."),
+		"conditions":           workloadConditionsSchema("deployment"),
+	})
+}
+
+func statefulSetStatusSchema() *schema.Schema {
+	return workloadStatusSchema("stateful set", map[string]*schema.Schema{
+		"observed_generation": computedIntField("The generation observed by the stateful set controller."),
+		"replicas":            computedIntField("Number of pods created by the stateful set controller."),
+		"ready_replicas":      computedIntField("Number of pods created for this stateful set with a Ready condition."),
+		"current_replicas":    computedIntField("Number of pods created by the stateful set controller from the version indicated by current_revision."),
+		"updated_replicas":    computedIntField("Number of pods created by the stateful set controller from the version indicated by update_revision."),
+		"available_replicas":  computedIntField("Total number of available pods targeted by this stateful set."),
+		"current_revision":    computedStringField("The version of the stateful set used to generate pods in the sequence [0,current_replicas)."),
+		"update_revision":     computedStringField("The version of the stateful set used to generate pods in the sequence [replicas-updated_replicas,replicas)."),
+		"conditions":          workloadConditionsSchema("stateful set"),
+	})
+}
+
+func daemonSetStatusSchema() *schema.Schema {
+	return workloadStatusSchema("daemon set", map[string]*schema.Schema{
+		"observed_generation":      computedIntField("The generation observed by the daemon set controller."),
+		"current_number_scheduled": computedIntField("Number of nodes that are running at least one daemon pod and are supposed to run the daemon pod."),
+		"desired_number_scheduled": computedIntField("Total number of nodes that should be running the daemon pod."),
+		"number_misscheduled":      computedIntField("Number of nodes that are running the daemon pod, but are not supposed to run the daemon pod."),
+		"number_ready":             computedIntField("Number of nodes that should be running the daemon pod and have one or more of the daemon pod running with a Ready condition."),
+		"updated_number_scheduled": computedIntField("Total number of nodes that are running the updated daemon pod."),
+		"number_available":         computedIntField("Number of nodes that should be running the daemon pod and have one or more of the daemon pod running and available."),
+		"number_unavailable":       computedIntField("Number of nodes that should be running the daemon pod and have none of the daemon pod running and available."),
+		"conditions":               workloadConditionsSchema("daemon set"),
+	})
+}
+
+func jobStatusSchema() *schema.Schema {
+	return workloadStatusSchema("job", map[string]*schema.Schema{
+		"active":          computedIntField("The number of pending and running pods."),
+		"succeeded":       computedIntField("The number of pods which reached phase Succeeded."),
+		"failed":          computedIntField("The number of pods which reached phase Failed."),
+		"start_time":      computedStringField("Time when the job was acknowledged by the job controller, in RFC 3339 format."),
+		"completion_time": computedStringField("Time when the job was completed, in RFC 3339 format."),
+		"conditions":      workloadConditionsSchema("job"),
+	})
+}
+
+func cronJobStatusSchema() *schema.Schema {
+	return workloadStatusSchema("cron job", map[string]*schema.Schema{
+		"active": {
+			Type:        schema.TypeList,
+			Description: "Names of the currently running jobs.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"last_schedule_time":   computedStringField("The last time the job was successfully scheduled, in RFC 3339 format."),
+		"last_successful_time": computedStringField("The last time the job successfully completed, in RFC 3339 format."),
+	})
+}
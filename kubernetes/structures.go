@@ -64,16 +64,43 @@ func expandMetadata(in []interface{}) metav1.ObjectMeta {
 	return meta
 }
 
+// expandResourceMetadata expands the metadata of a resource, merged over
+// the default labels and annotations of the provider that apply to it.
+func expandResourceMetadata(d *schema.ResourceData) metav1.ObjectMeta {
+	meta := expandMetadata(d.Get("metadata").([]interface{}))
+	if v, ok := d.Get("default_annotations").(map[string]interface{}); ok && len(v) > 0 {
+		meta.Annotations = expandStringMap(mergeDefaultMetadata(d.Get("metadata.0.annotations").(map[string]interface{}), v))
+	}
+	if v, ok := d.Get("default_labels").(map[string]interface{}); ok && len(v) > 0 {
+		meta.Labels = expandStringMap(mergeDefaultMetadata(d.Get("metadata.0.labels").(map[string]interface{}), v))
+	}
+	return meta
+}
+
 func patchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
 	ops := make([]PatchOperation, 0, 0)
-	if d.HasChange(keyPrefix + "annotations") {
-		oldV, newV := d.GetChange(keyPrefix + "annotations")
-		diffOps := diffStringMap(pathPrefix+"annotations", oldV.(map[string]interface{}), newV.(map[string]interface{}))
-		ops = append(ops, diffOps...)
-	}
-	if d.HasChange(keyPrefix + "labels") {
-		oldV, newV := d.GetChange(keyPrefix + "labels")
-		diffOps := diffStringMap(pathPrefix+"labels", oldV.(map[string]interface{}), newV.(map[string]interface{}))
+	for _, key := range []string{"annotations", "labels"} {
+		// The defaults of the provider only apply to the metadata of the
+		// resource itself, not to the metadata of templates.
+		defaultsKey := ""
+		if keyPrefix == "metadata.0." {
+			defaultsKey = "default_" + key
+		}
+		if !d.HasChange(keyPrefix+key) && (defaultsKey == "" || !d.HasChange(defaultsKey)) {
+			continue
+		}
+		oldV, newV := d.GetChange(keyPrefix + key)
+		oldMap, newMap := oldV.(map[string]interface{}), newV.(map[string]interface{})
+		if defaultsKey != "" {
+			oldDefaults, newDefaults := d.GetChange(defaultsKey)
+			if v, ok := oldDefaults.(map[string]interface{}); ok {
+				oldMap = mergeDefaultMetadata(oldMap, v)
+			}
+			if v, ok := newDefaults.(map[string]interface{}); ok {
+				newMap = mergeDefaultMetadata(newMap, v)
+			}
+		}
+		diffOps := diffStringMap(pathPrefix+key, oldMap, newMap)
 		ops = append(ops, diffOps...)
 	}
 	return ops
@@ -1,6 +1,20 @@
 package kubernetes
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	certificates "k8s.io/api/certificates/v1"
@@ -29,3 +43,112 @@ func expandCertificateSigningRequestV1Usages(s []interface{}) []certificates.Key
 	}
 	return out
 }
+
+func flattenCertificateSigningRequestV1Spec(in certificates.CertificateSigningRequestSpec) []interface{} {
+	att := make(map[string]interface{})
+	att["request"] = string(in.Request)
+	att["signer_name"] = in.SignerName
+	usages := make([]interface{}, len(in.Usages))
+	for i, u := range in.Usages {
+		usages[i] = string(u)
+	}
+	att["usages"] = schema.NewSet(schema.HashString, usages)
+	return []interface{}{att}
+}
+
+func expandCertificateSigningRequestV1Subject(l []interface{}) pkix.Name {
+	obj := pkix.Name{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	obj.CommonName = in["common_name"].(string)
+	obj.Organization = expandStringSlice(in["organization"].([]interface{}))
+	obj.OrganizationalUnit = expandStringSlice(in["organizational_unit"].([]interface{}))
+	obj.Country = expandStringSlice(in["country"].([]interface{}))
+	obj.Province = expandStringSlice(in["province"].([]interface{}))
+	obj.Locality = expandStringSlice(in["locality"].([]interface{}))
+	obj.StreetAddress = expandStringSlice(in["street_address"].([]interface{}))
+	obj.PostalCode = expandStringSlice(in["postal_code"].([]interface{}))
+	return obj
+}
+
+// generateCertificateSigningRequestV1 creates a private key and a PEM encoded
+// certificate request signed with it, as described by the generate_request block.
+// It returns the PEM encoded request and the PEM encoded PKCS#8 private key.
+func generateCertificateSigningRequestV1(l []interface{}) (string, string, error) {
+	if len(l) == 0 || l[0] == nil {
+		return "", "", errors.New("generate_request block is empty")
+	}
+	in := l[0].(map[string]interface{})
+
+	key, err := generateCertificateSigningRequestV1Key(in["key_algorithm"].(string), in["rsa_bits"].(int), in["ecdsa_curve"].(string))
+	if err != nil {
+		return "", "", err
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:  expandCertificateSigningRequestV1Subject(in["subject"].([]interface{})),
+		DNSNames: expandStringSlice(in["dns_names"].([]interface{})),
+	}
+	for _, v := range expandStringSlice(in["ip_addresses"].([]interface{})) {
+		ip := net.ParseIP(v)
+		if ip == nil {
+			return "", "", fmt.Errorf("invalid IP address %q", v)
+		}
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+
+	request, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create certificate request: %s", err)
+	}
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode private key: %s", err)
+	}
+
+	requestPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey})
+	return string(requestPEM), string(privateKeyPEM), nil
+}
+
+func generateCertificateSigningRequestV1Key(algorithm string, rsaBits int, ecdsaCurve string) (crypto.Signer, error) {
+	switch algorithm {
+	case "RSA":
+		return rsa.GenerateKey(rand.Reader, rsaBits)
+	case "ECDSA":
+		var curve elliptic.Curve
+		switch ecdsaCurve {
+		case "P224":
+			curve = elliptic.P224()
+		case "P256":
+			curve = elliptic.P256()
+		case "P384":
+			curve = elliptic.P384()
+		case "P521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve %q", ecdsaCurve)
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case "ED25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	}
+	return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+}
+
+// certificateNeedsRenewal reports whether the PEM encoded certificate expires
+// within renewBefore of now.
+func certificateNeedsRenewal(certificate string, renewBefore time.Duration, now time.Time) (bool, error) {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil {
+		return false, errors.New("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, err
+	}
+	return !now.Add(renewBefore).Before(cert.NotAfter), nil
+}
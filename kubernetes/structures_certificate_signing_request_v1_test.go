@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGenerateCertificateSigningRequestV1(t *testing.T) {
+	cases := []struct {
+		algorithm string
+		checkKey  func(interface{}) bool
+	}{
+		{"RSA", func(k interface{}) bool { _, ok := k.(*rsa.PrivateKey); return ok }},
+		{"ECDSA", func(k interface{}) bool { _, ok := k.(*ecdsa.PrivateKey); return ok }},
+		{"ED25519", func(k interface{}) bool { _, ok := k.(ed25519.PrivateKey); return ok }},
+	}
+
+	for _, tc := range cases {
+		in := []interface{}{map[string]interface{}{
+			"subject": []interface{}{map[string]interface{}{
+				"common_name":         "jane",
+				"organization":        []interface{}{"developers"},
+				"organizational_unit": []interface{}{},
+				"country":             []interface{}{},
+				"province":            []interface{}{},
+				"locality":            []interface{}{},
+				"street_address":      []interface{}{},
+				"postal_code":         []interface{}{},
+			}},
+			"dns_names":     []interface{}{"example.com"},
+			"ip_addresses":  []interface{}{"10.0.0.1"},
+			"key_algorithm": tc.algorithm,
+			"rsa_bits":      2048,
+			"ecdsa_curve":   "P256",
+		}}
+
+		request, privateKey, err := generateCertificateSigningRequestV1(in)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.algorithm, err)
+		}
+
+		block, _ := pem.Decode([]byte(request))
+		if block == nil || block.Type != "CERTIFICATE REQUEST" {
+			t.Fatalf("%s: request is not a PEM encoded certificate request", tc.algorithm)
+		}
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.algorithm, err)
+		}
+		if err := csr.CheckSignature(); err != nil {
+			t.Fatalf("%s: %s", tc.algorithm, err)
+		}
+		if csr.Subject.CommonName != "jane" || !reflect.DeepEqual(csr.Subject.Organization, []string{"developers"}) {
+			t.Fatalf("%s: unexpected subject %#v", tc.algorithm, csr.Subject)
+		}
+		if !reflect.DeepEqual(csr.DNSNames, []string{"example.com"}) || len(csr.IPAddresses) != 1 || csr.IPAddresses[0].String() != "10.0.0.1" {
+			t.Fatalf("%s: unexpected subject alternative names %v %v", tc.algorithm, csr.DNSNames, csr.IPAddresses)
+		}
+
+		block, _ = pem.Decode([]byte(privateKey))
+		if block == nil || block.Type != "PRIVATE KEY" {
+			t.Fatalf("%s: private key is not a PEM encoded PKCS#8 key", tc.algorithm)
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.algorithm, err)
+		}
+		if !tc.checkKey(key) {
+			t.Fatalf("%s: unexpected private key type %T", tc.algorithm, key)
+		}
+	}
+}
+
+func TestCertificateNeedsRenewal(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(48 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	cases := []struct {
+		renewBefore time.Duration
+		expected    bool
+	}{
+		{0, false},
+		{24 * time.Hour, false},
+		{48 * time.Hour, true},
+		{72 * time.Hour, true},
+	}
+	for _, tc := range cases {
+		renew, err := certificateNeedsRenewal(certificate, tc.renewBefore, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if renew != tc.expected {
+			t.Fatalf("renew_before %s: expected %t, got %t", tc.renewBefore, tc.expected, renew)
+		}
+	}
+
+	if _, err := certificateNeedsRenewal("not a certificate", time.Hour, now); err == nil {
+		t.Fatal("expected an error for an invalid certificate")
+	}
+}
@@ -0,0 +1,171 @@
+package kubernetes
+
+import (
+	api "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func expandEndpointSliceV1Endpoints(in []interface{}) []discovery.Endpoint {
+	endpoints := make([]discovery.Endpoint, 0, len(in))
+	for _, e := range in {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ep := discovery.Endpoint{
+			Addresses: sliceOfString(m["addresses"].([]interface{})),
+		}
+		if v, ok := m["condition"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ep.Conditions = expandEndpointSliceV1Conditions(v[0].(map[string]interface{}))
+		}
+		if v, ok := m["hostname"].(string); ok && v != "" {
+			ep.Hostname = ptrToString(v)
+		}
+		if v, ok := m["node_name"].(string); ok && v != "" {
+			ep.NodeName = ptrToString(v)
+		}
+		if v, ok := m["zone"].(string); ok && v != "" {
+			ep.Zone = ptrToString(v)
+		}
+		if v, ok := m["target_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ep.TargetRef = expandEndpointSliceV1TargetRef(v[0].(map[string]interface{}))
+		}
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints
+}
+
+// expandEndpointSliceV1Conditions sets all conditions when the condition block
+// is configured. Without the block the conditions are left unset, which
+// consumers interpret as ready, serving and not terminating.
+func expandEndpointSliceV1Conditions(in map[string]interface{}) discovery.EndpointConditions {
+	obj := discovery.EndpointConditions{}
+	if v, ok := in["ready"].(bool); ok {
+		obj.Ready = ptrToBool(v)
+	}
+	if v, ok := in["serving"].(bool); ok {
+		obj.Serving = ptrToBool(v)
+	}
+	if v, ok := in["terminating"].(bool); ok {
+		obj.Terminating = ptrToBool(v)
+	}
+	return obj
+}
+
+func expandEndpointSliceV1TargetRef(in map[string]interface{}) *api.ObjectReference {
+	ref := &api.ObjectReference{}
+	if v, ok := in["kind"].(string); ok {
+		ref.Kind = v
+	}
+	if v, ok := in["namespace"].(string); ok {
+		ref.Namespace = v
+	}
+	if v, ok := in["name"].(string); ok {
+		ref.Name = v
+	}
+	if v, ok := in["uid"].(string); ok {
+		ref.UID = types.UID(v)
+	}
+	if v, ok := in["resource_version"].(string); ok {
+		ref.ResourceVersion = v
+	}
+	if v, ok := in["field_path"].(string); ok {
+		ref.FieldPath = v
+	}
+	return ref
+}
+
+func expandEndpointSliceV1Ports(in []interface{}) []discovery.EndpointPort {
+	ports := make([]discovery.EndpointPort, 0, len(in))
+	for _, p := range in {
+		m, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		port := discovery.EndpointPort{}
+		if v, ok := m["name"].(string); ok {
+			port.Name = ptrToString(v)
+		}
+		if v, ok := m["port"].(int); ok {
+			port.Port = ptrToInt32(int32(v))
+		}
+		if v, ok := m["protocol"].(string); ok && v != "" {
+			protocol := api.Protocol(v)
+			port.Protocol = &protocol
+		}
+		if v, ok := m["app_protocol"].(string); ok && v != "" {
+			port.AppProtocol = ptrToString(v)
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func flattenEndpointSliceV1Endpoints(in []discovery.Endpoint) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, ep := range in {
+		m := map[string]interface{}{
+			"addresses": ep.Addresses,
+		}
+		if c := flattenEndpointSliceV1Conditions(ep.Conditions); len(c) > 0 {
+			m["condition"] = []interface{}{c}
+		}
+		if ep.Hostname != nil {
+			m["hostname"] = *ep.Hostname
+		}
+		if ep.NodeName != nil {
+			m["node_name"] = *ep.NodeName
+		}
+		if ep.Zone != nil {
+			m["zone"] = *ep.Zone
+		}
+		if ep.TargetRef != nil {
+			m["target_ref"] = []interface{}{map[string]interface{}{
+				"kind":             ep.TargetRef.Kind,
+				"namespace":        ep.TargetRef.Namespace,
+				"name":             ep.TargetRef.Name,
+				"uid":              string(ep.TargetRef.UID),
+				"resource_version": ep.TargetRef.ResourceVersion,
+				"field_path":       ep.TargetRef.FieldPath,
+			}}
+		}
+		out[i] = m
+	}
+	return out
+}
+
+func flattenEndpointSliceV1Conditions(in discovery.EndpointConditions) map[string]interface{} {
+	m := make(map[string]interface{})
+	if in.Ready != nil {
+		m["ready"] = *in.Ready
+	}
+	if in.Serving != nil {
+		m["serving"] = *in.Serving
+	}
+	if in.Terminating != nil {
+		m["terminating"] = *in.Terminating
+	}
+	return m
+}
+
+func flattenEndpointSliceV1Ports(in []discovery.EndpointPort) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, p := range in {
+		m := make(map[string]interface{})
+		if p.Name != nil {
+			m["name"] = *p.Name
+		}
+		if p.Port != nil {
+			m["port"] = int(*p.Port)
+		}
+		if p.Protocol != nil {
+			m["protocol"] = string(*p.Protocol)
+		}
+		if p.AppProtocol != nil {
+			m["app_protocol"] = *p.AppProtocol
+		}
+		out[i] = m
+	}
+	return out
+}
@@ -1,5 +1,14 @@
 package kubernetes
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
 func expandEnv(e []interface{}) []map[string]interface{} {
 	envs := []map[string]interface{}{}
 	if len(e) == 0 {
@@ -188,3 +197,73 @@ func flattenResourceFieldMap(e interface{}) []interface{} {
 
 	return []interface{}{expandedValues}
 }
+
+// podSpecPaths maps the kinds of workloads, in lower case, to the path of the
+// pod spec in their objects.
+var podSpecPaths = map[string][]string{
+	"pod":                   {"spec"},
+	"podtemplate":           {"template", "spec"},
+	"deployment":            {"spec", "template", "spec"},
+	"daemonset":             {"spec", "template", "spec"},
+	"statefulset":           {"spec", "template", "spec"},
+	"replicaset":            {"spec", "template", "spec"},
+	"replicationcontroller": {"spec", "template", "spec"},
+	"job":                   {"spec", "template", "spec"},
+	"cronjob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// podSpecPath returns the path of the pod spec in the objects of the given
+// kind. A custom path, made of field names separated by dots, takes
+// precedence, so that any kind embedding a pod spec can be used.
+func podSpecPath(kind, customPath string) ([]string, error) {
+	if customPath != "" {
+		path := strings.Split(customPath, ".")
+		for _, p := range path {
+			if p == "" {
+				return nil, fmt.Errorf("invalid pod spec path %q", customPath)
+			}
+		}
+		return path, nil
+	}
+	if path, ok := podSpecPaths[strings.ToLower(kind)]; ok {
+		return path, nil
+	}
+	return nil, fmt.Errorf("the path of the pod spec of kind %q is not known, set pod_spec_path", kind)
+}
+
+// containerTarget returns the name of the list of the pod spec holding the
+// container selected by the container or init_container attribute, and the
+// name of the container.
+func containerTarget(d *schema.ResourceData) (string, string) {
+	if c := d.Get("init_container").(string); c != "" {
+		return "initContainers", c
+	}
+	return "containers", d.Get("container").(string)
+}
+
+// containerFieldPath returns the path of a container of the pod spec at
+// specPath, or of one of its fields.
+func containerFieldPath(specPath []string, list, container string, field ...interface{}) fieldpath.Path {
+	parts := []interface{}{}
+	for _, p := range specPath {
+		parts = append(parts, p)
+	}
+	parts = append(parts, list, fieldpath.KeyByFields("name", container))
+	parts = append(parts, field...)
+	return fieldpath.MakePathOrDie(parts...)
+}
+
+// findContainer returns the container with the given name in a list of the
+// pod spec at specPath.
+func findContainer(obj map[string]interface{}, specPath []string, list, name string) (map[string]interface{}, error) {
+	containers, _, err := unstructured.NestedSlice(obj, append(append([]string{}, specPath...), list)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range containers {
+		if c, ok := c.(map[string]interface{}); ok && c["name"] == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find container with name %q", name)
+}
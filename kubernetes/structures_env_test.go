@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPodSpecPath(t *testing.T) {
+	cases := []struct {
+		Kind       string
+		CustomPath string
+		Expected   []string
+		Error      bool
+	}{
+		{"Pod", "", []string{"spec"}, false},
+		{"PodTemplate", "", []string{"template", "spec"}, false},
+		{"Deployment", "", []string{"spec", "template", "spec"}, false},
+		{"replicationcontroller", "", []string{"spec", "template", "spec"}, false},
+		{"CronJob", "", []string{"spec", "jobTemplate", "spec", "template", "spec"}, false},
+		{"Rollout", "spec.template.spec", []string{"spec", "template", "spec"}, false},
+		{"Deployment", "spec..spec", nil, true},
+		{"Rollout", "", nil, true},
+	}
+	for _, tc := range cases {
+		path, err := podSpecPath(tc.Kind, tc.CustomPath)
+		if tc.Error {
+			if err == nil {
+				t.Errorf("%s %q: expected an error", tc.Kind, tc.CustomPath)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %s", tc.Kind, tc.CustomPath, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.Expected, path) {
+			t.Errorf("%s %q: expected path %v, got %v", tc.Kind, tc.CustomPath, tc.Expected, path)
+		}
+	}
+}
+
+func TestContainerFieldPath(t *testing.T) {
+	cases := []struct {
+		SpecPath []string
+		List     string
+		Field    []interface{}
+		Expected string
+	}{
+		{[]string{"spec", "template", "spec"}, "containers", []interface{}{"image"}, `.spec.template.spec.containers[name="app"].image`},
+		{[]string{"spec"}, "initContainers", []interface{}{"envFrom"}, `.spec.initContainers[name="app"].envFrom`},
+		{[]string{"spec", "jobTemplate", "spec", "template", "spec"}, "containers", nil, `.spec.jobTemplate.spec.template.spec.containers[name="app"]`},
+	}
+	for _, tc := range cases {
+		p := containerFieldPath(tc.SpecPath, tc.List, "app", tc.Field...)
+		if p.String() != tc.Expected {
+			t.Errorf("expected path %s, got %s", tc.Expected, p.String())
+		}
+	}
+}
+
+func TestFindContainer(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"initContainers": []interface{}{
+				map[string]interface{}{"name": "init", "image": "busybox"},
+			},
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "nginx"},
+			},
+		},
+	}
+	c, err := findContainer(obj, []string{"spec"}, "initContainers", "init")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c["image"] != "busybox" {
+		t.Fatalf("unexpected container %v", c)
+	}
+	if _, err := findContainer(obj, []string{"spec"}, "initContainers", "app"); err == nil {
+		t.Fatal("expected an error for a missing container")
+	}
+}
@@ -0,0 +1,457 @@
+package kubernetes
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// The vendored k8s.io/api only has the flowcontrol.apiserver.k8s.io versions
+// up to v1beta2. The types below mirror v1, which is a superset of the older
+// versions apart from the concurrency shares of limited priority levels, that
+// v1beta1 and v1beta2 call assuredConcurrencyShares. The objects are sent to
+// the API server with the dynamic client, in the version served by the cluster.
+
+type flowSchema struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              flowSchemaSpec    `json:"spec,omitempty"`
+	Status            flowcontrolStatus `json:"status,omitempty"`
+}
+
+type flowSchemaSpec struct {
+	PriorityLevelConfiguration priorityLevelConfigurationReference `json:"priorityLevelConfiguration"`
+	MatchingPrecedence         int32                               `json:"matchingPrecedence,omitempty"`
+	DistinguisherMethod        *flowDistinguisherMethod            `json:"distinguisherMethod,omitempty"`
+	Rules                      []policyRulesWithSubjects           `json:"rules,omitempty"`
+}
+
+type priorityLevelConfigurationReference struct {
+	Name string `json:"name"`
+}
+
+type flowDistinguisherMethod struct {
+	Type string `json:"type"`
+}
+
+type policyRulesWithSubjects struct {
+	Subjects         []flowcontrolSubject    `json:"subjects"`
+	ResourceRules    []resourcePolicyRule    `json:"resourceRules,omitempty"`
+	NonResourceRules []nonResourcePolicyRule `json:"nonResourceRules,omitempty"`
+}
+
+type flowcontrolSubject struct {
+	Kind           string                            `json:"kind"`
+	User           *flowcontrolNamedSubject          `json:"user,omitempty"`
+	Group          *flowcontrolNamedSubject          `json:"group,omitempty"`
+	ServiceAccount *flowcontrolServiceAccountSubject `json:"serviceAccount,omitempty"`
+}
+
+type flowcontrolNamedSubject struct {
+	Name string `json:"name"`
+}
+
+type flowcontrolServiceAccountSubject struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type resourcePolicyRule struct {
+	Verbs        []string `json:"verbs"`
+	APIGroups    []string `json:"apiGroups"`
+	Resources    []string `json:"resources"`
+	ClusterScope bool     `json:"clusterScope,omitempty"`
+	Namespaces   []string `json:"namespaces,omitempty"`
+}
+
+type nonResourcePolicyRule struct {
+	Verbs           []string `json:"verbs"`
+	NonResourceURLs []string `json:"nonResourceURLs"`
+}
+
+type priorityLevelConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              priorityLevelConfigurationSpec `json:"spec,omitempty"`
+	Status            flowcontrolStatus              `json:"status,omitempty"`
+}
+
+type priorityLevelConfigurationSpec struct {
+	Type    string                             `json:"type"`
+	Limited *limitedPriorityLevelConfiguration `json:"limited,omitempty"`
+	Exempt  *exemptPriorityLevelConfiguration  `json:"exempt,omitempty"`
+}
+
+type limitedPriorityLevelConfiguration struct {
+	NominalConcurrencyShares *int32        `json:"nominalConcurrencyShares,omitempty"`
+	AssuredConcurrencyShares *int32        `json:"assuredConcurrencyShares,omitempty"`
+	LimitResponse            limitResponse `json:"limitResponse,omitempty"`
+	LendablePercent          *int32        `json:"lendablePercent,omitempty"`
+	BorrowingLimitPercent    *int32        `json:"borrowingLimitPercent,omitempty"`
+}
+
+type limitResponse struct {
+	Type    string                `json:"type"`
+	Queuing *queuingConfiguration `json:"queuing,omitempty"`
+}
+
+type queuingConfiguration struct {
+	Queues           int32 `json:"queues,omitempty"`
+	HandSize         int32 `json:"handSize,omitempty"`
+	QueueLengthLimit int32 `json:"queueLengthLimit,omitempty"`
+}
+
+type exemptPriorityLevelConfiguration struct {
+	NominalConcurrencyShares *int32 `json:"nominalConcurrencyShares,omitempty"`
+	LendablePercent          *int32 `json:"lendablePercent,omitempty"`
+}
+
+type flowcontrolStatus struct {
+	Conditions []flowcontrolCondition `json:"conditions,omitempty"`
+}
+
+type flowcontrolCondition struct {
+	Type               string      `json:"type,omitempty"`
+	Status             string      `json:"status,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+}
+
+// flowcontrolResource returns the client of the named resource of the API
+// Priority and Fairness API, in the version served by the cluster.
+func flowcontrolResource(meta interface{}, resource string) (dynamic.NamespaceableResourceInterface, k8sschema.GroupVersion, error) {
+	dc, err := meta.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return nil, k8sschema.GroupVersion{}, err
+	}
+	gv, err := flowcontrolGroupVersion(dc)
+	if err != nil {
+		return nil, k8sschema.GroupVersion{}, err
+	}
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return nil, k8sschema.GroupVersion{}, err
+	}
+	return conn.Resource(gv.WithResource(resource)), gv, nil
+}
+
+// usesAssuredConcurrencyShares reports whether the version calls the
+// concurrency shares of limited priority levels assuredConcurrencyShares.
+func usesAssuredConcurrencyShares(gv k8sschema.GroupVersion) bool {
+	return gv.Version == "v1beta1" || gv.Version == "v1beta2"
+}
+
+// supportsBorrowing reports whether the version has the fields of priority
+// levels that lend and borrow concurrency, and exempt priority levels.
+func supportsBorrowing(gv k8sschema.GroupVersion) bool {
+	return !usesAssuredConcurrencyShares(gv)
+}
+
+// priorityLevelAttributeConfigured reports whether the attribute of the block
+// of spec is set in the configuration. Unlike d.Get, it tells zero values apart
+// from unset ones. Unknown values count as set.
+func priorityLevelAttributeConfigured(config cty.Value, block, attribute string) bool {
+	v := config
+	for _, name := range []string{"spec", block, attribute} {
+		if !v.IsKnown() {
+			return true
+		}
+		if v.IsNull() {
+			return false
+		}
+		if v.Type().IsListType() {
+			if v.LengthInt() == 0 {
+				return false
+			}
+			v = v.Index(cty.NumberIntVal(0))
+			if !v.IsKnown() {
+				return true
+			}
+			if v.IsNull() {
+				return false
+			}
+		}
+		if !v.Type().IsObjectType() || !v.Type().HasAttribute(name) {
+			return false
+		}
+		v = v.GetAttr(name)
+	}
+	return !v.IsKnown() || !v.IsNull()
+}
+
+// Flatteners
+
+func flattenFlowSchemaSpec(in flowSchemaSpec) []interface{} {
+	att := make(map[string]interface{})
+
+	att["priority_level_configuration"] = []interface{}{map[string]interface{}{
+		"name": in.PriorityLevelConfiguration.Name,
+	}}
+	att["matching_precedence"] = int(in.MatchingPrecedence)
+	if in.DistinguisherMethod != nil {
+		att["distinguisher_method"] = []interface{}{map[string]interface{}{
+			"type": in.DistinguisherMethod.Type,
+		}}
+	}
+
+	rules := make([]interface{}, len(in.Rules))
+	for i, r := range in.Rules {
+		rules[i] = flattenPolicyRulesWithSubjects(r)
+	}
+	att["rule"] = rules
+
+	return []interface{}{att}
+}
+
+func flattenPolicyRulesWithSubjects(in policyRulesWithSubjects) map[string]interface{} {
+	att := make(map[string]interface{})
+
+	subjects := make([]interface{}, len(in.Subjects))
+	for i, s := range in.Subjects {
+		m := map[string]interface{}{
+			"kind": s.Kind,
+		}
+		if s.User != nil {
+			m["user"] = []interface{}{map[string]interface{}{"name": s.User.Name}}
+		}
+		if s.Group != nil {
+			m["group"] = []interface{}{map[string]interface{}{"name": s.Group.Name}}
+		}
+		if s.ServiceAccount != nil {
+			m["service_account"] = []interface{}{map[string]interface{}{
+				"namespace": s.ServiceAccount.Namespace,
+				"name":      s.ServiceAccount.Name,
+			}}
+		}
+		subjects[i] = m
+	}
+	att["subject"] = subjects
+
+	resourceRules := make([]interface{}, len(in.ResourceRules))
+	for i, r := range in.ResourceRules {
+		resourceRules[i] = map[string]interface{}{
+			"verbs":         r.Verbs,
+			"api_groups":    r.APIGroups,
+			"resources":     r.Resources,
+			"cluster_scope": r.ClusterScope,
+			"namespaces":    r.Namespaces,
+		}
+	}
+	att["resource_rule"] = resourceRules
+
+	nonResourceRules := make([]interface{}, len(in.NonResourceRules))
+	for i, r := range in.NonResourceRules {
+		nonResourceRules[i] = map[string]interface{}{
+			"verbs":             r.Verbs,
+			"non_resource_urls": r.NonResourceURLs,
+		}
+	}
+	att["non_resource_rule"] = nonResourceRules
+
+	return att
+}
+
+func flattenPriorityLevelConfigurationSpec(in priorityLevelConfigurationSpec) []interface{} {
+	att := make(map[string]interface{})
+
+	att["type"] = in.Type
+	if l := in.Limited; l != nil {
+		limited := make(map[string]interface{})
+		if l.NominalConcurrencyShares != nil {
+			limited["nominal_concurrency_shares"] = int(*l.NominalConcurrencyShares)
+		} else if l.AssuredConcurrencyShares != nil {
+			limited["nominal_concurrency_shares"] = int(*l.AssuredConcurrencyShares)
+		}
+		if l.LendablePercent != nil {
+			limited["lendable_percent"] = int(*l.LendablePercent)
+		}
+		if l.BorrowingLimitPercent != nil {
+			limited["borrowing_limit_percent"] = int(*l.BorrowingLimitPercent)
+		}
+		lr := map[string]interface{}{
+			"type": l.LimitResponse.Type,
+		}
+		if q := l.LimitResponse.Queuing; q != nil {
+			lr["queuing"] = []interface{}{map[string]interface{}{
+				"queues":             int(q.Queues),
+				"hand_size":          int(q.HandSize),
+				"queue_length_limit": int(q.QueueLengthLimit),
+			}}
+		}
+		limited["limit_response"] = []interface{}{lr}
+		att["limited"] = []interface{}{limited}
+	}
+	if e := in.Exempt; e != nil {
+		exempt := make(map[string]interface{})
+		if e.NominalConcurrencyShares != nil {
+			exempt["nominal_concurrency_shares"] = int(*e.NominalConcurrencyShares)
+		}
+		if e.LendablePercent != nil {
+			exempt["lendable_percent"] = int(*e.LendablePercent)
+		}
+		att["exempt"] = []interface{}{exempt}
+	}
+
+	return []interface{}{att}
+}
+
+func flattenFlowcontrolStatus(in flowcontrolStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = map[string]interface{}{
+			"type":                 c.Type,
+			"status":               c.Status,
+			"last_transition_time": c.LastTransitionTime.UTC().Format(time.RFC3339),
+			"reason":               c.Reason,
+			"message":              c.Message,
+		}
+	}
+	return []interface{}{map[string]interface{}{
+		"condition": conditions,
+	}}
+}
+
+// Expanders
+
+func expandFlowSchemaSpec(l []interface{}) flowSchemaSpec {
+	obj := flowSchemaSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["priority_level_configuration"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		obj.PriorityLevelConfiguration.Name = v[0].(map[string]interface{})["name"].(string)
+	}
+	if v, ok := in["matching_precedence"].(int); ok {
+		obj.MatchingPrecedence = int32(v)
+	}
+	if v, ok := in["distinguisher_method"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		obj.DistinguisherMethod = &flowDistinguisherMethod{
+			Type: v[0].(map[string]interface{})["type"].(string),
+		}
+	}
+	if v, ok := in["rule"].([]interface{}); ok {
+		for _, r := range v {
+			obj.Rules = append(obj.Rules, expandPolicyRulesWithSubjects(r.(map[string]interface{})))
+		}
+	}
+
+	return obj
+}
+
+func expandPolicyRulesWithSubjects(in map[string]interface{}) policyRulesWithSubjects {
+	obj := policyRulesWithSubjects{}
+
+	if v, ok := in["subject"].([]interface{}); ok {
+		for _, s := range v {
+			m := s.(map[string]interface{})
+			subject := flowcontrolSubject{
+				Kind: m["kind"].(string),
+			}
+			if u, ok := m["user"].([]interface{}); ok && len(u) > 0 && u[0] != nil {
+				subject.User = &flowcontrolNamedSubject{Name: u[0].(map[string]interface{})["name"].(string)}
+			}
+			if g, ok := m["group"].([]interface{}); ok && len(g) > 0 && g[0] != nil {
+				subject.Group = &flowcontrolNamedSubject{Name: g[0].(map[string]interface{})["name"].(string)}
+			}
+			if sa, ok := m["service_account"].([]interface{}); ok && len(sa) > 0 && sa[0] != nil {
+				sam := sa[0].(map[string]interface{})
+				subject.ServiceAccount = &flowcontrolServiceAccountSubject{
+					Namespace: sam["namespace"].(string),
+					Name:      sam["name"].(string),
+				}
+			}
+			obj.Subjects = append(obj.Subjects, subject)
+		}
+	}
+	if v, ok := in["resource_rule"].([]interface{}); ok {
+		for _, r := range v {
+			m := r.(map[string]interface{})
+			obj.ResourceRules = append(obj.ResourceRules, resourcePolicyRule{
+				Verbs:        expandStringSlice(m["verbs"].([]interface{})),
+				APIGroups:    expandStringSlice(m["api_groups"].([]interface{})),
+				Resources:    expandStringSlice(m["resources"].([]interface{})),
+				ClusterScope: m["cluster_scope"].(bool),
+				Namespaces:   expandStringSlice(m["namespaces"].([]interface{})),
+			})
+		}
+	}
+	if v, ok := in["non_resource_rule"].([]interface{}); ok {
+		for _, r := range v {
+			m := r.(map[string]interface{})
+			obj.NonResourceRules = append(obj.NonResourceRules, nonResourcePolicyRule{
+				Verbs:           expandStringSlice(m["verbs"].([]interface{})),
+				NonResourceURLs: expandStringSlice(m["non_resource_urls"].([]interface{})),
+			})
+		}
+	}
+
+	return obj
+}
+
+// expandPriorityLevelConfigurationSpec expands the spec for the version gv of
+// the API, which determines the name of the concurrency shares field. The raw
+// configuration tells the zero values that are set from the unset ones.
+func expandPriorityLevelConfigurationSpec(l []interface{}, config cty.Value, gv k8sschema.GroupVersion) priorityLevelConfigurationSpec {
+	obj := priorityLevelConfigurationSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["type"].(string); ok {
+		obj.Type = v
+	}
+	if v, ok := in["limited"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		m := v[0].(map[string]interface{})
+		limited := &limitedPriorityLevelConfiguration{}
+		if s, ok := m["nominal_concurrency_shares"].(int); ok && (s > 0 || priorityLevelAttributeConfigured(config, "limited", "nominal_concurrency_shares")) {
+			if usesAssuredConcurrencyShares(gv) {
+				limited.AssuredConcurrencyShares = ptrToInt32(int32(s))
+			} else {
+				limited.NominalConcurrencyShares = ptrToInt32(int32(s))
+			}
+		}
+		if p, ok := m["lendable_percent"].(int); ok && p > 0 {
+			limited.LendablePercent = ptrToInt32(int32(p))
+		}
+		if p, ok := m["borrowing_limit_percent"].(int); ok && (p > 0 || priorityLevelAttributeConfigured(config, "limited", "borrowing_limit_percent")) {
+			limited.BorrowingLimitPercent = ptrToInt32(int32(p))
+		}
+		if lr, ok := m["limit_response"].([]interface{}); ok && len(lr) > 0 && lr[0] != nil {
+			lrm := lr[0].(map[string]interface{})
+			limited.LimitResponse.Type = lrm["type"].(string)
+			// queuing is computed, so it keeps its prior value when the
+			// type changes to Reject, which does not allow it
+			if q, ok := lrm["queuing"].([]interface{}); ok && len(q) > 0 && q[0] != nil && limited.LimitResponse.Type == "Queue" {
+				qm := q[0].(map[string]interface{})
+				limited.LimitResponse.Queuing = &queuingConfiguration{
+					Queues:           int32(qm["queues"].(int)),
+					HandSize:         int32(qm["hand_size"].(int)),
+					QueueLengthLimit: int32(qm["queue_length_limit"].(int)),
+				}
+			}
+		}
+		obj.Limited = limited
+	}
+	if v, ok := in["exempt"].([]interface{}); ok && len(v) > 0 {
+		exempt := &exemptPriorityLevelConfiguration{}
+		if v[0] != nil {
+			m := v[0].(map[string]interface{})
+			if s, ok := m["nominal_concurrency_shares"].(int); ok && (s > 0 || priorityLevelAttributeConfigured(config, "exempt", "nominal_concurrency_shares")) {
+				exempt.NominalConcurrencyShares = ptrToInt32(int32(s))
+			}
+			if p, ok := m["lendable_percent"].(int); ok && p > 0 {
+				exempt.LendablePercent = ptrToInt32(int32(p))
+			}
+		}
+		obj.Exempt = exempt
+	}
+
+	return obj
+}
@@ -0,0 +1,288 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestFlowcontrolGroupVersion(t *testing.T) {
+	cases := []struct {
+		Served          []string
+		ExpectedVersion string
+	}{
+		{[]string{"v1", "v1beta3"}, "v1"},
+		{[]string{"v1beta2", "v1beta3"}, "v1beta3"},
+		{[]string{"v1alpha1", "v1beta1", "v1beta2"}, "v1beta2"},
+		{[]string{"v1alpha1"}, ""},
+		{nil, ""},
+	}
+
+	for _, tc := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var out interface{}
+			switch r.URL.Path {
+			case "/api":
+				out = &metav1.APIVersions{Versions: []string{"v1"}}
+			case "/apis":
+				groups := &metav1.APIGroupList{}
+				if len(tc.Served) > 0 {
+					g := metav1.APIGroup{Name: "flowcontrol.apiserver.k8s.io"}
+					for _, v := range tc.Served {
+						g.Versions = append(g.Versions, metav1.GroupVersionForDiscovery{
+							GroupVersion: "flowcontrol.apiserver.k8s.io/" + v,
+							Version:      v,
+						})
+					}
+					groups.Groups = append(groups.Groups, g)
+				}
+				out = groups
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(out)
+		}))
+
+		dc := discovery.NewDiscoveryClientForConfigOrDie(&restclient.Config{Host: srv.URL})
+		gv, err := flowcontrolGroupVersion(dc)
+		srv.Close()
+
+		if tc.ExpectedVersion == "" {
+			if err == nil {
+				t.Fatalf("Expected an error for served versions %v, got %s", tc.Served, gv)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gv.Version != tc.ExpectedVersion {
+			t.Fatalf("Expected version %s for served versions %v, got %s", tc.ExpectedVersion, tc.Served, gv.Version)
+		}
+	}
+}
+
+func TestExpandPriorityLevelConfigurationSpec(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"type": "Limited",
+		"limited": []interface{}{map[string]interface{}{
+			"nominal_concurrency_shares": 10,
+			"lendable_percent":           0,
+			"borrowing_limit_percent":    0,
+			"limit_response": []interface{}{map[string]interface{}{
+				"type": "Queue",
+				"queuing": []interface{}{map[string]interface{}{
+					"queues":             16,
+					"hand_size":          4,
+					"queue_length_limit": 50,
+				}},
+			}},
+		}},
+		"exempt": []interface{}{},
+	}}
+	queuing := map[string]interface{}{
+		"type": "Queue",
+		"queuing": map[string]interface{}{
+			"queues":           int64(16),
+			"handSize":         int64(4),
+			"queueLengthLimit": int64(50),
+		},
+	}
+
+	cases := []struct {
+		Version        string
+		ExpectedOutput map[string]interface{}
+	}{
+		{
+			"v1",
+			map[string]interface{}{
+				"type": "Limited",
+				"limited": map[string]interface{}{
+					"nominalConcurrencyShares": int64(10),
+					"limitResponse":            queuing,
+				},
+			},
+		},
+		{
+			"v1beta2",
+			map[string]interface{}{
+				"type": "Limited",
+				"limited": map[string]interface{}{
+					"assuredConcurrencyShares": int64(10),
+					"limitResponse":            queuing,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		gv := k8sschema.GroupVersion{Group: "flowcontrol.apiserver.k8s.io", Version: tc.Version}
+		spec := expandPriorityLevelConfigurationSpec(in, cty.NilVal, gv)
+		out, err := toUnstructuredObject(&priorityLevelConfiguration{Spec: spec})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.ExpectedOutput, out["spec"]); diff != "" {
+			t.Fatalf("Unexpected output from expander for %s: mismatch (-want +got):\n%s", tc.Version, diff)
+		}
+
+		// both field names flatten to the same attribute
+		flat := flattenPriorityLevelConfigurationSpec(spec)
+		shares := flat[0].(map[string]interface{})["limited"].([]interface{})[0].(map[string]interface{})["nominal_concurrency_shares"]
+		if shares != 10 {
+			t.Fatalf("Expected nominal_concurrency_shares 10 for %s, got %v", tc.Version, shares)
+		}
+	}
+}
+
+func TestExpandPriorityLevelConfigurationSpecReject(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"type": "Limited",
+		"limited": []interface{}{map[string]interface{}{
+			"nominal_concurrency_shares": 30,
+			"limit_response": []interface{}{map[string]interface{}{
+				"type": "Reject",
+				// left in the state from a previous Queue configuration
+				"queuing": []interface{}{map[string]interface{}{
+					"queues":             64,
+					"hand_size":          8,
+					"queue_length_limit": 50,
+				}},
+			}},
+		}},
+	}}
+	gv := k8sschema.GroupVersion{Group: "flowcontrol.apiserver.k8s.io", Version: "v1"}
+	spec := expandPriorityLevelConfigurationSpec(in, cty.NilVal, gv)
+	if spec.Limited.LimitResponse.Queuing != nil {
+		t.Fatalf("Expected no queuing configuration for the Reject limit response, got %#v", spec.Limited.LimitResponse.Queuing)
+	}
+}
+
+func TestExpandPriorityLevelConfigurationSpecZeroShares(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"type": "Limited",
+		"limited": []interface{}{map[string]interface{}{
+			"nominal_concurrency_shares": 0,
+			"borrowing_limit_percent":    0,
+			"limit_response":             []interface{}{map[string]interface{}{"type": "Reject"}},
+		}},
+	}}
+	gv := k8sschema.GroupVersion{Group: "flowcontrol.apiserver.k8s.io", Version: "v1"}
+
+	// unset attributes are left to the API server defaults
+	spec := expandPriorityLevelConfigurationSpec(in, cty.NilVal, gv)
+	if spec.Limited.NominalConcurrencyShares != nil || spec.Limited.BorrowingLimitPercent != nil {
+		t.Fatalf("Expected no shares and borrowing limit when they are not configured, got %#v", spec.Limited)
+	}
+
+	config, _ := priorityLevelConfigurationConfig(t, map[string]int{"nominal_concurrency_shares": 0, "borrowing_limit_percent": 0})
+	spec = expandPriorityLevelConfigurationSpec(in, config, gv)
+	if s := spec.Limited.NominalConcurrencyShares; s == nil || *s != 0 {
+		t.Fatalf("Expected zero nominal concurrency shares, got %v", s)
+	}
+	if p := spec.Limited.BorrowingLimitPercent; p == nil || *p != 0 {
+		t.Fatalf("Expected a zero borrowing limit, got %v", p)
+	}
+}
+
+func TestResourceKubernetesPriorityLevelConfigurationCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		version  string
+		limited  map[string]int
+		expected string
+	}{
+		{"v1", map[string]int{"lendable_percent": 50, "borrowing_limit_percent": 0}, ""},
+		{"v1beta3", map[string]int{"lendable_percent": 50}, ""},
+		{"v1beta2", map[string]int{"nominal_concurrency_shares": 10}, ""},
+		{"v1beta2", map[string]int{"lendable_percent": 0}, "spec.0.limited.0.lendable_percent: not supported"},
+		{"v1beta1", map[string]int{"borrowing_limit_percent": 10}, "spec.0.limited.0.borrowing_limit_percent: not supported"},
+		{"v1", map[string]int{"nominal_concurrency_shares": 0}, ""},
+		{"v1beta3", map[string]int{"nominal_concurrency_shares": 0}, "spec.0.limited.0.nominal_concurrency_shares: must be at least 1"},
+	}
+
+	for _, tc := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var out interface{}
+			switch r.URL.Path {
+			case "/api":
+				out = &metav1.APIVersions{Versions: []string{"v1"}}
+			case "/apis":
+				out = &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+					Name: "flowcontrol.apiserver.k8s.io",
+					Versions: []metav1.GroupVersionForDiscovery{{
+						GroupVersion: "flowcontrol.apiserver.k8s.io/" + tc.version,
+						Version:      tc.version,
+					}},
+				}}}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(out)
+		}))
+
+		raw, config := priorityLevelConfigurationConfig(t, tc.limited)
+		meta := kubeClientsets{config: &restclient.Config{Host: srv.URL}}
+		_, err := resourceKubernetesPriorityLevelConfiguration().Diff(context.Background(), &terraform.InstanceState{RawConfig: raw}, config, meta)
+		srv.Close()
+
+		name := fmt.Sprintf("%s %v", tc.version, tc.limited)
+		if tc.expected == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Fatalf("%s: expected an error containing %q, got %v", name, tc.expected, err)
+		}
+	}
+}
+
+// priorityLevelConfigurationConfig returns the raw and the legacy configuration
+// of a Limited priority level with the given attributes in the limited block.
+func priorityLevelConfigurationConfig(t *testing.T, limited map[string]int) (cty.Value, *terraform.ResourceConfig) {
+	rawLimited := map[string]cty.Value{
+		"limit_response": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"type": cty.StringVal("Reject")})}),
+	}
+	legacyLimited := map[string]interface{}{
+		"limit_response": []interface{}{map[string]interface{}{"type": "Reject"}},
+	}
+	for k, v := range limited {
+		rawLimited[k] = cty.NumberIntVal(int64(v))
+		legacyLimited[k] = v
+	}
+	raw, err := resourceKubernetesPriorityLevelConfiguration().CoreConfigSchema().CoerceValue(cty.ObjectVal(map[string]cty.Value{
+		"metadata": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("test")})}),
+		"spec": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"type":    cty.StringVal("Limited"),
+			"limited": cty.ListVal([]cty.Value{cty.ObjectVal(rawLimited)}),
+		})}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "test"}},
+		"spec": []interface{}{map[string]interface{}{
+			"type":    "Limited",
+			"limited": []interface{}{legacyLimited},
+		}},
+	})
+	return raw, legacy
+}
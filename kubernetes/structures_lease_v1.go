@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"time"
+
+	coordination "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func expandLeaseV1Spec(l []interface{}) (coordination.LeaseSpec, error) {
+	obj := coordination.LeaseSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["holder_identity"].(string); ok && v != "" {
+		obj.HolderIdentity = ptrToString(v)
+	}
+	if v, ok := in["lease_duration_seconds"].(int); ok && v > 0 {
+		obj.LeaseDurationSeconds = ptrToInt32(int32(v))
+	}
+	if v, ok := in["acquire_time"].(string); ok && v != "" {
+		t, err := expandMicroTime(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.AcquireTime = t
+	}
+	if v, ok := in["renew_time"].(string); ok && v != "" {
+		t, err := expandMicroTime(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.RenewTime = t
+	}
+	if v, ok := in["lease_transitions"].(int); ok && v > 0 {
+		obj.LeaseTransitions = ptrToInt32(int32(v))
+	}
+	return obj, nil
+}
+
+func flattenLeaseV1Spec(in coordination.LeaseSpec) []interface{} {
+	att := make(map[string]interface{})
+	if in.HolderIdentity != nil {
+		att["holder_identity"] = *in.HolderIdentity
+	}
+	if in.LeaseDurationSeconds != nil {
+		att["lease_duration_seconds"] = int(*in.LeaseDurationSeconds)
+	}
+	if in.AcquireTime != nil {
+		att["acquire_time"] = flattenMicroTime(*in.AcquireTime)
+	}
+	if in.RenewTime != nil {
+		att["renew_time"] = flattenMicroTime(*in.RenewTime)
+	}
+	if in.LeaseTransitions != nil {
+		att["lease_transitions"] = int(*in.LeaseTransitions)
+	}
+	if len(att) == 0 {
+		return []interface{}{}
+	}
+	return []interface{}{att}
+}
+
+func expandMicroTime(s string) (*metav1.MicroTime, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	mt := metav1.NewMicroTime(t)
+	return &mt, nil
+}
+
+func flattenMicroTime(t metav1.MicroTime) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
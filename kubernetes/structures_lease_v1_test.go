@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	coordination "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpandLeaseV1Spec(t *testing.T) {
+	acquireTime := metav1.NewMicroTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	renewTime := metav1.NewMicroTime(time.Date(2024, 1, 1, 0, 1, 0, 123456000, time.UTC))
+
+	cases := []struct {
+		Input          []interface{}
+		ExpectedOutput coordination.LeaseSpec
+	}{
+		{
+			[]interface{}{map[string]interface{}{
+				"holder_identity":        "terraform",
+				"lease_duration_seconds": 30,
+				"acquire_time":           "2024-01-01T00:00:00Z",
+				"renew_time":             "2024-01-01T00:01:00.123456Z",
+				"lease_transitions":      2,
+			}},
+			coordination.LeaseSpec{
+				HolderIdentity:       ptrToString("terraform"),
+				LeaseDurationSeconds: ptrToInt32(30),
+				AcquireTime:          &acquireTime,
+				RenewTime:            &renewTime,
+				LeaseTransitions:     ptrToInt32(2),
+			},
+		},
+		{
+			[]interface{}{map[string]interface{}{
+				"holder_identity":        "",
+				"lease_duration_seconds": 0,
+				"acquire_time":           "",
+				"renew_time":             "",
+				"lease_transitions":      0,
+			}},
+			coordination.LeaseSpec{},
+		},
+		{
+			[]interface{}{},
+			coordination.LeaseSpec{},
+		},
+	}
+
+	for _, tc := range cases {
+		output, err := expandLeaseV1Spec(tc.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from expander: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestFlattenLeaseV1Spec(t *testing.T) {
+	renewTime := metav1.NewMicroTime(time.Date(2024, 1, 1, 0, 1, 0, 123456000, time.UTC))
+
+	cases := []struct {
+		Input          coordination.LeaseSpec
+		ExpectedOutput []interface{}
+	}{
+		{
+			coordination.LeaseSpec{
+				HolderIdentity: ptrToString("terraform"),
+				RenewTime:      &renewTime,
+			},
+			[]interface{}{map[string]interface{}{
+				"holder_identity": "terraform",
+				"renew_time":      "2024-01-01T00:01:00.123456Z",
+			}},
+		},
+		{
+			coordination.LeaseSpec{},
+			[]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenLeaseV1Spec(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// managedFieldSet returns the set of the fields of an object that are managed
+// by the given field manager, whatever the operation that set them.
+func managedFieldSet(managedFields []metav1.ManagedFieldsEntry, manager string) (*fieldpath.Set, error) {
+	set := &fieldpath.Set{}
+	for _, m := range managedFields {
+		if m.Manager != manager || m.FieldsV1 == nil {
+			continue
+		}
+		fs := &fieldpath.Set{}
+		if err := fs.FromJSON(bytes.NewReader(m.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("failed to decode the fields managed by %q: %s", manager, err)
+		}
+		set = set.Union(fs)
+	}
+	return set, nil
+}
+
+// extractManagedFields returns the part of obj made of the fields in set,
+// with their current values.
+func extractManagedFields(obj map[string]interface{}, set *fieldpath.Set) map[string]interface{} {
+	var result interface{} = map[string]interface{}{}
+	set.Leaves().Iterate(func(p fieldpath.Path) {
+		if v, ok := valueAtFieldPath(obj, p); ok {
+			result = insertAtFieldPath(result, p, v)
+		}
+	})
+	return result.(map[string]interface{})
+}
+
+func valueAtFieldPath(obj interface{}, p fieldpath.Path) (interface{}, bool) {
+	cur := obj
+	for _, pe := range p {
+		switch {
+		case pe.FieldName != nil:
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if cur, ok = m[*pe.FieldName]; !ok {
+				return nil, false
+			}
+		case pe.Index != nil:
+			l, ok := cur.([]interface{})
+			if !ok || *pe.Index >= len(l) {
+				return nil, false
+			}
+			cur = l[*pe.Index]
+		default:
+			l, ok := cur.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			found := false
+			for _, e := range l {
+				if listElementMatches(e, pe) {
+					cur = e
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		}
+	}
+	return cur, true
+}
+
+// listElementMatches tells whether the element of an associative list or of a
+// set is the one selected by the path element.
+func listElementMatches(e interface{}, pe fieldpath.PathElement) bool {
+	if pe.Value != nil {
+		return value.Equals(value.NewValueInterface(e), *pe.Value)
+	}
+	if pe.Key == nil {
+		return false
+	}
+	m, ok := e.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, f := range *pe.Key {
+		v, ok := m[f.Name]
+		if !ok || !value.Equals(value.NewValueInterface(v), f.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func insertAtFieldPath(node interface{}, p fieldpath.Path, v interface{}) interface{} {
+	if len(p) == 0 {
+		return runtime.DeepCopyJSONValue(v)
+	}
+	pe := p[0]
+	switch {
+	case pe.FieldName != nil:
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+		}
+		m[*pe.FieldName] = insertAtFieldPath(m[*pe.FieldName], p[1:], v)
+		return m
+	case pe.Index != nil:
+		l, _ := node.([]interface{})
+		for len(l) <= *pe.Index {
+			l = append(l, nil)
+		}
+		l[*pe.Index] = insertAtFieldPath(l[*pe.Index], p[1:], v)
+		return l
+	case pe.Value != nil:
+		l, _ := node.([]interface{})
+		return append(l, runtime.DeepCopyJSONValue(v))
+	default:
+		l, _ := node.([]interface{})
+		for i, e := range l {
+			if listElementMatches(e, pe) {
+				l[i] = insertAtFieldPath(e, p[1:], v)
+				return l
+			}
+		}
+		e := map[string]interface{}{}
+		for _, f := range *pe.Key {
+			e[f.Name] = f.Value.Unstructured()
+		}
+		return append(l, insertAtFieldPath(e, p[1:], v))
+	}
+}
+
+// patchDocumentsEqual tells whether two JSON documents are semantically equal.
+func patchDocumentsEqual(a, b string) bool {
+	var va, vb interface{}
+	if err := json.Unmarshal([]byte(a), &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExtractManagedFields(t *testing.T) {
+	obj := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "DaemonSet",
+		"metadata": map[string]interface{}{
+			"name":      "kube-proxy",
+			"namespace": "kube-system",
+			"labels": map[string]interface{}{
+				"k8s-app": "kube-proxy",
+				"team":    "platform",
+			},
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "kube-proxy",
+							"image": "registry.k8s.io/kube-proxy:v1.25.5",
+						},
+					},
+					"tolerations": []interface{}{
+						map[string]interface{}{
+							"operator": "Exists",
+						},
+						map[string]interface{}{
+							"key":      "dedicated",
+							"operator": "Equal",
+							"value":    "gpu",
+						},
+					},
+				},
+			},
+		},
+	}
+	managedFields := []metav1.ManagedFieldsEntry{
+		{
+			Manager:    "kubeadm",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{".":{},"f:k8s-app":{}}},"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"kube-proxy\"}":{".":{},"f:image":{},"f:name":{}}},"f:tolerations":{}}}}}`)},
+		},
+		{
+			Manager:    "Terraform",
+			Operation:  metav1.ManagedFieldsOperationApply,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}}}`)},
+		},
+		{
+			Manager:    "Terraform",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"kube-proxy\"}":{"f:image":{}}},"f:tolerations":{"v:{\"key\":\"dedicated\",\"operator\":\"Equal\",\"value\":\"gpu\"}":{}}}}}}`)},
+		},
+	}
+
+	set, err := managedFieldSet(managedFields, "Terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"team": "platform",
+			},
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "kube-proxy",
+							"image": "registry.k8s.io/kube-proxy:v1.25.5",
+						},
+					},
+					"tolerations": []interface{}{
+						map[string]interface{}{
+							"key":      "dedicated",
+							"operator": "Equal",
+							"value":    "gpu",
+						},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, extractManagedFields(obj, set)); diff != "" {
+		t.Fatalf("unexpected managed fields (-want +got):\n%s", diff)
+	}
+
+	set, err = managedFieldSet(managedFields, "kubectl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]interface{}{}, extractManagedFields(obj, set)); diff != "" {
+		t.Fatalf("unexpected managed fields (-want +got):\n%s", diff)
+	}
+}
+
+func TestPatchDocumentsEqual(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{`{"data":{"a":"1","b":"2"}}`, `{"data": {"b": "2", "a": "1"}}`, true},
+		{`{"data":{"a":"1"}}`, `{"data":{"a":"2"}}`, false},
+		{`[{"op":"add","path":"/data/a","value":"1"}]`, `[{"path":"/data/a","op":"add","value":"1"}]`, true},
+		{`{"data":{}}`, `not json`, false},
+	}
+	for _, c := range cases {
+		if got := patchDocumentsEqual(c.a, c.b); got != c.expected {
+			t.Errorf("patchDocumentsEqual(%s, %s): expected %t, got %t", c.a, c.b, c.expected, got)
+		}
+	}
+}
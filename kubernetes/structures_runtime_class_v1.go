@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	v1 "k8s.io/api/core/v1"
+	node "k8s.io/api/node/v1"
+)
+
+func expandRuntimeClassV1Overhead(l []interface{}) (*node.Overhead, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &node.Overhead{}
+
+	if v, ok := in["pod_fixed"].(map[string]interface{}); ok && len(v) > 0 {
+		rl, err := expandMapToResourceList(v)
+		if err != nil {
+			return nil, err
+		}
+		obj.PodFixed = *rl
+	}
+	return obj, nil
+}
+
+func expandRuntimeClassV1Scheduling(l []interface{}) (*node.Scheduling, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &node.Scheduling{}
+
+	if v, ok := in["node_selector"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.NodeSelector = expandStringMap(v)
+	}
+	if v, ok := in["toleration"].([]interface{}); ok && len(v) > 0 {
+		ts, err := expandTolerations(v)
+		if err != nil {
+			return nil, err
+		}
+		obj.Tolerations = make([]v1.Toleration, len(ts))
+		for i, t := range ts {
+			obj.Tolerations[i] = *t
+		}
+	}
+	return obj, nil
+}
+
+func flattenRuntimeClassV1Overhead(in *node.Overhead) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+	att := make(map[string]interface{})
+	if len(in.PodFixed) > 0 {
+		att["pod_fixed"] = flattenResourceList(in.PodFixed)
+	}
+	return []interface{}{att}
+}
+
+func flattenRuntimeClassV1Scheduling(in *node.Scheduling) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+	att := make(map[string]interface{})
+	if len(in.NodeSelector) > 0 {
+		att["node_selector"] = in.NodeSelector
+	}
+	if len(in.Tolerations) > 0 {
+		att["toleration"] = flattenTolerations(in.Tolerations)
+	}
+	return []interface{}{att}
+}
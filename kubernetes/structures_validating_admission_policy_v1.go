@@ -0,0 +1,370 @@
+package kubernetes
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The vendored k8s.io/api predates the ValidatingAdmissionPolicy API, which
+// became available in admissionregistration.k8s.io/v1 with Kubernetes 1.30.
+// The types below mirror the fields of that API version which the provider
+// manages, and the objects are sent to the API server with the dynamic client.
+
+var (
+	validatingAdmissionPolicyV1GVR = k8sschema.GroupVersionResource{
+		Group:    admissionregistrationv1.GroupName,
+		Version:  "v1",
+		Resource: "validatingadmissionpolicies",
+	}
+	validatingAdmissionPolicyBindingV1GVR = k8sschema.GroupVersionResource{
+		Group:    admissionregistrationv1.GroupName,
+		Version:  "v1",
+		Resource: "validatingadmissionpolicybindings",
+	}
+)
+
+type validatingAdmissionPolicyV1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              validatingAdmissionPolicySpecV1 `json:"spec,omitempty"`
+}
+
+type validatingAdmissionPolicySpecV1 struct {
+	ParamKind        *paramKindV1                               `json:"paramKind,omitempty"`
+	MatchConstraints *matchResourcesV1                          `json:"matchConstraints,omitempty"`
+	Validations      []validationV1                             `json:"validations,omitempty"`
+	FailurePolicy    *admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+	AuditAnnotations []auditAnnotationV1                        `json:"auditAnnotations,omitempty"`
+	MatchConditions  []namedExpressionV1                        `json:"matchConditions,omitempty"`
+	Variables        []namedExpressionV1                        `json:"variables,omitempty"`
+}
+
+type paramKindV1 struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+}
+
+type matchResourcesV1 struct {
+	NamespaceSelector    *metav1.LabelSelector                    `json:"namespaceSelector,omitempty"`
+	ObjectSelector       *metav1.LabelSelector                    `json:"objectSelector,omitempty"`
+	ResourceRules        []namedRuleWithOperationsV1              `json:"resourceRules,omitempty"`
+	ExcludeResourceRules []namedRuleWithOperationsV1              `json:"excludeResourceRules,omitempty"`
+	MatchPolicy          *admissionregistrationv1.MatchPolicyType `json:"matchPolicy,omitempty"`
+}
+
+type namedRuleWithOperationsV1 struct {
+	ResourceNames                              []string `json:"resourceNames,omitempty"`
+	admissionregistrationv1.RuleWithOperations `json:",inline"`
+}
+
+type validationV1 struct {
+	Expression        string               `json:"expression"`
+	Message           string               `json:"message,omitempty"`
+	Reason            *metav1.StatusReason `json:"reason,omitempty"`
+	MessageExpression string               `json:"messageExpression,omitempty"`
+}
+
+type auditAnnotationV1 struct {
+	Key             string `json:"key"`
+	ValueExpression string `json:"valueExpression"`
+}
+
+// namedExpressionV1 holds both match conditions and variables, which share
+// the same fields.
+type namedExpressionV1 struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+type validatingAdmissionPolicyBindingV1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              validatingAdmissionPolicyBindingSpecV1 `json:"spec,omitempty"`
+}
+
+type validatingAdmissionPolicyBindingSpecV1 struct {
+	PolicyName        string            `json:"policyName,omitempty"`
+	ParamRef          *paramRefV1       `json:"paramRef,omitempty"`
+	MatchResources    *matchResourcesV1 `json:"matchResources,omitempty"`
+	ValidationActions []string          `json:"validationActions,omitempty"`
+}
+
+type paramRefV1 struct {
+	Name                    string                `json:"name,omitempty"`
+	Namespace               string                `json:"namespace,omitempty"`
+	Selector                *metav1.LabelSelector `json:"selector,omitempty"`
+	ParameterNotFoundAction *string               `json:"parameterNotFoundAction,omitempty"`
+}
+
+func toUnstructuredObject(obj interface{}) (map[string]interface{}, error) {
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+func fromUnstructuredObject(u map[string]interface{}, obj interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u, obj)
+}
+
+// Flatteners
+
+func flattenValidatingAdmissionPolicyV1Spec(in validatingAdmissionPolicySpecV1) []interface{} {
+	att := make(map[string]interface{})
+
+	if in.ParamKind != nil {
+		att["param_kind"] = []interface{}{map[string]interface{}{
+			"api_version": in.ParamKind.APIVersion,
+			"kind":        in.ParamKind.Kind,
+		}}
+	}
+	if in.MatchConstraints != nil {
+		att["match_constraints"] = flattenMatchResourcesV1(*in.MatchConstraints)
+	}
+
+	validations := make([]interface{}, len(in.Validations))
+	for i, v := range in.Validations {
+		m := map[string]interface{}{
+			"expression":         v.Expression,
+			"message":            v.Message,
+			"message_expression": v.MessageExpression,
+		}
+		if v.Reason != nil {
+			m["reason"] = string(*v.Reason)
+		}
+		validations[i] = m
+	}
+	att["validation"] = validations
+
+	if in.FailurePolicy != nil {
+		att["failure_policy"] = string(*in.FailurePolicy)
+	}
+
+	annotations := make([]interface{}, len(in.AuditAnnotations))
+	for i, a := range in.AuditAnnotations {
+		annotations[i] = map[string]interface{}{
+			"key":              a.Key,
+			"value_expression": a.ValueExpression,
+		}
+	}
+	att["audit_annotation"] = annotations
+
+	att["match_condition"] = flattenNamedExpressionsV1(in.MatchConditions)
+	att["variable"] = flattenNamedExpressionsV1(in.Variables)
+
+	return []interface{}{att}
+}
+
+func flattenMatchResourcesV1(in matchResourcesV1) []interface{} {
+	att := make(map[string]interface{})
+
+	// The API server defaults the selectors to an empty selector, which
+	// matches everything like an unset selector does.
+	if in.NamespaceSelector != nil {
+		if in.NamespaceSelector.MatchExpressions != nil || in.NamespaceSelector.MatchLabels != nil {
+			att["namespace_selector"] = flattenLabelSelector(in.NamespaceSelector)
+		}
+	}
+	if in.ObjectSelector != nil {
+		if in.ObjectSelector.MatchExpressions != nil || in.ObjectSelector.MatchLabels != nil {
+			att["object_selector"] = flattenLabelSelector(in.ObjectSelector)
+		}
+	}
+	att["resource_rule"] = flattenNamedRulesWithOperationsV1(in.ResourceRules)
+	att["exclude_resource_rule"] = flattenNamedRulesWithOperationsV1(in.ExcludeResourceRules)
+	if in.MatchPolicy != nil {
+		att["match_policy"] = string(*in.MatchPolicy)
+	}
+
+	return []interface{}{att}
+}
+
+func flattenNamedRulesWithOperationsV1(in []namedRuleWithOperationsV1) []interface{} {
+	rules := make([]interface{}, len(in))
+	for i, r := range in {
+		att := flattenRuleWithOperations(r.RuleWithOperations)
+		att["resource_names"] = r.ResourceNames
+		rules[i] = att
+	}
+	return rules
+}
+
+func flattenNamedExpressionsV1(in []namedExpressionV1) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, e := range in {
+		out[i] = map[string]interface{}{
+			"name":       e.Name,
+			"expression": e.Expression,
+		}
+	}
+	return out
+}
+
+func flattenValidatingAdmissionPolicyBindingV1Spec(in validatingAdmissionPolicyBindingSpecV1) []interface{} {
+	att := make(map[string]interface{})
+
+	att["policy_name"] = in.PolicyName
+	if in.ParamRef != nil {
+		ref := map[string]interface{}{
+			"name":      in.ParamRef.Name,
+			"namespace": in.ParamRef.Namespace,
+		}
+		if in.ParamRef.Selector != nil {
+			ref["selector"] = flattenLabelSelector(in.ParamRef.Selector)
+		}
+		if in.ParamRef.ParameterNotFoundAction != nil {
+			ref["parameter_not_found_action"] = *in.ParamRef.ParameterNotFoundAction
+		}
+		att["param_ref"] = []interface{}{ref}
+	}
+	if in.MatchResources != nil {
+		att["match_resources"] = flattenMatchResourcesV1(*in.MatchResources)
+	}
+	att["validation_actions"] = in.ValidationActions
+
+	return []interface{}{att}
+}
+
+// Expanders
+
+func expandValidatingAdmissionPolicyV1Spec(l []interface{}) validatingAdmissionPolicySpecV1 {
+	obj := validatingAdmissionPolicySpecV1{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["param_kind"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		p := v[0].(map[string]interface{})
+		obj.ParamKind = &paramKindV1{
+			APIVersion: p["api_version"].(string),
+			Kind:       p["kind"].(string),
+		}
+	}
+	if v, ok := in["match_constraints"].([]interface{}); ok && len(v) > 0 {
+		obj.MatchConstraints = expandMatchResourcesV1(v)
+	}
+	if v, ok := in["validation"].([]interface{}); ok {
+		for _, e := range v {
+			m := e.(map[string]interface{})
+			validation := validationV1{
+				Expression:        m["expression"].(string),
+				Message:           m["message"].(string),
+				MessageExpression: m["message_expression"].(string),
+			}
+			if r, ok := m["reason"].(string); ok && r != "" {
+				reason := metav1.StatusReason(r)
+				validation.Reason = &reason
+			}
+			obj.Validations = append(obj.Validations, validation)
+		}
+	}
+	if v, ok := in["failure_policy"].(string); ok && v != "" {
+		policy := admissionregistrationv1.FailurePolicyType(v)
+		obj.FailurePolicy = &policy
+	}
+	if v, ok := in["audit_annotation"].([]interface{}); ok {
+		for _, e := range v {
+			m := e.(map[string]interface{})
+			obj.AuditAnnotations = append(obj.AuditAnnotations, auditAnnotationV1{
+				Key:             m["key"].(string),
+				ValueExpression: m["value_expression"].(string),
+			})
+		}
+	}
+	if v, ok := in["match_condition"].([]interface{}); ok {
+		obj.MatchConditions = expandNamedExpressionsV1(v)
+	}
+	if v, ok := in["variable"].([]interface{}); ok {
+		obj.Variables = expandNamedExpressionsV1(v)
+	}
+
+	return obj
+}
+
+func expandMatchResourcesV1(l []interface{}) *matchResourcesV1 {
+	obj := &matchResourcesV1{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["namespace_selector"].([]interface{}); ok && len(v) != 0 {
+		obj.NamespaceSelector = expandLabelSelector(v)
+	}
+	if v, ok := in["object_selector"].([]interface{}); ok && len(v) != 0 {
+		obj.ObjectSelector = expandLabelSelector(v)
+	}
+	if v, ok := in["resource_rule"].([]interface{}); ok {
+		obj.ResourceRules = expandNamedRulesWithOperationsV1(v)
+	}
+	if v, ok := in["exclude_resource_rule"].([]interface{}); ok {
+		obj.ExcludeResourceRules = expandNamedRulesWithOperationsV1(v)
+	}
+	if v, ok := in["match_policy"].(string); ok && v != "" {
+		policy := admissionregistrationv1.MatchPolicyType(v)
+		obj.MatchPolicy = &policy
+	}
+
+	return obj
+}
+
+func expandNamedRulesWithOperationsV1(l []interface{}) []namedRuleWithOperationsV1 {
+	rules := make([]namedRuleWithOperationsV1, 0, len(l))
+	for _, r := range l {
+		m := r.(map[string]interface{})
+		rule := namedRuleWithOperationsV1{
+			RuleWithOperations: expandRuleWithOperations(m),
+		}
+		if v, ok := m["resource_names"].([]interface{}); ok {
+			rule.ResourceNames = expandStringSlice(v)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func expandNamedExpressionsV1(l []interface{}) []namedExpressionV1 {
+	out := make([]namedExpressionV1, 0, len(l))
+	for _, e := range l {
+		m := e.(map[string]interface{})
+		out = append(out, namedExpressionV1{
+			Name:       m["name"].(string),
+			Expression: m["expression"].(string),
+		})
+	}
+	return out
+}
+
+func expandValidatingAdmissionPolicyBindingV1Spec(l []interface{}) validatingAdmissionPolicyBindingSpecV1 {
+	obj := validatingAdmissionPolicyBindingSpecV1{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["policy_name"].(string); ok {
+		obj.PolicyName = v
+	}
+	if v, ok := in["param_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		p := v[0].(map[string]interface{})
+		ref := &paramRefV1{
+			Name:      p["name"].(string),
+			Namespace: p["namespace"].(string),
+		}
+		if s, ok := p["selector"].([]interface{}); ok && len(s) != 0 {
+			ref.Selector = expandLabelSelector(s)
+		}
+		if a, ok := p["parameter_not_found_action"].(string); ok && a != "" {
+			ref.ParameterNotFoundAction = ptrToString(a)
+		}
+		obj.ParamRef = ref
+	}
+	if v, ok := in["match_resources"].([]interface{}); ok && len(v) > 0 {
+		obj.MatchResources = expandMatchResourcesV1(v)
+	}
+	if v, ok := in["validation_actions"].([]interface{}); ok {
+		obj.ValidationActions = expandStringSlice(v)
+	}
+
+	return obj
+}
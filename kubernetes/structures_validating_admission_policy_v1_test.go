@@ -0,0 +1,132 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandValidatingAdmissionPolicyV1Spec(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"param_kind": []interface{}{map[string]interface{}{
+			"api_version": "v1",
+			"kind":        "ConfigMap",
+		}},
+		"match_constraints": []interface{}{map[string]interface{}{
+			"namespace_selector": []interface{}{},
+			"object_selector":    []interface{}{},
+			"resource_rule": []interface{}{map[string]interface{}{
+				"api_groups":     []interface{}{"apps"},
+				"api_versions":   []interface{}{"v1"},
+				"operations":     []interface{}{"CREATE", "UPDATE"},
+				"resources":      []interface{}{"deployments"},
+				"resource_names": []interface{}{},
+				"scope":          "*",
+			}},
+			"exclude_resource_rule": []interface{}{},
+			"match_policy":          "Equivalent",
+		}},
+		"validation": []interface{}{map[string]interface{}{
+			"expression":         "object.spec.replicas <= int(params.data.maxReplicas)",
+			"message":            "",
+			"message_expression": "'too many replicas'",
+			"reason":             "Forbidden",
+		}},
+		"failure_policy":   "Fail",
+		"audit_annotation": []interface{}{},
+		"match_condition":  []interface{}{},
+		"variable": []interface{}{map[string]interface{}{
+			"name":       "replicas",
+			"expression": "object.spec.replicas",
+		}},
+	}}
+	expected := map[string]interface{}{
+		"paramKind": map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+		},
+		"matchConstraints": map[string]interface{}{
+			"resourceRules": []interface{}{map[string]interface{}{
+				"apiGroups":   []interface{}{"apps"},
+				"apiVersions": []interface{}{"v1"},
+				"operations":  []interface{}{"CREATE", "UPDATE"},
+				"resources":   []interface{}{"deployments"},
+				"scope":       "*",
+			}},
+			"matchPolicy": "Equivalent",
+		},
+		"validations": []interface{}{map[string]interface{}{
+			"expression":        "object.spec.replicas <= int(params.data.maxReplicas)",
+			"messageExpression": "'too many replicas'",
+			"reason":            "Forbidden",
+		}},
+		"failurePolicy": "Fail",
+		"variables": []interface{}{map[string]interface{}{
+			"name":       "replicas",
+			"expression": "object.spec.replicas",
+		}},
+	}
+
+	spec := expandValidatingAdmissionPolicyV1Spec(in)
+	out, err := toUnstructuredObject(&validatingAdmissionPolicyV1{Spec: spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, out["spec"]); diff != "" {
+		t.Fatalf("Unexpected output from expander: mismatch (-want +got):\n%s", diff)
+	}
+
+	policy := validatingAdmissionPolicyV1{}
+	if err := fromUnstructuredObject(out, &policy); err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := toUnstructuredObject(&policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(out, roundTrip); diff != "" {
+		t.Fatalf("Unexpected conversion from unstructured: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFlattenValidatingAdmissionPolicyBindingV1Spec(t *testing.T) {
+	in := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"policyName": "replica-limit",
+			"paramRef": map[string]interface{}{
+				"name":                    "replica-limit",
+				"namespace":               "default",
+				"parameterNotFoundAction": "Deny",
+			},
+			"matchResources": map[string]interface{}{
+				"namespaceSelector": map[string]interface{}{},
+				"objectSelector":    map[string]interface{}{},
+				"matchPolicy":       "Equivalent",
+			},
+			"validationActions": []interface{}{"Deny", "Audit"},
+		},
+	}
+	expected := []interface{}{map[string]interface{}{
+		"policy_name": "replica-limit",
+		"param_ref": []interface{}{map[string]interface{}{
+			"name":                       "replica-limit",
+			"namespace":                  "default",
+			"parameter_not_found_action": "Deny",
+		}},
+		"match_resources": []interface{}{map[string]interface{}{
+			"resource_rule":         []interface{}{},
+			"exclude_resource_rule": []interface{}{},
+			"match_policy":          "Equivalent",
+		}},
+		"validation_actions": []string{"Deny", "Audit"},
+	}}
+
+	binding := validatingAdmissionPolicyBindingV1{}
+	if err := fromUnstructuredObject(in, &binding); err != nil {
+		t.Fatal(err)
+	}
+	output := flattenValidatingAdmissionPolicyBindingV1Spec(binding.Spec)
+	if diff := cmp.Diff(expected, output); diff != "" {
+		t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+	}
+}
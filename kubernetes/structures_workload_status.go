@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func flattenWorkloadTime(t *metav1.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func flattenWorkloadCondition(condType string, status corev1.ConditionStatus, reason, message string, lastTransitionTime metav1.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 condType,
+		"status":               string(status),
+		"reason":               reason,
+		"message":              message,
+		"last_transition_time": flattenWorkloadTime(&lastTransitionTime),
+	}
+}
+
+func flattenDeploymentStatus(in appsv1.DeploymentStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenWorkloadCondition(string(c.Type), c.Status, c.Reason, c.Message, c.LastTransitionTime)
+	}
+	att := map[string]interface{}{
+		"observed_generation":  int(in.ObservedGeneration),
+		"replicas":             int(in.Replicas),
+		"updated_replicas":     int(in.UpdatedReplicas),
+		"ready_replicas":       int(in.ReadyReplicas),
+		"available_replicas":   int(in.AvailableReplicas),
+		"unavailable_replicas": int(in.UnavailableReplicas),
+		"conditions":           conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenStatefulSetStatus(in appsv1.StatefulSetStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenWorkloadCondition(string(c.Type), c.Status, c.Reason, c.Message, c.LastTransitionTime)
+	}
+	att := map[string]interface{}{
+		"observed_generation": int(in.ObservedGeneration),
+		"replicas":            int(in.Replicas),
+		"ready_replicas":      int(in.ReadyReplicas),
+		"current_replicas":    int(in.CurrentReplicas),
+		"updated_replicas":    int(in.UpdatedReplicas),
+		"available_replicas":  int(in.AvailableReplicas),
+		"current_revision":    in.CurrentRevision,
+		"update_revision":     in.UpdateRevision,
+		"conditions":          conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenDaemonSetStatus(in appsv1.DaemonSetStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenWorkloadCondition(string(c.Type), c.Status, c.Reason, c.Message, c.LastTransitionTime)
+	}
+	att := map[string]interface{}{
+		"observed_generation":      int(in.ObservedGeneration),
+		"current_number_scheduled": int(in.CurrentNumberScheduled),
+		"desired_number_scheduled": int(in.DesiredNumberScheduled),
+		"number_misscheduled":      int(in.NumberMisscheduled),
+		"number_ready":             int(in.NumberReady),
+		"updated_number_scheduled": int(in.UpdatedNumberScheduled),
+		"number_available":         int(in.NumberAvailable),
+		"number_unavailable":       int(in.NumberUnavailable),
+		"conditions":               conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenJobStatus(in batchv1.JobStatus) []interface{} {
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = flattenWorkloadCondition(string(c.Type), c.Status, c.Reason, c.Message, c.LastTransitionTime)
+	}
+	att := map[string]interface{}{
+		"active":          int(in.Active),
+		"succeeded":       int(in.Succeeded),
+		"failed":          int(in.Failed),
+		"start_time":      flattenWorkloadTime(in.StartTime),
+		"completion_time": flattenWorkloadTime(in.CompletionTime),
+		"conditions":      conditions,
+	}
+	return []interface{}{att}
+}
+
+func flattenCronJobStatus(in batchv1.CronJobStatus) []interface{} {
+	active := make([]interface{}, len(in.Active))
+	for i, ref := range in.Active {
+		active[i] = ref.Name
+	}
+	att := map[string]interface{}{
+		"active":               active,
+		"last_schedule_time":   flattenWorkloadTime(in.LastScheduleTime),
+		"last_successful_time": flattenWorkloadTime(in.LastSuccessfulTime),
+	}
+	return []interface{}{att}
+}
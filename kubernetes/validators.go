@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/cel-go/cel"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apiValidation "k8s.io/apimachinery/pkg/api/validation"
@@ -290,3 +292,36 @@ func validateTypeStringNullableIntOrPercent(v interface{}, key string) (ws []str
 
 	return
 }
+
+func validateRenewBefore(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s: cannot parse '%s' as a duration: %s", key, v, err))
+		return
+	}
+	if d < 0 {
+		es = append(es, fmt.Errorf("%s must not be negative", key))
+	}
+	return
+}
+
+// validateCELExpression checks the syntax of a CEL expression, such as the
+// expressions of admission policies. The variables and functions that the
+// expression refers to are only known to the API server, so they are not checked.
+func validateCELExpression(v interface{}, k string) (ws []string, es []error) {
+	expr, ok := v.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("%s: expected a string", k))
+		return
+	}
+	env, err := cel.NewEnv()
+	if err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+		return
+	}
+	if _, issues := env.Parse(expr); issues != nil && issues.Err() != nil {
+		es = append(es, fmt.Errorf("%s is not a valid CEL expression: %s", k, issues.Err()))
+	}
+	return
+}
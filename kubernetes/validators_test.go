@@ -161,3 +161,31 @@ func TestValidateTypeStringNullableIntOrPercent(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateCELExpression(t *testing.T) {
+	validCases := []string{
+		"object.spec.replicas <= 5",
+		"has(object.metadata.labels) && 'team' in object.metadata.labels",
+		"object.spec.containers.all(c, c.image.startsWith(params.registry))",
+		"variables.foo == 'bar'",
+	}
+	for _, expr := range validCases {
+		_, es := validateCELExpression(expr, "expression")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", expr, es)
+		}
+	}
+
+	invalidCases := []interface{}{
+		nil,
+		"object.spec.replicas <=",
+		"object.spec.containers.all(c, c.image",
+		"'unterminated",
+	}
+	for _, expr := range invalidCases {
+		_, es := validateCELExpression(expr, "expression")
+		if len(es) == 0 {
+			t.Fatalf("Expected %#v to be invalid", expr)
+		}
+	}
+}
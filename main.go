@@ -27,7 +27,7 @@ func main() {
 	debugFlag := flag.Bool("debug", false, "Start provider in stand-alone debug mode.")
 	flag.Parse()
 
-	mainProvider := kubernetes.Provider().GRPCProvider
+	mainProvider := kubernetes.ProviderServer
 	manifestProvider := manifest.Provider()
 
 	ctx := context.Background()
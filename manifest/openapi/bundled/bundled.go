@@ -0,0 +1,95 @@
+// Package bundled provides the OpenAPI v2 specs of the built-in API groups of
+// several Kubernetes minor versions. They let the provider type built-in
+// resources without downloading the spec from the cluster, or when the
+// cluster cannot be reached at all.
+//
+// The specs are generated with gen.go and only contain the definitions of
+// the built-in types, without their descriptions.
+package bundled
+
+import (
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+//go:embed specs/*.json.gz
+var specs embed.FS
+
+// Versions returns the Kubernetes minor versions with a bundled spec,
+// such as "v1.23", from the oldest to the newest.
+func Versions() []string {
+	entries, err := specs.ReadDir("specs")
+	if err != nil {
+		return nil
+	}
+	versions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		versions = append(versions, strings.TrimSuffix(e.Name(), ".json.gz"))
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// Select returns the bundled version to use for a cluster running the given
+// Kubernetes version. Versions may be given with or without the "v" prefix
+// and with any patch or build suffix, as in "1.23" or "v1.23.4-eks-1234".
+// It is the newest bundled version that is not newer than the cluster, or
+// the newest bundled version when the version of the cluster is not known.
+// An empty string is returned when the cluster is older than all bundled
+// versions. The second return value reports whether the minor versions match
+// exactly.
+func Select(version string) (string, bool) {
+	versions := Versions()
+	if len(versions) == 0 {
+		return "", false
+	}
+	mm := MajorMinor(version)
+	if mm == "" {
+		return versions[len(versions)-1], false
+	}
+	var selected string
+	for _, v := range versions {
+		if semver.Compare(v, mm) > 0 {
+			break
+		}
+		selected = v
+	}
+	return selected, selected == mm
+}
+
+// MajorMinor returns the "vMAJOR.MINOR" prefix of a Kubernetes version, or an
+// empty string if it is not a valid version.
+func MajorMinor(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return semver.MajorMinor(version)
+}
+
+// Spec returns the OpenAPI v2 document bundled for a version returned by Versions.
+func Spec(version string) ([]byte, error) {
+	f, err := specs.Open(path.Join("specs", version+".json.gz"))
+	if err != nil {
+		return nil, fmt.Errorf("no OpenAPI spec bundled for Kubernetes %s", version)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if _, err := io.Copy(&b, zr); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
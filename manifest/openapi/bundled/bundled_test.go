@@ -0,0 +1,97 @@
+package bundled
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestSpecs(t *testing.T) {
+	versions := Versions()
+	if len(versions) < 2 {
+		t.Fatalf("expected several bundled versions, got %v", versions)
+	}
+	for _, v := range versions {
+		t.Run(v, func(t *testing.T) {
+			spec, err := Spec(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := openapi.NewFoundryFromSpecV2(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, gvk := range []schema.GroupVersionKind{
+				{Group: "apps", Version: "v1", Kind: "Deployment"},
+				{Group: "", Version: "v1", Kind: "ConfigMap"},
+				{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"},
+				openapi.ObjectMetaGVK,
+			} {
+				typ, _, err := f.GetTypeByGVK(gvk)
+				if err != nil {
+					t.Errorf("%s: %s", gvk, err)
+					continue
+				}
+				if !typ.Is(tftypes.Object{}) {
+					t.Errorf("%s: expected an object type, got %s", gvk, typ)
+				}
+			}
+		})
+	}
+}
+
+// TestNewestSpec checks that the newest bundled spec is the one of the
+// Kubernetes release the provider is built against.
+func TestNewestSpec(t *testing.T) {
+	gomod, err := os.ReadFile("../../../go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := regexp.MustCompile(`(?m)^\s*k8s\.io/api v0\.(\d+)\.`).FindSubmatch(gomod)
+	if m == nil {
+		t.Fatal("k8s.io/api is not required in go.mod")
+	}
+	versions := Versions()
+	if expected, newest := "v1."+string(m[1]), versions[len(versions)-1]; newest != expected {
+		t.Errorf("the newest bundled spec is %s, but the provider is built against Kubernetes %s: run gen.go for %s", newest, expected, expected)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	versions := Versions()
+	oldest, newest := versions[0], versions[len(versions)-1]
+	samples := map[string]struct {
+		version string
+		exact   bool
+	}{
+		oldest:                     {oldest, true},
+		newest:                     {newest, true},
+		newest[1:] + ".4-eks-1234": {newest, true},
+		"v1.99":                    {newest, false},
+		"v1.2":                     {"", false},
+		"":                         {newest, false},
+		"not-a-version":            {newest, false},
+	}
+	// a version between two bundled ones uses the older one
+	var major, minor int
+	if _, err := fmt.Sscanf(oldest, "v%d.%d", &major, &minor); err != nil {
+		t.Fatal(err)
+	}
+	if next := fmt.Sprintf("v%d.%d", major, minor+1); next != versions[1] {
+		samples[next] = struct {
+			version string
+			exact   bool
+		}{oldest, false}
+	}
+	for in, s := range samples {
+		v, exact := Select(in)
+		if v != s.version || exact != s.exact {
+			t.Errorf("Select(%q): expected %s (exact %t), got %s (exact %t)", in, s.version, s.exact, v, exact)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+//go:build ignore
+// +build ignore
+
+// This program reduces the OpenAPI v2 spec of a Kubernetes release to the
+// definitions of its built-in API groups and writes it in the compressed
+// form bundled with the provider.
+//
+// Usage:
+//
+//	go run gen.go -version 1.23 -in api/openapi-spec/swagger.json
+//
+// The input is the swagger.json document published in the api/openapi-spec
+// directory of the Kubernetes repository, or the /openapi/v2 document served
+// by a cluster without aggregated APIs or custom resources.
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// builtinPrefixes are the definition IDs of the API groups that every
+// Kubernetes API server serves.
+var builtinPrefixes = []string{
+	"io.k8s.api.",
+	"io.k8s.apimachinery.",
+	"io.k8s.apiextensions-apiserver.",
+	"io.k8s.kube-aggregator.",
+}
+
+func main() {
+	version := flag.String("version", "", "Kubernetes minor version of the spec, such as 1.23")
+	in := flag.String("in", "", "Path to the OpenAPI v2 spec")
+	flag.Parse()
+	if *version == "" || *in == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	*version = strings.TrimPrefix(*version, "v")
+
+	b, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var spec struct {
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		log.Fatal(err)
+	}
+
+	defs := make(map[string]interface{})
+	for id, d := range spec.Definitions {
+		for _, p := range builtinPrefixes {
+			if strings.HasPrefix(id, p) {
+				defs[id] = stripDescriptions(d)
+				break
+			}
+		}
+	}
+	out := map[string]interface{}{
+		"swagger":     "2.0",
+		"info":        map[string]string{"title": "Kubernetes", "version": "v" + *version},
+		"paths":       map[string]interface{}{},
+		"definitions": defs,
+	}
+
+	path := filepath.Join("specs", fmt.Sprintf("v%s.json.gz", *version))
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	zw, err := gzip.NewWriterLevel(f, gzip.BestCompression)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := json.NewEncoder(zw).Encode(out); err != nil {
+		log.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d definitions to %s", len(defs), path)
+}
+
+// stripDescriptions removes the documentation from a schema, which the
+// provider does not use and which makes up most of the size of the spec.
+func stripDescriptions(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range tv {
+			if k == "description" {
+				if _, ok := e.(string); ok {
+					delete(tv, k)
+					continue
+				}
+			}
+			tv[k] = stripDescriptions(e)
+		}
+	case []interface{}:
+		for i := range tv {
+			tv[i] = stripDescriptions(tv[i])
+		}
+	}
+	return v
+}
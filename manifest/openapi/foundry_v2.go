@@ -50,6 +50,9 @@ func NewFoundryFromSpecV2(spec []byte) (Foundry, error) {
 // Foundry is a mechanism to construct tftypes out of OpenAPI specifications
 type Foundry interface {
 	GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[string]string, error)
+	// ValidateByGVK checks a manifest against the schema of its resource and
+	// returns the parts of it that the API server would reject
+	ValidateByGVK(gvk schema.GroupVersionKind, v tftypes.Value) ([]Violation, error)
 }
 
 type foapiv2 struct {
@@ -86,6 +89,20 @@ func (f *foapiv2) GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[s
 	return t, hints, err
 }
 
+// ValidateByGVK looks up a type by its GVK in the Definitions sections of
+// the OpenAPI spec and validates the value v against it
+func (f *foapiv2) ValidateByGVK(gvk schema.GroupVersionKind, v tftypes.Value) ([]Violation, error) {
+	id, ok := f.gkvIndex.Load(gvk)
+	if !ok {
+		return nil, fmt.Errorf("%v resource not found in OpenAPI index", gvk)
+	}
+	sch, err := resolveSchemaRef(f.swagger.Definitions[id.(string)], f.swagger.Definitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema: %s", err)
+	}
+	return validateValue(v, sch, f.swagger.Definitions, tftypes.NewAttributePath()), nil
+}
+
 func (f *foapiv2) getTypeByID(id string, h map[string]string, ap tftypes.AttributePath) (tftypes.Type, error) {
 	swd, ok := f.swagger.Definitions[id]
 
@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -15,7 +16,16 @@ func NewFoundryFromSpecV3(spec []byte) (Foundry, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &foapiv3{doc: oapi3}, nil
+	// keep the raw schema around to evaluate the validation rules of CRDs
+	var raw struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(spec, &raw); err != nil {
+		return nil, err
+	}
+	return &foapiv3{doc: oapi3, raw: raw.Components.Schemas[""]}, nil
 }
 
 func SchemaToSpec(key string, crschema map[string]interface{}) map[string]interface{} {
@@ -40,6 +50,7 @@ func SchemaToSpec(key string, crschema map[string]interface{}) map[string]interf
 
 type foapiv3 struct {
 	doc       *openapi3.T
+	raw       json.RawMessage
 	gate      sync.Mutex
 	typeCache sync.Map
 }
@@ -61,3 +72,34 @@ func (f *foapiv3) GetTypeByGVK(_ schema.GroupVersionKind) (tftypes.Type, map[str
 	tftype, err := getTypeFromSchema(sch, 50, &(f.typeCache), f.doc.Components.Schemas, ap, hints)
 	return tftype, hints, err
 }
+
+// ValidateByGVK validates the value v against the schema of the spec and
+// evaluates the CEL rules in its 'x-kubernetes-validations' extensions
+func (f *foapiv3) ValidateByGVK(_ schema.GroupVersionKind, v tftypes.Value) ([]Violation, error) {
+	sref := f.doc.Components.Schemas[""]
+
+	sch, err := resolveSchemaRef(sref, f.doc.Components.Schemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema: %s", err)
+	}
+	// CRD schemas may leave out the type and object metadata, or only constrain
+	// the name of the object, as the API server validates these for all resources
+	root := *sch
+	root.Properties = make(openapi3.Schemas, len(sch.Properties)+3)
+	for k, p := range sch.Properties {
+		root.Properties[k] = p
+	}
+	for _, k := range []string{"apiVersion", "kind"} {
+		if _, ok := root.Properties[k]; !ok {
+			root.Properties[k] = openapi3.NewStringSchema().NewRef()
+		}
+	}
+	root.Properties["metadata"] = openapi3.NewObjectSchema().NewRef()
+
+	vs := validateValue(v, &root, f.doc.Components.Schemas, tftypes.NewAttributePath())
+	if len(vs) > 0 || !v.IsFullyKnown() {
+		// rules are only evaluated against well-formed and complete objects
+		return vs, nil
+	}
+	return validateRules(f.raw, v)
+}
@@ -0,0 +1,178 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ruleRoot is the name of the root of the field paths reported by the rule
+// validator, which is how CEL rules refer to the object they validate
+const ruleRoot = "self"
+
+// validateRules evaluates the CEL rules in the 'x-kubernetes-validations' extensions
+// of a CRD schema against the value v, the way the API server does on create.
+func validateRules(rawSchema json.RawMessage, v tftypes.Value) ([]Violation, error) {
+	if len(rawSchema) == 0 || !strings.Contains(string(rawSchema), "x-kubernetes-validations") {
+		return nil, nil
+	}
+	var v1Props apiextensionsv1.JSONSchemaProps
+	if err := json.Unmarshal(rawSchema, &v1Props); err != nil {
+		return nil, fmt.Errorf("failed to parse CRD schema: %s", err)
+	}
+	var props apiextensions.JSONSchemaProps
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&v1Props, &props, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert CRD schema: %s", err)
+	}
+	ss, err := structuralschema.NewStructural(&props)
+	if err != nil {
+		return nil, fmt.Errorf("CRD schema is not structural: %s", err)
+	}
+
+	obj, err := payload.FromTFValue(v, nil, tftypes.NewAttributePath())
+	if err != nil {
+		return nil, err
+	}
+	obj = coerceToSchema(obj, ss)
+
+	validator := cel.NewValidator(ss, true, cel.PerCallLimit)
+	errs, _ := validator.Validate(context.TODO(), field.NewPath(ruleRoot), ss, obj, nil, cel.RuntimeCELCostBudget)
+	vs := make([]Violation, 0, len(errs))
+	for _, e := range errs {
+		vs = append(vs, Violation{
+			Path:   attributePathFromField(v, e.Field),
+			Detail: e.Detail,
+		})
+	}
+	return vs, nil
+}
+
+// coerceToSchema converts the scalars of an unstructured object to the types of its
+// schema, like morph.ValueToType does for tftypes values, so that rules see the
+// object as the API server would.
+func coerceToSchema(obj interface{}, s *structuralschema.Structural) interface{} {
+	if s == nil || obj == nil {
+		return obj
+	}
+	if s.XIntOrString {
+		if sv, ok := obj.(string); ok {
+			if n, err := strconv.ParseInt(sv, 10, 64); err == nil {
+				return n
+			}
+		}
+		return obj
+	}
+	switch s.Type {
+	case "object":
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return obj
+		}
+		for k, e := range m {
+			if p, ok := s.Properties[k]; ok {
+				m[k] = coerceToSchema(e, &p)
+			} else if s.AdditionalProperties != nil {
+				m[k] = coerceToSchema(e, s.AdditionalProperties.Structural)
+			}
+		}
+	case "array":
+		l, ok := obj.([]interface{})
+		if !ok {
+			return obj
+		}
+		for i, e := range l {
+			l[i] = coerceToSchema(e, s.Items)
+		}
+	case "string":
+		switch o := obj.(type) {
+		case int64:
+			return strconv.FormatInt(o, 10)
+		case float64:
+			return strconv.FormatFloat(o, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(o)
+		}
+	case "integer":
+		if sv, ok := obj.(string); ok {
+			if n, err := strconv.ParseInt(sv, 10, 64); err == nil {
+				return n
+			}
+		}
+	case "number":
+		if sv, ok := obj.(string); ok {
+			if n, err := strconv.ParseFloat(sv, 64); err == nil {
+				return n
+			}
+		}
+	case "boolean":
+		if sv, ok := obj.(string); ok {
+			if b, err := strconv.ParseBool(sv); err == nil {
+				return b
+			}
+		}
+	}
+	return obj
+}
+
+// attributePathFromField translates a field path reported by the rule validator,
+// such as "self.spec.ports[0]", into the path of the same attribute of v.
+// It stops at the deepest attribute of v that it can resolve.
+func attributePathFromField(v tftypes.Value, f string) *tftypes.AttributePath {
+	ap := tftypes.NewAttributePath()
+	f = strings.TrimPrefix(strings.TrimPrefix(f, ruleRoot), ".")
+	for f != "" {
+		var step string
+		if strings.HasPrefix(f, "[") {
+			end := strings.Index(f, "]")
+			if end < 0 {
+				return ap
+			}
+			step, f = f[1:end], f[end+1:]
+		} else {
+			end := strings.IndexAny(f, ".[")
+			if end < 0 {
+				end = len(f)
+			}
+			step, f = f[:end], f[end:]
+		}
+		f = strings.TrimPrefix(f, ".")
+
+		switch {
+		case v.Type().Is(tftypes.List{}) || v.Type().Is(tftypes.Tuple{}):
+			var l []tftypes.Value
+			i, err := strconv.Atoi(step)
+			if err != nil || v.As(&l) != nil || i < 0 || i >= len(l) {
+				return ap
+			}
+			ap, v = ap.WithElementKeyInt(i), l[i]
+		case v.Type().Is(tftypes.Map{}) || v.Type().Is(tftypes.Object{}):
+			var m map[string]tftypes.Value
+			if v.As(&m) != nil {
+				return ap
+			}
+			e, ok := m[step]
+			if !ok {
+				return ap
+			}
+			if v.Type().Is(tftypes.Map{}) {
+				ap = ap.WithElementKeyString(step)
+			} else {
+				ap = ap.WithAttributeName(step)
+			}
+			v = e
+		default:
+			return ap
+		}
+	}
+	return ap
+}
@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest"
+)
+
+// Violation describes a part of a value that does not conform to its OpenAPI schema.
+type Violation struct {
+	// Path of the offending value, relative to the validated value
+	Path *tftypes.AttributePath
+	// Detail is a human readable description of the violation
+	Detail string
+	// UnknownAttribute is set when the violation is an attribute that is not
+	// defined by the schema, rather than an invalid value
+	UnknownAttribute bool
+}
+
+// validateValue checks v against the constraints of sch that Kubernetes enforces
+// for manifests: types, required attributes, attributes missing from the schema,
+// enums, patterns and bounds. Unknown values are skipped, as are the scalar
+// conversions that morph.ValueToType performs.
+func validateValue(v tftypes.Value, sch *openapi3.Schema, defs map[string]*openapi3.SchemaRef, ap *tftypes.AttributePath) []Violation {
+	if sch == nil || v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	if isIntOrString(sch) {
+		if !v.Type().Is(tftypes.String) && !v.Type().Is(tftypes.Number) {
+			return []Violation{{Path: ap, Detail: "Expected an integer or a string."}}
+		}
+		return nil
+	}
+
+	switch sch.Type {
+	case "object":
+		return validateObject(v, sch, defs, ap)
+	case "array":
+		return validateArray(v, sch, defs, ap)
+	case "string":
+		s, ok := stringValue(v)
+		if !ok {
+			return []Violation{{Path: ap, Detail: "Expected a string."}}
+		}
+		return validateString(s, sch, ap)
+	case "integer", "number":
+		n, ok := numberValue(v)
+		if !ok {
+			return []Violation{{Path: ap, Detail: "Expected a number."}}
+		}
+		return validateNumber(n, sch, ap)
+	case "boolean":
+		if !v.Type().Is(tftypes.Bool) {
+			if s, ok := stringValue(v); !ok || (s != "true" && s != "false") {
+				return []Violation{{Path: ap, Detail: "Expected a boolean."}}
+			}
+		}
+	}
+	return nil
+}
+
+func validateObject(v tftypes.Value, sch *openapi3.Schema, defs map[string]*openapi3.SchemaRef, ap *tftypes.AttributePath) (vs []Violation) {
+	if !v.Type().Is(tftypes.Object{}) && !v.Type().Is(tftypes.Map{}) {
+		return []Violation{{Path: ap, Detail: "Expected an object."}}
+	}
+	var atts map[string]tftypes.Value
+	if err := v.As(&atts); err != nil {
+		return nil
+	}
+	childPath := func(k string) *tftypes.AttributePath {
+		if v.Type().Is(tftypes.Map{}) {
+			return ap.WithElementKeyString(k)
+		}
+		return ap.WithAttributeName(k)
+	}
+
+	for _, r := range sch.Required {
+		if a, ok := atts[r]; ok && !a.IsNull() {
+			continue
+		}
+		// the API server sets required attributes that have a default
+		if ref := sch.Properties[r]; ref != nil {
+			if s, err := resolveSchemaRef(ref, defs); err == nil && s.Default != nil {
+				continue
+			}
+		}
+		vs = append(vs, Violation{Path: childPath(r), Detail: fmt.Sprintf("Attribute %q is required.", r)})
+	}
+	if n := uint64(len(atts)); n < sch.MinProps || (sch.MaxProps != nil && n > *sch.MaxProps) {
+		vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Expected %s entries, got %d.", bounds(sch.MinProps, sch.MaxProps), n)})
+	}
+
+	preserve := isPreserveUnknownFields(sch)
+	keys := make([]string, 0, len(atts))
+	for k := range atts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var ref *openapi3.SchemaRef
+		switch {
+		case sch.Properties[k] != nil:
+			ref = sch.Properties[k]
+		case sch.AdditionalProperties != nil:
+			ref = sch.AdditionalProperties
+		case preserve || len(sch.Properties) == 0:
+			continue
+		case sch.AdditionalPropertiesAllowed != nil && *sch.AdditionalPropertiesAllowed:
+			continue
+		default:
+			vs = append(vs, Violation{
+				Path:             childPath(k),
+				Detail:           fmt.Sprintf("Attribute %q is not defined in the schema.", k),
+				UnknownAttribute: true,
+			})
+			continue
+		}
+		s, err := resolveSchemaRef(ref, defs)
+		if err != nil {
+			continue
+		}
+		vs = append(vs, validateValue(atts[k], s, defs, childPath(k))...)
+	}
+	return vs
+}
+
+func validateArray(v tftypes.Value, sch *openapi3.Schema, defs map[string]*openapi3.SchemaRef, ap *tftypes.AttributePath) (vs []Violation) {
+	if !v.Type().Is(tftypes.List{}) && !v.Type().Is(tftypes.Tuple{}) && !v.Type().Is(tftypes.Set{}) {
+		return []Violation{{Path: ap, Detail: "Expected a list."}}
+	}
+	var elems []tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return nil
+	}
+	if n := uint64(len(elems)); n < sch.MinItems || (sch.MaxItems != nil && n > *sch.MaxItems) {
+		vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Expected %s items, got %d.", bounds(sch.MinItems, sch.MaxItems), n)})
+	}
+	ref := sch.Items
+	if ref == nil {
+		ref = sch.AdditionalProperties
+	}
+	if ref == nil {
+		return vs
+	}
+	s, err := resolveSchemaRef(ref, defs)
+	if err != nil {
+		return vs
+	}
+	for i, e := range elems {
+		vs = append(vs, validateValue(e, s, defs, ap.WithElementKeyInt(i))...)
+	}
+	return vs
+}
+
+func validateString(s string, sch *openapi3.Schema, ap *tftypes.AttributePath) (vs []Violation) {
+	if len(sch.Enum) > 0 {
+		found := false
+		allowed := make([]string, 0, len(sch.Enum))
+		for _, e := range sch.Enum {
+			es := fmt.Sprintf("%v", e)
+			allowed = append(allowed, strconv.Quote(es))
+			found = found || es == s
+		}
+		if !found {
+			vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Value %q is not one of %s.", s, strings.Join(allowed, ", "))})
+		}
+	}
+	if sch.Pattern != "" {
+		// Kubernetes patterns follow ECMA 262, skip the ones Go cannot compile
+		if re, err := regexp.Compile(sch.Pattern); err == nil && !re.MatchString(s) {
+			vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Value %q does not match the pattern %q.", s, sch.Pattern)})
+		}
+	}
+	if n := uint64(utf8.RuneCountInString(s)); n < sch.MinLength || (sch.MaxLength != nil && n > *sch.MaxLength) {
+		vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Expected %s characters, got %d.", bounds(sch.MinLength, sch.MaxLength), n)})
+	}
+	return vs
+}
+
+func validateNumber(n *big.Float, sch *openapi3.Schema, ap *tftypes.AttributePath) (vs []Violation) {
+	if sch.Type == "integer" && !n.IsInt() {
+		vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Expected an integer, got %s.", n.Text('g', -1))})
+	}
+	if len(sch.Enum) > 0 {
+		found := false
+		for _, e := range sch.Enum {
+			if f, ok := e.(float64); ok && big.NewFloat(f).Cmp(n) == 0 {
+				found = true
+			}
+		}
+		if !found {
+			vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Value %s is not one of the allowed values.", n.Text('g', -1))})
+		}
+	}
+	if sch.Min != nil {
+		c := n.Cmp(big.NewFloat(*sch.Min))
+		if c < 0 || (c == 0 && sch.ExclusiveMin) {
+			vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Value %s is below the minimum of %g.", n.Text('g', -1), *sch.Min)})
+		}
+	}
+	if sch.Max != nil {
+		c := n.Cmp(big.NewFloat(*sch.Max))
+		if c > 0 || (c == 0 && sch.ExclusiveMax) {
+			vs = append(vs, Violation{Path: ap, Detail: fmt.Sprintf("Value %s is above the maximum of %g.", n.Text('g', -1), *sch.Max)})
+		}
+	}
+	return vs
+}
+
+// stringValue returns the string a scalar value converts to.
+func stringValue(v tftypes.Value) (string, bool) {
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		return s, v.As(&s) == nil
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		if v.As(&n) != nil {
+			return "", false
+		}
+		return n.Text('f', -1), true
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		if v.As(&b) != nil {
+			return "", false
+		}
+		return strconv.FormatBool(b), true
+	}
+	return "", false
+}
+
+// numberValue returns the number a scalar value converts to.
+func numberValue(v tftypes.Value) (*big.Float, bool) {
+	switch {
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		return &n, v.As(&n) == nil
+	case v.Type().Is(tftypes.String):
+		var s string
+		if v.As(&s) != nil {
+			return nil, false
+		}
+		n, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+		return n, err == nil
+	}
+	return nil, false
+}
+
+func bounds(min uint64, max *uint64) string {
+	switch {
+	case max == nil:
+		return fmt.Sprintf("at least %d", min)
+	case min == 0:
+		return fmt.Sprintf("at most %d", *max)
+	}
+	return fmt.Sprintf("between %d and %d", min, *max)
+}
+
+func isIntOrString(sch *openapi3.Schema) bool {
+	return sch.Format == "int-or-string" || boolExtension(sch, "x-kubernetes-int-or-string")
+}
+
+func isPreserveUnknownFields(sch *openapi3.Schema) bool {
+	return boolExtension(sch, manifest.PreserveUnknownFieldsLabel) || boolExtension(sch, "x-kubernetes-embedded-resource")
+}
+
+func boolExtension(sch *openapi3.Schema, name string) bool {
+	xv, ok := sch.Extensions[name]
+	if !ok {
+		return false
+	}
+	raw, ok := xv.(json.RawMessage)
+	if !ok {
+		return false
+	}
+	var x bool
+	return json.Unmarshal(raw, &x) == nil && x
+}
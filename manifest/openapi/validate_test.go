@@ -0,0 +1,229 @@
+package openapi
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi/bundled"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// tfValue builds a tftypes value with the types Terraform infers for an HCL
+// expression of the JSON document in.
+func tfValue(t *testing.T, in string) tftypes.Value {
+	var v interface{}
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	return toTFValue(v)
+}
+
+func toTFValue(in interface{}) tftypes.Value {
+	switch v := in.(type) {
+	case string:
+		return tftypes.NewValue(tftypes.String, v)
+	case float64:
+		return tftypes.NewValue(tftypes.Number, big.NewFloat(v))
+	case bool:
+		return tftypes.NewValue(tftypes.Bool, v)
+	case []interface{}:
+		types := make([]tftypes.Type, len(v))
+		vals := make([]tftypes.Value, len(v))
+		for i, e := range v {
+			vals[i] = toTFValue(e)
+			types[i] = vals[i].Type()
+		}
+		return tftypes.NewValue(tftypes.Tuple{ElementTypes: types}, vals)
+	case map[string]interface{}:
+		types := make(map[string]tftypes.Type, len(v))
+		vals := make(map[string]tftypes.Value, len(v))
+		for k, e := range v {
+			vals[k] = toTFValue(e)
+			types[k] = vals[k].Type()
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, vals)
+	}
+	return tftypes.NewValue(tftypes.DynamicPseudoType, nil)
+}
+
+func violationPaths(vs []Violation) []string {
+	paths := make([]string, len(vs))
+	for i, v := range vs {
+		paths[i] = v.Path.String()
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestValidateByGVKFromCRD(t *testing.T) {
+	crdSchema := `{
+		"type": "object",
+		"properties": {
+			"spec": {
+				"type": "object",
+				"required": ["size", "mode"],
+				"x-kubernetes-validations": [
+					{"rule": "!has(self.minReplicas) || !has(self.maxReplicas) || self.minReplicas <= self.maxReplicas", "message": "minReplicas must not exceed maxReplicas"}
+				],
+				"properties": {
+					"size": {"type": "string", "enum": ["small", "large"]},
+					"mode": {"type": "string", "default": "auto"},
+					"name": {"type": "string", "pattern": "^[a-z]+$", "maxLength": 8},
+					"minReplicas": {"type": "integer", "minimum": 1},
+					"maxReplicas": {"type": "integer", "maximum": 10},
+					"port": {"x-kubernetes-int-or-string": true},
+					"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+					"labels": {"type": "object", "additionalProperties": {"type": "string", "minLength": 1}},
+					"extra": {"type": "object", "x-kubernetes-preserve-unknown-fields": true}
+				}
+			}
+		}
+	}`
+	var crd map[string]interface{}
+	if err := json.Unmarshal([]byte(crdSchema), &crd); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := json.Marshal(SchemaToSpec("", crd))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFoundryFromSpecV3(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+	samples := map[string]struct {
+		manifest string
+		paths    []string
+	}{
+		"valid": {
+			manifest: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "test", "labels": {"app": "test"}},
+				"spec": {"size": "small", "name": "abc", "minReplicas": 1, "maxReplicas": "3", "port": "http", "tags": ["a"],
+				"labels": {"a": "b"}, "extra": {"anything": true}}}`,
+		},
+		"int-or-string": {
+			manifest: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "test"},
+				"spec": {"size": "large", "port": 8080}}`,
+		},
+		"required": {
+			manifest: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "test"}, "spec": {}}`,
+			paths:    []string{`AttributeName("spec").AttributeName("size")`},
+		},
+		"constraints": {
+			manifest: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "test"},
+				"spec": {"size": "medium", "name": "ABCDEFGHIJ", "minReplicas": 0, "maxReplicas": 11, "port": [1],
+				"tags": ["a", "b", "c"], "labels": {"a": ""}, "colour": "red"}}`,
+			paths: []string{
+				`AttributeName("spec").AttributeName("colour")`,
+				`AttributeName("spec").AttributeName("labels").AttributeName("a")`,
+				`AttributeName("spec").AttributeName("maxReplicas")`,
+				`AttributeName("spec").AttributeName("minReplicas")`,
+				`AttributeName("spec").AttributeName("name")`,
+				`AttributeName("spec").AttributeName("name")`,
+				`AttributeName("spec").AttributeName("port")`,
+				`AttributeName("spec").AttributeName("size")`,
+				`AttributeName("spec").AttributeName("tags")`,
+			},
+		},
+		"rules": {
+			manifest: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "test"},
+				"spec": {"size": "small", "minReplicas": 5, "maxReplicas": "2"}}`,
+			paths: []string{`AttributeName("spec")`},
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			vs, err := f.ValidateByGVK(gvk, tfValue(t, s.manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			paths := violationPaths(vs)
+			if len(paths) != len(s.paths) {
+				t.Fatalf("expected violations at %v, got %v", s.paths, vs)
+			}
+			for i := range paths {
+				if paths[i] != s.paths[i] {
+					t.Errorf("expected violations at %v, got %v", s.paths, paths)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestValidateByGVKFromSpecV2(t *testing.T) {
+	spec, err := bundled.Spec("v1.23")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFoundryFromSpecV2(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	valid := tfValue(t, `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test", "namespace": "default"},
+		"spec": {"replicas": "2", "selector": {"matchLabels": {"app": "test"}},
+		"template": {"metadata": {"labels": {"app": "test"}}, "spec": {"containers": [
+			{"name": "nginx", "image": "nginx", "ports": [{"containerPort": 80}],
+			 "resources": {"limits": {"cpu": 1, "memory": "512Mi"}},
+			 "livenessProbe": {"httpGet": {"port": "http"}}}]}}}}`)
+	vs, err := f.ValidateByGVK(gvk, valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vs) > 0 {
+		t.Errorf("expected a valid Deployment, got %v", vs)
+	}
+
+	invalid := tfValue(t, `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test", "namespace": "default"},
+		"spec": {"replica": 2, "template": {"spec": {"containers": [{"image": "nginx", "ports": [{"containerPort": "http"}]}]}}}}`)
+	vs, err = f.ValidateByGVK(gvk, invalid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`AttributeName("spec").AttributeName("replica")`,
+		`AttributeName("spec").AttributeName("selector")`,
+		`AttributeName("spec").AttributeName("template").AttributeName("spec").AttributeName("containers").ElementKeyInt(0).AttributeName("name")`,
+		`AttributeName("spec").AttributeName("template").AttributeName("spec").AttributeName("containers").ElementKeyInt(0).AttributeName("ports").ElementKeyInt(0).AttributeName("containerPort")`,
+	}
+	paths := violationPaths(vs)
+	if len(paths) != len(expected) {
+		t.Fatalf("expected violations at %v, got %v", expected, vs)
+	}
+	for i := range paths {
+		if paths[i] != expected[i] {
+			t.Errorf("expected violations at %v, got %v", expected, paths)
+			break
+		}
+	}
+	for _, v := range vs {
+		if v.UnknownAttribute != (v.Path.String() == expected[0]) {
+			t.Errorf("unexpected UnknownAttribute flag on violation %v", v)
+		}
+	}
+}
+
+func TestAttributePathFromField(t *testing.T) {
+	v := tfValue(t, `{"spec": {"ports": [{"name": "http"}], "labels": {"a.b/c": "x"}}}`)
+	samples := map[string]string{
+		"self":                      ``,
+		"self.spec":                 `AttributeName("spec")`,
+		"self.spec.ports[0].name":   `AttributeName("spec").AttributeName("ports").ElementKeyInt(0).AttributeName("name")`,
+		"self.spec.labels":          `AttributeName("spec").AttributeName("labels")`,
+		"self.spec.labels[a.b/c]":   `AttributeName("spec").AttributeName("labels").AttributeName("a.b/c")`,
+		"self.spec.missing.name":    `AttributeName("spec")`,
+		"self.spec.ports[3]":        `AttributeName("spec").AttributeName("ports")`,
+		"self.spec.ports[0].name.x": `AttributeName("spec").AttributeName("ports").ElementKeyInt(0).AttributeName("name")`,
+	}
+	for f, expected := range samples {
+		if p := attributePathFromField(v, f).String(); p != expected {
+			t.Errorf("%s: expected %s, got %s", f, expected, p)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// adoptExisting returns the value of the "adopt_existing" attribute, which
+// defaults to false.
+func adoptExisting(v map[string]tftypes.Value) bool {
+	var adopt bool
+	if a, ok := v["adopt_existing"]; ok && !a.IsNull() && a.IsKnown() {
+		a.As(&adopt)
+	}
+	return adopt
+}
+
+// managedFieldSets returns the set of fields managed by each field manager of the object.
+func managedFieldSets(obj *unstructured.Unstructured) (map[string]*fieldpath.Set, error) {
+	sets := make(map[string]*fieldpath.Set)
+	for _, mf := range obj.GetManagedFields() {
+		if mf.FieldsV1 == nil {
+			continue
+		}
+		fs := &fieldpath.Set{}
+		if err := fs.FromJSON(bytes.NewReader(mf.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("failed to decode the fields managed by %q: %s", mf.Manager, err)
+		}
+		if s, ok := sets[mf.Manager]; ok {
+			fs = s.Union(fs)
+		}
+		sets[mf.Manager] = fs
+	}
+	return sets, nil
+}
+
+// fieldOwnershipChanges returns, for each field manager of the object before
+// it was adopted, the fields it managed that are now managed by manager.
+func fieldOwnershipChanges(before, after *unstructured.Unstructured, manager string) (map[string][]string, error) {
+	beforeSets, err := managedFieldSets(before)
+	if err != nil {
+		return nil, err
+	}
+	afterSets, err := managedFieldSets(after)
+	if err != nil {
+		return nil, err
+	}
+	owned, ok := afterSets[manager]
+	if !ok {
+		return nil, nil
+	}
+	changes := make(map[string][]string)
+	for m, fs := range beforeSets {
+		if m == manager {
+			continue
+		}
+		var fields []string
+		fs.Intersection(owned).Leaves().Iterate(func(p fieldpath.Path) {
+			fields = append(fields, p.String())
+		})
+		if len(fields) > 0 {
+			sort.Strings(fields)
+			changes[m] = fields
+		}
+	}
+	return changes, nil
+}
+
+// adoptionDiagnostic returns a warning reporting that the resource was
+// adopted, along with the fields that changed ownership.
+func adoptionDiagnostic(rnn string, manager string, before, after *unstructured.Unstructured) *tfprotov5.Diagnostic {
+	d := &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  fmt.Sprintf("Adopted existing resource %q", rnn),
+	}
+	changes, err := fieldOwnershipChanges(before, after, manager)
+	if err != nil {
+		d.Detail = fmt.Sprintf("The resource already existed and the manifest was applied over it with field manager %q. Could not determine the fields that changed ownership: %s", manager, err)
+		return d
+	}
+	if len(changes) == 0 {
+		d.Detail = fmt.Sprintf("The resource already existed and the manifest was applied over it with field manager %q. No field managed by another field manager was taken over.", manager)
+		return d
+	}
+	managers := make([]string, 0, len(changes))
+	for m := range changes {
+		managers = append(managers, m)
+	}
+	sort.Strings(managers)
+	var b strings.Builder
+	fmt.Fprintf(&b, "The resource already existed and the manifest was applied over it with field manager %q, which now manages the following fields:\n", manager)
+	for _, m := range managers {
+		fmt.Fprintf(&b, "\nPreviously managed by %q:\n", m)
+		for _, f := range changes[m] {
+			fmt.Fprintf(&b, "  %s\n", f)
+		}
+	}
+	d.Detail = b.String()
+	return d
+}
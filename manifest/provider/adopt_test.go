@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func objectWithManagedFields(fields ...metav1.ManagedFieldsEntry) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetManagedFields(fields)
+	return u
+}
+
+func managedFieldsEntry(manager string, operation metav1.ManagedFieldsOperationType, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  operation,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestFieldOwnershipChanges(t *testing.T) {
+	before := objectWithManagedFields(
+		managedFieldsEntry("eksctl", metav1.ManagedFieldsOperationUpdate,
+			`{"f:data":{".":{},"f:mapRoles":{},"f:mapUsers":{}}}`),
+		managedFieldsEntry("kube-controller-manager", metav1.ManagedFieldsOperationUpdate,
+			`{"f:metadata":{"f:annotations":{".":{},"f:example.com/owner":{}}}}`),
+	)
+	after := objectWithManagedFields(
+		managedFieldsEntry("eksctl", metav1.ManagedFieldsOperationUpdate,
+			`{"f:data":{".":{},"f:mapUsers":{}}}`),
+		managedFieldsEntry("kube-controller-manager", metav1.ManagedFieldsOperationUpdate,
+			`{"f:metadata":{"f:annotations":{".":{},"f:example.com/owner":{}}}}`),
+		managedFieldsEntry("Terraform", metav1.ManagedFieldsOperationApply,
+			`{"f:data":{"f:mapRoles":{}},"f:metadata":{"f:labels":{"f:app":{}}}}`),
+	)
+
+	changes, err := fieldOwnershipChanges(before, after, "Terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{
+		"eksctl": {".data.mapRoles"},
+	}
+	if !reflect.DeepEqual(expected, changes) {
+		t.Fatalf("unexpected field ownership changes: expected %v, got %v", expected, changes)
+	}
+
+	changes, err = fieldOwnershipChanges(before, before, "Terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no field ownership changes, got %v", changes)
+	}
+}
+
+func TestPrivateStateAdoptedFlag(t *testing.T) {
+	p, err := newPrivateStateValue(false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isAdopted, d := isAdoptedFlagFromPrivate(p)
+	if len(d) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	if !isAdopted {
+		t.Fatal("expected the adopted flag to be set")
+	}
+	isImported, d := isImportedFlagFromPrivate(p)
+	if len(d) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	if isImported {
+		t.Fatal("expected the imported flag not to be set")
+	}
+
+	// private state stored before adoption was recorded
+	legacy, err := tftypes.NewValue(legacyPrivateStateSchema, map[string]tftypes.Value{
+		"IsImported": tftypes.NewValue(tftypes.Bool, true),
+	}).MarshalMsgPack(legacyPrivateStateSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isImported, d = isImportedFlagFromPrivate(legacy)
+	if len(d) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	if !isImported {
+		t.Fatal("expected the imported flag of the legacy private state to be set")
+	}
+	isAdopted, d = isAdoptedFlagFromPrivate(legacy)
+	if len(d) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	if isAdopted {
+		t.Fatal("expected the adopted flag of the legacy private state not to be set")
+	}
+}
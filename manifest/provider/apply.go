@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -23,6 +24,9 @@ var defaultDeleteTimeout = "10m"
 
 // ApplyResourceChange function
 func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	if r, ok := s.crdResources[req.TypeName]; ok {
+		return s.applyCRDResource(ctx, r, req)
+	}
 	resp := &tfprotov5.ApplyResourceChangeResponse{}
 
 	execDiag := s.canExecute()
@@ -30,6 +34,10 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
 		return resp, nil
 	}
+	if s.configUnknown {
+		resp.Diagnostics = append(resp.Diagnostics, configUnknownDiagnostic())
+		return resp, nil
+	}
 
 	rt, err := GetResourceType(req.TypeName)
 	if err != nil {
@@ -94,6 +102,17 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 		return resp, nil
 	}
 
+	// from here on, use the clients of the cluster selected by the resource
+	cluster := clusterName(applyPlannedState)
+	if applyPlannedState.IsNull() {
+		cluster = clusterName(applyPriorState)
+	}
+	s, err = s.forCluster(cluster)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+
 	// Extract computed fields configuration
 	computedFields := make(map[string]*tftypes.AttributePath)
 	var atp *tftypes.AttributePath
@@ -162,6 +181,15 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			})
 			return resp, nil
 		}
+		if !obj.IsKnown() {
+			// the resource was planned while the provider configuration was unknown
+			var d []*tfprotov5.Diagnostic
+			obj, d = s.objectFromManifest(ctx, plannedStateVal["manifest"], applyPriorState)
+			if len(d) > 0 {
+				resp.Diagnostics = append(resp.Diagnostics, d...)
+				return resp, nil
+			}
+		}
 
 		gvk, err := GVKFromTftypesObject(&obj, m)
 		if err != nil {
@@ -310,17 +338,22 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			rs = c.Resource(gvr)
 		}
 
-		// Check the resource does not exist if this is a create operation
+		// Check the resource does not exist if this is a create operation,
+		// unless it is meant to be adopted
+		var adopted *unstructured.Unstructured
 		if applyPriorState.IsNull() {
-			_, err := rs.Get(ctx, rname, metav1.GetOptions{})
+			existing, err := rs.Get(ctx, rname, metav1.GetOptions{})
 			if err == nil {
-				resp.Diagnostics = append(resp.Diagnostics,
-					&tfprotov5.Diagnostic{
-						Severity: tfprotov5.DiagnosticSeverityError,
-						Summary:  "Cannot create resource that already exists",
-						Detail:   fmt.Sprintf("resource %q already exists", rnn),
-					})
-				return resp, nil
+				if !adoptExisting(plannedStateVal) {
+					resp.Diagnostics = append(resp.Diagnostics,
+						&tfprotov5.Diagnostic{
+							Severity: tfprotov5.DiagnosticSeverityError,
+							Summary:  "Cannot create resource that already exists",
+							Detail:   fmt.Sprintf("resource %q already exists\n\nSet \"adopt_existing\" to true to adopt it instead.", rnn),
+						})
+					return resp, nil
+				}
+				adopted = existing
 			} else if !apierrors.IsNotFound(err) {
 				resp.Diagnostics = append(resp.Diagnostics,
 					&tfprotov5.Diagnostic{
@@ -401,6 +434,25 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			return resp, nil
 		}
 
+		isImported, d := isImportedFlagFromPrivate(req.PlannedPrivate)
+		resp.Diagnostics = append(resp.Diagnostics, d...)
+		isAdopted, d := isAdoptedFlagFromPrivate(req.PlannedPrivate)
+		resp.Diagnostics = append(resp.Diagnostics, d...)
+		if adopted != nil {
+			isAdopted = true
+			resp.Diagnostics = append(resp.Diagnostics, adoptionDiagnostic(rnn, fieldManagerName, adopted, result))
+		}
+		if isImported || isAdopted {
+			resp.Private, err = newPrivateStateValue(isImported, isAdopted)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "Failed to earmark imported or adopted resource",
+					Detail:   err.Error(),
+				})
+			}
+		}
+
 		newResObject, err := payload.ToTFValue(RemoveServerSideFields(result.Object), tsch, th, tftypes.NewAttributePath())
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics,
@@ -523,6 +575,35 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 		ctxDeadline, cancel := context.WithDeadline(ctx, deadline)
 		defer cancel()
 
+		isImported, d := isImportedFlagFromPrivate(req.PlannedPrivate)
+		resp.Diagnostics = append(resp.Diagnostics, d...)
+		isAdopted, d := isAdoptedFlagFromPrivate(req.PlannedPrivate)
+		resp.Diagnostics = append(resp.Diagnostics, d...)
+		if orphanOnDelete(deletionPolicy(priorStateVal), isImported || isAdopted) {
+			fieldManagerName, _, err := s.getFieldManagerConfig(priorStateVal)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Could not extract field_manager config",
+					Detail:   err.Error(),
+				})
+				return resp, nil
+			}
+			err = util.RemoveFieldManager(ctxDeadline, rs, rname, fieldManagerName)
+			if err != nil && !apierrors.IsNotFound(err) {
+				rn := types.NamespacedName{Namespace: rnamespace, Name: rname}.String()
+				resp.Diagnostics = append(resp.Diagnostics,
+					&tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  fmt.Sprintf("Error orphaning resource %s: %s", rn, err),
+						Detail:   err.Error(),
+					})
+				return resp, nil
+			}
+			resp.NewState = req.PlannedState
+			return resp, nil
+		}
+
 		err = rs.Delete(ctxDeadline, rname, metav1.DeleteOptions{})
 		if err != nil {
 			rn := types.NamespacedName{Namespace: rnamespace, Name: rname}.String()
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi/bundled"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sversion "k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
@@ -122,6 +126,103 @@ func (ps *RawProviderServer) getOAPIv2Foundry() (openapi.Foundry, error) {
 	return oapif, nil
 }
 
+// getBundledSpec returns the foundry of the OpenAPI spec bundled with the provider for
+// the newest Kubernetes minor version that is not newer than the cluster, that version,
+// and whether it is the minor version of the cluster. The version of the cluster is
+// queried from the cluster, or taken from the 'kubernetes_version' hint of the provider
+// configuration when the cluster cannot be reached. The foundry is nil when no bundled
+// spec applies.
+func (ps *RawProviderServer) getBundledSpec() (openapi.Foundry, string, bool) {
+	if ps.bundledFoundryChecked {
+		return ps.bundledFoundry, ps.bundledVersion, ps.bundledExact
+	}
+	ps.bundledFoundryChecked = true
+
+	clusterVersion := ps.kubernetesVersion
+	if !ps.configUnknown && ps.clientConfig != nil {
+		dc, err := ps.getDiscoveryClient()
+		if err == nil {
+			var sv *k8sversion.Info
+			sv, err = dc.ServerVersion()
+			if err == nil {
+				clusterVersion = sv.GitVersion
+			}
+		}
+		if err != nil {
+			ps.logger.Debug("[getBundledSpec]", "cluster version unavailable", err)
+		}
+	}
+	version, exact := bundled.Select(clusterVersion)
+	if version == "" {
+		ps.logger.Debug("[getBundledSpec]", "no OpenAPI spec bundled for cluster version", clusterVersion)
+		return nil, "", false
+	}
+	f, err := loadBundledFoundry(version)
+	if err != nil {
+		ps.logger.Error("[getBundledSpec]", "failed to load bundled OpenAPI spec", err)
+		return nil, "", false
+	}
+	ps.bundledFoundry, ps.bundledVersion, ps.bundledExact = f, version, exact
+	return f, version, exact
+}
+
+// getBundledFoundry returns an interface to request tftype types from the OpenAPI spec
+// bundled with the provider for the minor version of the cluster, so that the types match
+// the ones the cluster would serve itself. It returns nil when no bundled spec matches,
+// as the types of another version may lack attributes the cluster knows about.
+func (ps *RawProviderServer) getBundledFoundry() openapi.Foundry {
+	f, _, exact := ps.getBundledSpec()
+	if !exact {
+		return nil
+	}
+	return f
+}
+
+// bundledFoundries caches the foundries of the bundled OpenAPI specs by version.
+// They do not depend on the provider configuration and are shared by all servers.
+var bundledFoundries = struct {
+	sync.Mutex
+	byVersion map[string]openapi.Foundry
+}{byVersion: map[string]openapi.Foundry{}}
+
+func loadBundledFoundry(version string) (openapi.Foundry, error) {
+	bundledFoundries.Lock()
+	defer bundledFoundries.Unlock()
+
+	if f, ok := bundledFoundries.byVersion[version]; ok {
+		return f, nil
+	}
+	spec, err := bundled.Spec(version)
+	if err != nil {
+		return nil, err
+	}
+	f, err := openapi.NewFoundryFromSpecV2(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct OpenAPI foundry from bundled spec: %s", err)
+	}
+	bundledFoundries.byVersion[version] = f
+	return f, nil
+}
+
+// getBundledFoundryByGVK returns an interface to request tftype types from the newest
+// OpenAPI spec bundled with the provider that contains the GVK, and the version of that
+// spec. It does not need access to the cluster and returns nil when no bundled spec
+// contains the GVK, as is the case for custom resources.
+func getBundledFoundryByGVK(gvk schema.GroupVersionKind) (openapi.Foundry, string) {
+	versions := bundled.Versions()
+	for i := len(versions) - 1; i >= 0; i-- {
+		f, err := loadBundledFoundry(versions[i])
+		if err != nil {
+			// the bundled specs are tested, this only happens with a broken build
+			continue
+		}
+		if _, _, err := f.GetTypeByGVK(gvk); err == nil {
+			return f, versions[i]
+		}
+	}
+	return nil, ""
+}
+
 func loggingTransport(rt http.RoundTripper) http.RoundTripper {
 	return &loggingRountTripper{
 		ot: rt,
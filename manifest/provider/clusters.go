@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+)
+
+// clusterImportSeparator separates the cluster name from the resource ID
+// when importing a resource into a cluster of the clusters block.
+const clusterImportSeparator = "@"
+
+// clusterServers holds a server per entry of the clusters block. Each has its
+// own clients and caches, which are only created when a resource first selects
+// the cluster.
+type clusterServers struct {
+	mu       sync.Mutex
+	configs  map[string]*util.ResolvedConfig
+	wrappers []transport.WrapperFunc
+	servers  map[string]*RawProviderServer
+}
+
+// forCluster returns the server that talks to the named cluster of the
+// clusters block. An empty name selects the cluster configured at the top
+// level of the provider block, served by s itself.
+func (s *RawProviderServer) forCluster(name string) (*RawProviderServer, error) {
+	if name == "" {
+		return s, nil
+	}
+	c := s.clusters
+	if c == nil {
+		return nil, fmt.Errorf("cluster %q is not defined in the clusters block of the provider", name)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cs, ok := c.servers[name]; ok {
+		return cs, nil
+	}
+	resolved, ok := c.configs[name]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q is not defined in the clusters block of the provider", name)
+	}
+	clientConfig, err := resolved.ClientConfig(c.wrappers...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load client config of cluster %q: %s", name, err)
+	}
+	s.logger.Debug("[Configure]", "[ResolvedConfig]", name, "\n"+resolved.Report.String())
+	setNegotiatedSerializer(clientConfig)
+
+	// Everything but the clients and the caches derived from them is shared
+	// with the top level server.
+	cs := &RawProviderServer{
+		logger:             s.logger,
+		clientConfig:       clientConfig,
+		providerEnabled:    s.providerEnabled,
+		hostTFVersion:      s.hostTFVersion,
+		configUnknown:      s.configUnknown,
+		kubernetesVersion:  s.kubernetesVersion,
+		defaultLabels:      s.defaultLabels,
+		defaultAnnotations: s.defaultAnnotations,
+		namespace:          resolved.Namespace(),
+		crdResources:       s.crdResources,
+		crdResourcesErr:    s.crdResourcesErr,
+	}
+	c.servers[name] = cs
+	return cs, nil
+}
+
+func setNegotiatedSerializer(clientConfig *rest.Config) {
+	codec := runtime.NoopEncoder{Decoder: scheme.Codecs.UniversalDecoder()}
+	clientConfig.NegotiatedSerializer = serializer.NegotiatedSerializerWrapper(runtime.SerializerInfo{Serializer: codec})
+}
+
+// clusterName returns the value of the cluster attribute of a resource or
+// data source object, or an empty string when it is not set.
+func clusterName(v tftypes.Value) string {
+	var vals map[string]tftypes.Value
+	if v.IsNull() || !v.IsKnown() || v.As(&vals) != nil {
+		return ""
+	}
+	var name string
+	if c, ok := vals["cluster"]; ok && c.IsKnown() && !c.IsNull() {
+		c.As(&name)
+	}
+	return name
+}
+
+// splitClusterImportID separates the optional "<cluster>@" prefix from an import ID.
+func splitClusterImportID(id string) (string, string) {
+	if cluster, rid, ok := strings.Cut(id, clusterImportSeparator); ok {
+		return cluster, rid
+	}
+	return "", id
+}
+
+func clusterDiagnostic(err error) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity:  tfprotov5.DiagnosticSeverityError,
+		Summary:   "Failed to configure client for cluster",
+		Detail:    err.Error(),
+		Attribute: tftypes.NewAttributePath().WithAttributeName("cluster"),
+	}
+}
+
+func getClusterConfigs(v tftypes.Value) ([]util.ClusterConfig, error) {
+	var clustersBlock []tftypes.Value
+	err := v.As(&clustersBlock)
+	if err != nil {
+		return nil, err
+	}
+	clusters := make([]util.ClusterConfig, 0, len(clustersBlock))
+	for _, b := range clustersBlock {
+		var clusterObj map[string]tftypes.Value
+		err = b.As(&clusterObj)
+		if err != nil {
+			return nil, err
+		}
+		c := util.ClusterConfig{}
+		err = getStringAttributes(clusterObj, map[string]*string{
+			"name":                     &c.Name,
+			"host":                     &c.Host,
+			"username":                 &c.Username,
+			"password":                 &c.Password,
+			"client_certificate":       &c.ClientCertificate,
+			"client_key":               &c.ClientKey,
+			"cluster_ca_certificate":   &c.ClusterCACertificate,
+			"config_path":              &c.ConfigPath,
+			"config_context":           &c.ConfigContext,
+			"config_context_auth_info": &c.ConfigContextAuthInfo,
+			"config_context_cluster":   &c.ConfigContextCluster,
+			"token":                    &c.Token,
+			"proxy_url":                &c.ProxyURL,
+			"tls_server_name":          &c.TLSServerName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !clusterObj["insecure"].IsNull() {
+			var insecure bool
+			err = clusterObj["insecure"].As(&insecure)
+			if err != nil {
+				return nil, err
+			}
+			c.Insecure = &insecure
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters, nil
+}
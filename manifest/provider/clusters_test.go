@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+	"k8s.io/client-go/transport"
+)
+
+func TestClusterName(t *testing.T) {
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"cluster": tftypes.String}}
+	samples := map[string]struct {
+		in  tftypes.Value
+		out string
+	}{
+		"set": {
+			in:  tftypes.NewValue(objType, map[string]tftypes.Value{"cluster": tftypes.NewValue(tftypes.String, "east")}),
+			out: "east",
+		},
+		"null attribute": {
+			in:  tftypes.NewValue(objType, map[string]tftypes.Value{"cluster": tftypes.NewValue(tftypes.String, nil)}),
+			out: "",
+		},
+		"unknown attribute": {
+			in:  tftypes.NewValue(objType, map[string]tftypes.Value{"cluster": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+			out: "",
+		},
+		"null object": {
+			in:  tftypes.NewValue(objType, nil),
+			out: "",
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			if n := clusterName(s.in); n != s.out {
+				t.Errorf("expected %q, got %q", s.out, n)
+			}
+		})
+	}
+}
+
+func TestSplitClusterImportID(t *testing.T) {
+	cluster, id := splitClusterImportID("east@apiVersion=v1,kind=ConfigMap,namespace=default,name=test")
+	if cluster != "east" || id != "apiVersion=v1,kind=ConfigMap,namespace=default,name=test" {
+		t.Errorf("unexpected split: %q, %q", cluster, id)
+	}
+	cluster, id = splitClusterImportID("apiVersion=v1,kind=ConfigMap,namespace=default,name=test")
+	if cluster != "" || id != "apiVersion=v1,kind=ConfigMap,namespace=default,name=test" {
+		t.Errorf("unexpected split: %q, %q", cluster, id)
+	}
+}
+
+func TestForCluster(t *testing.T) {
+	resolved, err := util.ResolveConfig(util.ProviderConfig{Host: "https://default.example.com", IgnoreEnv: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs, err := resolved.ResolveClusters([]util.ClusterConfig{
+		{Name: "east", ProviderConfig: util.ProviderConfig{Host: "https://east.example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &RawProviderServer{
+		logger:            hclog.NewNullLogger(),
+		providerEnabled:   true,
+		kubernetesVersion: "1.23",
+		crdResources:      map[string]*crdResource{"kubernetes_example": {}},
+		clusters: &clusterServers{
+			configs:  configs,
+			wrappers: []transport.WrapperFunc{},
+			servers:  map[string]*RawProviderServer{},
+		},
+	}
+
+	if cs, err := s.forCluster(""); err != nil || cs != s {
+		t.Errorf("an empty cluster should select the top level cluster")
+	}
+	east, err := s.forCluster("east")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if east.clientConfig.Host != "https://east.example.com" || !east.providerEnabled {
+		t.Errorf("unexpected server for cluster east: host=%q", east.clientConfig.Host)
+	}
+	if east.kubernetesVersion != "1.23" || east.crdResources["kubernetes_example"] == nil {
+		t.Errorf("server for cluster east does not share the provider settings")
+	}
+	if again, _ := s.forCluster("east"); again != east {
+		t.Errorf("server for cluster east was not cached")
+	}
+	if _, err := s.forCluster("west"); err == nil {
+		t.Errorf("expected an error for an undefined cluster")
+	}
+}
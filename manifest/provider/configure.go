@@ -2,26 +2,21 @@ package provider
 
 import (
 	"context"
-	"encoding/pem"
 	"errors"
 	"fmt"
-	"net/url"
+	"math/big"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
-	"github.com/mitchellh/go-homedir"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi/bundled"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
 	"golang.org/x/mod/semver"
-	"k8s.io/apimachinery/pkg/runtime"
-	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/runtime/serializer"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/transport"
 )
 
 const minTFVersion string = "v0.14.8"
@@ -29,7 +24,6 @@ const minTFVersion string = "v0.14.8"
 // ConfigureProvider function
 func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
 	response := &tfprotov5.ConfigureProviderResponse{}
-	diags := []*tfprotov5.Diagnostic{}
 	var providerConfig map[string]tftypes.Value
 	var err error
 
@@ -117,148 +111,126 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 		return response, nil
 	}
 
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := &clientcmd.ClientConfigLoadingRules{}
-
-	// Handle 'config_path' attribute
-	//
-	var configPath string
-	if !providerConfig["config_path"].IsNull() && providerConfig["config_path"].IsKnown() {
-		err = providerConfig["config_path"].As(&configPath)
+	if !providerConfig["kubernetes_version"].IsNull() && providerConfig["kubernetes_version"].IsKnown() {
+		var kubernetesVersion string
+		err = providerConfig["kubernetes_version"].As(&kubernetesVersion)
 		if err != nil {
-			// invalid attribute - this shouldn't happen, bail out now
+			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to extract 'config_path' value",
+				Summary:  "Provider configuration: failed to extract 'kubernetes_version' value",
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-	}
-	// check environment - this overrides any value found in provider configuration
-	if configPathEnv, ok := os.LookupEnv("KUBE_CONFIG_PATH"); ok && configPathEnv != "" {
-		configPath = configPathEnv
-	}
-	if len(configPath) > 0 {
-		configPathAbs, err := homedir.Expand(configPath)
-		if err == nil {
-			_, err = os.Stat(configPathAbs)
+		if bundled.MajorMinor(kubernetesVersion) == "" {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    fmt.Sprintf("'kubernetes_version' must be a Kubernetes version such as 1.23, got %q", kubernetesVersion),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("kubernetes_version"),
+			})
+			return response, nil
 		}
-		if err != nil {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid attribute in provider configuration",
-				Detail:   fmt.Sprintf("'config_path' refers to an invalid path: %q: %v", configPathAbs, err),
+		if version, exact := bundled.Select(kubernetesVersion); !exact {
+			detail := fmt.Sprintf("The provider bundles OpenAPI schemas for Kubernetes %s, but not for %s.", strings.Join(bundled.Versions(), ", "), kubernetesVersion)
+			if version == "" {
+				detail += " Manifests are only validated once the cluster can be reached."
+			} else {
+				detail += fmt.Sprintf(" Until the cluster can be reached, manifests are checked against the schema of Kubernetes %s and violations are only reported as warnings.", version)
+			}
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityWarning,
+				Summary:   "No OpenAPI schema bundled for Kubernetes version",
+				Detail:    detail,
+				Attribute: tftypes.NewAttributePath().WithAttributeName("kubernetes_version"),
 			})
 		}
-		loader.ExplicitPath = configPathAbs
+		s.kubernetesVersion = kubernetesVersion
 	}
-	// Handle 'config_paths' attribute
-	//
-	var precedence []string
-	if !providerConfig["config_paths"].IsNull() && providerConfig["config_paths"].IsKnown() {
-		var configPaths []tftypes.Value
-		err = providerConfig["config_paths"].As(&configPaths)
+
+	for _, attr := range []string{"default_labels", "default_annotations"} {
+		if providerConfig[attr].IsNull() || !providerConfig[attr].IsFullyKnown() {
+			continue
+		}
+		var values map[string]tftypes.Value
+		err = providerConfig[attr].As(&values)
 		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to extract 'config_paths' value",
+				Summary:  fmt.Sprintf("Provider configuration: failed to extract '%s' value", attr),
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-		for _, p := range configPaths {
-			var pp string
-			p.As(&pp)
-			precedence = append(precedence, pp)
-		}
-	}
-	//
-	// check environment for KUBE_CONFIG_PATHS
-	if configPathsEnv, ok := os.LookupEnv("KUBE_CONFIG_PATHS"); ok && configPathsEnv != "" {
-		precedence = filepath.SplitList(configPathsEnv)
-	}
-	if len(precedence) > 0 {
-		for i, p := range precedence {
-			absPath, err := homedir.Expand(p)
-			if err == nil {
-				_, err = os.Stat(absPath)
-			}
-			if err != nil {
-				diags = append(diags, &tfprotov5.Diagnostic{
-					Severity: tfprotov5.DiagnosticSeverityInvalid,
-					Summary:  "Invalid attribute in provider configuration",
-					Detail:   fmt.Sprintf("'config_paths' refers to an invalid path: %q: %v", absPath, err),
+		defaults := make(map[string]string, len(values))
+		for k, v := range values {
+			var str string
+			if err := v.As(&str); err != nil {
+				response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  fmt.Sprintf("Provider configuration: failed to extract '%s' value", attr),
+					Detail:   err.Error(),
 				})
+				return response, nil
 			}
-			precedence[i] = absPath
+			defaults[k] = str
 		}
-		loader.Precedence = precedence
-	}
-
-	// Handle 'client_certificate' attribute
-	//
-	var clientCertificate string
-	if !providerConfig["client_certificate"].IsNull() && providerConfig["client_certificate"].IsKnown() {
-		err = providerConfig["client_certificate"].As(&clientCertificate)
-		if err != nil {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid attribute in provider configuration",
-				Detail:   "'client_certificate' type cannot be asserted: " + err.Error(),
-			})
-			return response, nil
+		if attr == "default_labels" {
+			s.defaultLabels = defaults
+		} else {
+			s.defaultAnnotations = defaults
 		}
 	}
-	if clientCrtEnv, ok := os.LookupEnv("KUBE_CLIENT_CERT_DATA"); ok && clientCrtEnv != "" {
-		clientCertificate = clientCrtEnv
-	}
-	if len(clientCertificate) > 0 {
-		ccPEM, _ := pem.Decode([]byte(clientCertificate))
-		if ccPEM == nil || ccPEM.Type != "CERTIFICATE" {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid attribute in provider configuration",
-				Detail:   "'client_certificate' is not a valid PEM encoded certificate",
-			})
-		}
-		overrides.AuthInfo.ClientCertificateData = []byte(clientCertificate)
+
+	if !cfgVal.IsFullyKnown() {
+		// The configuration depends on values that are only known during apply,
+		// such as the credentials of a cluster created in the same run.
+		// Resources are planned without calling the API until then.
+		s.logger.Info("[Configure]", "provider configuration is not known yet, deferring API calls until apply")
+		s.configUnknown = true
+		return response, nil
 	}
 
-	// Handle 'cluster_ca_certificate' attribute
-	//
-	var clusterCaCertificate string
-	if !providerConfig["cluster_ca_certificate"].IsNull() && providerConfig["cluster_ca_certificate"].IsKnown() {
-		err = providerConfig["cluster_ca_certificate"].As(&clusterCaCertificate)
+	cfg := util.ProviderConfig{}
+	for attr, v := range map[string]*string{
+		"host":                     &cfg.Host,
+		"username":                 &cfg.Username,
+		"password":                 &cfg.Password,
+		"client_certificate":       &cfg.ClientCertificate,
+		"client_key":               &cfg.ClientKey,
+		"cluster_ca_certificate":   &cfg.ClusterCACertificate,
+		"config_path":              &cfg.ConfigPath,
+		"config_raw":               &cfg.ConfigRaw,
+		"config_context":           &cfg.ConfigContext,
+		"config_context_auth_info": &cfg.ConfigContextAuthInfo,
+		"config_context_cluster":   &cfg.ConfigContextCluster,
+		"namespace":                &cfg.Namespace,
+		"token":                    &cfg.Token,
+		"proxy_url":                &cfg.ProxyURL,
+		"tls_server_name":          &cfg.TLSServerName,
+		"request_timeout":          &cfg.RequestTimeout,
+	} {
+		if providerConfig[attr].IsNull() || !providerConfig[attr].IsKnown() {
+			continue
+		}
+		err = providerConfig[attr].As(v)
 		if err != nil {
 			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to extract 'cluster_ca_certificate' value",
+				Summary:  fmt.Sprintf("Provider configuration: failed to extract '%s' value", attr),
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
 	}
-	if clusterCAEnv, ok := os.LookupEnv("KUBE_CLUSTER_CA_CERT_DATA"); ok && clusterCAEnv != "" {
-		clusterCaCertificate = clusterCAEnv
-	}
-	if len(clusterCaCertificate) > 0 {
-		ca, _ := pem.Decode([]byte(clusterCaCertificate))
-		if ca == nil || ca.Type != "CERTIFICATE" {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid attribute in provider configuration",
-				Detail:   "'cluster_ca_certificate' is not a valid PEM encoded certificate",
-			})
-		}
-		overrides.ClusterInfo.CertificateAuthorityData = []byte(clusterCaCertificate)
-	}
 
 	// Handle 'insecure' attribute
 	//
-	var insecure bool
 	if !providerConfig["insecure"].IsNull() && providerConfig["insecure"].IsKnown() {
+		var insecure bool
 		err = providerConfig["insecure"].As(&insecure)
 		if err != nil {
 			// invalid attribute type - this shouldn't happen, bail out for now
@@ -269,239 +241,144 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 			})
 			return response, nil
 		}
+		cfg.Insecure = &insecure
 	}
-	if insecureEnv, ok := os.LookupEnv("KUBE_INSECURE"); ok && insecureEnv != "" {
-		iv, err := strconv.ParseBool(insecureEnv)
-		if err != nil {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid provider configuration",
-				Detail:   "Environment variable KUBE_INSECURE contains invalid value: " + err.Error(),
-			})
-		} else {
-			insecure = iv
-		}
-	}
-	overrides.ClusterInfo.InsecureSkipTLSVerify = insecure
 
-	hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
-	hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
-	defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
-
-	// Handle 'host' attribute
+	// Handle 'use_kubeconfig_namespace' attribute
 	//
-	var host string
-	if !providerConfig["host"].IsNull() && providerConfig["host"].IsKnown() {
-		err = providerConfig["host"].As(&host)
+	if !providerConfig["use_kubeconfig_namespace"].IsNull() && providerConfig["use_kubeconfig_namespace"].IsKnown() {
+		err = providerConfig["use_kubeconfig_namespace"].As(&cfg.UseKubeconfigNamespace)
 		if err != nil {
-			// invalid attribute path - this shouldn't happen, bail out for now
+			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to extract 'host' value",
+				Summary:  "Provider configuration: failed to assert type of 'use_kubeconfig_namespace' value",
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
 	}
-	// check environment - this overrides any value found in provider configuration
-	if hostEnv, ok := os.LookupEnv("KUBE_HOST"); ok && hostEnv != "" {
-		host = hostEnv
-	}
-	if len(host) > 0 {
-		_, err = url.ParseRequestURI(host)
-		if err != nil {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid attribute in provider configuration",
-				Detail:   "'host' is not a valid URL",
-			})
-		}
-		hostURL, _, err := rest.DefaultServerURL(host, "", apimachineryschema.GroupVersion{}, defaultTLS)
-		if err != nil {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid attribute in provider configuration",
-				Detail:   "Invalid value for 'host': " + err.Error(),
-			})
-			return response, nil
-		}
-		// Server has to be the complete address of the kubernetes cluster (scheme://hostname:port), not just the hostname,
-		// because `overrides` are processed too late to be taken into account by `defaultServerUrlFor()`.
-		// This basically replicates what defaultServerUrlFor() does with config but for overrides,
-		// see https://github.com/kubernetes/client-go/blob/v12.0.0/rest/url_utils.go#L85-L87
-		overrides.ClusterInfo.Server = hostURL.String()
-	}
 
-	// Handle 'client_key' attribute
+	// Handle 'config_paths' attribute
 	//
-	var clientKey string
-	if !providerConfig["client_key"].IsNull() && providerConfig["client_key"].IsKnown() {
-		err = providerConfig["client_key"].As(&clientKey)
+	if !providerConfig["config_paths"].IsNull() && providerConfig["config_paths"].IsFullyKnown() {
+		var configPaths []tftypes.Value
+		err = providerConfig["config_paths"].As(&configPaths)
 		if err != nil {
-			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: ",
-				Detail:   "Failed to extract 'client_key' value" + err.Error(),
+				Summary:  "Provider configuration: failed to extract 'config_paths' value",
+				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-	}
-	// check environment - this overrides any value found in provider configuration
-	if clientKeyEnv, ok := os.LookupEnv("KUBE_CLIENT_KEY_DATA"); ok && clientKeyEnv != "" {
-		clientKey = clientKeyEnv
-	}
-	if len(clientKey) > 0 {
-		ck, _ := pem.Decode([]byte(clientKey))
-		if ck == nil || !strings.Contains(ck.Type, "PRIVATE KEY") {
-			diags = append(diags, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityInvalid,
-				Summary:  "Invalid attribute in provider configuration",
-				Detail:   "'client_key' is not a valid PEM encoded private key",
-			})
+		for _, p := range configPaths {
+			var pp string
+			p.As(&pp)
+			cfg.ConfigPaths = append(cfg.ConfigPaths, pp)
 		}
-		overrides.AuthInfo.ClientKeyData = []byte(clientKey)
-	}
-
-	if len(diags) > 0 {
-		response.Diagnostics = diags
-		return response, nil
 	}
 
-	// Handle 'config_context' attribute
+	// Handle 'qps' and 'burst' attributes
 	//
-	var cfgContext string
-	if !providerConfig["config_context"].IsNull() && providerConfig["config_context"].IsKnown() {
-		err = providerConfig["config_context"].As(&cfgContext)
+	if !providerConfig["qps"].IsNull() && providerConfig["qps"].IsKnown() {
+		var qps big.Float
+		err = providerConfig["qps"].As(&qps)
 		if err != nil {
 			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to assert type of 'config_context' value",
+				Summary:  "Provider configuration: failed to assert type of 'qps' value",
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-		overrides.CurrentContext = cfgContext
-	}
-	if cfgContext, ok := os.LookupEnv("KUBE_CTX"); ok && cfgContext != "" {
-		overrides.CurrentContext = cfgContext
+		cfg.QPS, _ = qps.Float64()
 	}
-
-	overrides.Context = clientcmdapi.Context{}
-
-	// Handle 'config_context_cluster' attribute
-	//
-	var cfgCtxCluster string
-	if !providerConfig["config_context_cluster"].IsNull() && providerConfig["config_context_cluster"].IsKnown() {
-		err = providerConfig["config_context_cluster"].As(&cfgCtxCluster)
+	if !providerConfig["burst"].IsNull() && providerConfig["burst"].IsKnown() {
+		var burst big.Float
+		err = providerConfig["burst"].As(&burst)
 		if err != nil {
 			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to assert type of 'config_context_cluster' value",
+				Summary:  "Provider configuration: failed to assert type of 'burst' value",
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-		overrides.Context.Cluster = cfgCtxCluster
-	}
-	if cfgCtxCluster, ok := os.LookupEnv("KUBE_CTX_CLUSTER"); ok && cfgCtxCluster != "" {
-		overrides.Context.Cluster = cfgCtxCluster
+		b, _ := burst.Int64()
+		cfg.Burst = int(b)
 	}
 
-	// Handle 'config_context_user' attribute
+	// Handle 'retry' block
 	//
-	var cfgContextAuthInfo *string
-	if !providerConfig["config_context_user"].IsNull() && providerConfig["config_context_user"].IsKnown() {
-		err = providerConfig["config_context_user"].As(&cfgContextAuthInfo)
+	if !providerConfig["retry"].IsNull() && providerConfig["retry"].IsFullyKnown() {
+		var retryBlock []tftypes.Value
+		err = providerConfig["retry"].As(&retryBlock)
 		if err != nil {
 			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to assert type of 'config_context_user' value",
+				Summary:  "Provider configuration: failed to assert type of 'retry' value",
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-		if cfgContextAuthInfo != nil {
-			overrides.Context.AuthInfo = *cfgContextAuthInfo
+		if len(retryBlock) > 0 {
+			cfg.Retry, err = getRetryConfig(retryBlock[0])
+			if err != nil {
+				response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  `Provider configuration: failed to assert type of "retry" block`,
+					Detail:   err.Error(),
+				})
+				return response, nil
+			}
 		}
 	}
-	if cfgContextAuthInfoEnv, ok := os.LookupEnv("KUBE_CTX_AUTH_INFO"); ok && cfgContextAuthInfoEnv != "" {
-		overrides.Context.AuthInfo = cfgContextAuthInfoEnv
-	}
 
-	var username string
-	if !providerConfig["username"].IsNull() && providerConfig["username"].IsKnown() {
-		err = providerConfig["username"].As(&username)
-		if err != nil {
-			// invalid attribute type - this shouldn't happen, bail out for now
-			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to assert type of 'username' value",
-				Detail:   err.Error(),
-			})
-			return response, nil
+	// Handle 'eks' and 'oidc' blocks
+	//
+	for _, block := range []string{"eks", "oidc"} {
+		if providerConfig[block].IsNull() || !providerConfig[block].IsFullyKnown() {
+			continue
 		}
-		overrides.AuthInfo.Username = username
-	}
-	if username, ok := os.LookupEnv("KUBE_USERNAME"); ok && username != "" {
-		overrides.AuthInfo.Username = username
-	}
-
-	var password string
-	if !providerConfig["password"].IsNull() && providerConfig["password"].IsKnown() {
-		err = providerConfig["password"].As(&password)
-		if err != nil {
-			// invalid attribute type - this shouldn't happen, bail out for now
-			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
-				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to assert type of 'password' value",
-				Detail:   err.Error(),
-			})
-			return response, nil
+		var authBlock []tftypes.Value
+		err = providerConfig[block].As(&authBlock)
+		if err == nil && len(authBlock) > 0 {
+			switch block {
+			case "eks":
+				cfg.EKS, err = getEKSAuthConfig(authBlock[0])
+			case "oidc":
+				cfg.OIDC, err = getOIDCAuthConfig(authBlock[0])
+			}
 		}
-		overrides.AuthInfo.Password = password
-	}
-	if password, ok := os.LookupEnv("KUBE_PASSWORD"); ok && password != "" {
-		overrides.AuthInfo.Password = password
-	}
-
-	var token string
-	if !providerConfig["token"].IsNull() && providerConfig["token"].IsKnown() {
-		err = providerConfig["token"].As(&token)
 		if err != nil {
 			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to assert type of 'token' value",
+				Summary:  fmt.Sprintf("Provider configuration: failed to assert type of %q block", block),
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-		overrides.AuthInfo.Token = token
-	}
-	if token, ok := os.LookupEnv("KUBE_TOKEN"); ok && token != "" {
-		overrides.AuthInfo.Token = token
 	}
 
-	var proxyURL string
-	if !providerConfig["proxy_url"].IsNull() && providerConfig["proxy_url"].IsKnown() {
-		err = providerConfig["proxy_url"].As(&proxyURL)
+	// Handle 'clusters' block
+	//
+	var clusters []util.ClusterConfig
+	if !providerConfig["clusters"].IsNull() && providerConfig["clusters"].IsFullyKnown() {
+		clusters, err = getClusterConfigs(providerConfig["clusters"])
 		if err != nil {
 			// invalid attribute type - this shouldn't happen, bail out for now
 			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
-				Summary:  "Provider configuration: failed to assert type of 'proxy_url' value",
+				Summary:  `Provider configuration: failed to assert type of "clusters" block`,
 				Detail:   err.Error(),
 			})
 			return response, nil
 		}
-		overrides.ClusterDefaults.ProxyURL = proxyURL
-	}
-	if proxyUrl, ok := os.LookupEnv("KUBE_PROXY_URL"); ok && proxyUrl != "" {
-		overrides.ClusterDefaults.ProxyURL = proxyURL
 	}
 
 	if !providerConfig["exec"].IsNull() && providerConfig["exec"].IsKnown() {
@@ -517,7 +394,6 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 			return response, nil
 		}
 		execCfg := clientcmdapi.ExecConfig{}
-		execCfg.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
 		if len(execBlock) > 0 {
 			var execObj map[string]tftypes.Value
 			err := execBlock[0].As(&execObj)
@@ -616,14 +492,37 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 					})
 				}
 			}
-			overrides.AuthInfo.Exec = &execCfg
+			cfg.Exec = &execCfg
 		}
 	}
 
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
-	clientConfig, err := cc.ClientConfig()
+	resolved, err := util.ResolveConfig(cfg)
+	if err != nil {
+		for _, e := range err.(util.ConfigErrors) {
+			response.Diagnostics = append(response.Diagnostics, configErrorDiagnostic(e))
+		}
+		return response, nil
+	}
+	clusterConfigs, err := resolved.ResolveClusters(clusters)
+	if err != nil {
+		for _, e := range err.(util.ConfigErrors) {
+			response.Diagnostics = append(response.Diagnostics, configErrorDiagnostic(e))
+		}
+		return response, nil
+	}
+	s.namespace = resolved.Namespace()
+	var wrappers []transport.WrapperFunc
+	if s.logger.IsTrace() {
+		wrappers = append(wrappers, loggingTransport)
+	}
+	s.clusters = &clusterServers{
+		configs:  clusterConfigs,
+		wrappers: wrappers,
+		servers:  map[string]*RawProviderServer{},
+	}
+	clientConfig, err := resolved.ClientConfig(wrappers...)
 	if err != nil {
-		s.logger.Error("[Configure]", "Failed to load config:", dump(cc))
+		s.logger.Error("[Configure]", "Failed to load config:", dump(resolved.Loader))
 		if errors.Is(err, clientcmd.ErrEmptyConfig) {
 			// this is a terrible fix for if the configuration is a calculated value
 			return response, nil
@@ -635,13 +534,9 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 		})
 		return response, nil
 	}
+	s.logger.Debug("[Configure]", "[ResolvedConfig]", "\n"+resolved.Report.String())
 
-	if s.logger.IsTrace() {
-		clientConfig.WrapTransport = loggingTransport
-	}
-
-	codec := runtime.NoopEncoder{Decoder: scheme.Codecs.UniversalDecoder()}
-	clientConfig.NegotiatedSerializer = serializer.NegotiatedSerializerWrapper(runtime.SerializerInfo{Serializer: codec})
+	setNegotiatedSerializer(clientConfig)
 
 	s.logger.Trace("[Configure]", "[ClientConfig]", dump(*clientConfig))
 	s.clientConfig = clientConfig
@@ -649,6 +544,125 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 	return response, nil
 }
 
+func getRetryConfig(v tftypes.Value) (*util.RetryConfig, error) {
+	var retryObj map[string]tftypes.Value
+	err := v.As(&retryObj)
+	if err != nil {
+		return nil, err
+	}
+	retry := &util.RetryConfig{}
+	if !retryObj["max_attempts"].IsNull() {
+		var maxAttempts big.Float
+		err = retryObj["max_attempts"].As(&maxAttempts)
+		if err != nil {
+			return nil, err
+		}
+		m, _ := maxAttempts.Int64()
+		retry.MaxAttempts = int(m)
+	}
+	if !retryObj["min_backoff"].IsNull() {
+		err = retryObj["min_backoff"].As(&retry.MinBackoff)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !retryObj["max_backoff"].IsNull() {
+		err = retryObj["max_backoff"].As(&retry.MaxBackoff)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !retryObj["status_codes"].IsNull() {
+		var codes []tftypes.Value
+		err = retryObj["status_codes"].As(&codes)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range codes {
+			var code big.Float
+			err = c.As(&code)
+			if err != nil {
+				return nil, err
+			}
+			cc, _ := code.Int64()
+			retry.StatusCodes = append(retry.StatusCodes, int(cc))
+		}
+	}
+	return retry, nil
+}
+
+func getEKSAuthConfig(v tftypes.Value) (*util.EKSAuthConfig, error) {
+	var eksObj map[string]tftypes.Value
+	err := v.As(&eksObj)
+	if err != nil {
+		return nil, err
+	}
+	eks := &util.EKSAuthConfig{}
+	err = getStringAttributes(eksObj, map[string]*string{
+		"cluster_name": &eks.ClusterName,
+		"region":       &eks.Region,
+		"role_arn":     &eks.RoleARN,
+		"sts_endpoint": &eks.STSEndpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eks, nil
+}
+
+func getOIDCAuthConfig(v tftypes.Value) (*util.OIDCAuthConfig, error) {
+	var oidcObj map[string]tftypes.Value
+	err := v.As(&oidcObj)
+	if err != nil {
+		return nil, err
+	}
+	oidc := &util.OIDCAuthConfig{}
+	err = getStringAttributes(oidcObj, map[string]*string{
+		"token_url":     &oidc.TokenURL,
+		"client_id":     &oidc.ClientID,
+		"client_secret": &oidc.ClientSecret,
+		"refresh_token": &oidc.RefreshToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !oidcObj["scopes"].IsNull() {
+		var scopes []tftypes.Value
+		err = oidcObj["scopes"].As(&scopes)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range scopes {
+			var scope string
+			err = s.As(&scope)
+			if err != nil {
+				return nil, err
+			}
+			oidc.Scopes = append(oidc.Scopes, scope)
+		}
+	}
+	if !oidcObj["use_id_token"].IsNull() {
+		err = oidcObj["use_id_token"].As(&oidc.UseIDToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return oidc, nil
+}
+
+// getStringAttributes extracts the non-null string attributes of obj into the given targets.
+func getStringAttributes(obj map[string]tftypes.Value, targets map[string]*string) error {
+	for name, target := range targets {
+		if obj[name].IsNull() {
+			continue
+		}
+		if err := obj[name].As(target); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+	}
+	return nil
+}
+
 func (s *RawProviderServer) canExecute() (resp []*tfprotov5.Diagnostic) {
 	if !s.providerEnabled {
 		resp = append(resp, &tfprotov5.Diagnostic{
@@ -666,3 +680,27 @@ func (s *RawProviderServer) canExecute() (resp []*tfprotov5.Diagnostic) {
 	}
 	return
 }
+
+// configErrorDiagnostic returns the diagnostic of an error of the resolved
+// provider configuration, pointing at the attribute that caused it.
+func configErrorDiagnostic(err error) *tfprotov5.Diagnostic {
+	d := &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityInvalid,
+		Summary:  "Invalid attribute in provider configuration",
+		Detail:   err.Error(),
+	}
+	var ae *util.AttributeError
+	if errors.As(err, &ae) {
+		path := tftypes.NewAttributePath()
+		for _, step := range ae.Path {
+			switch step := step.(type) {
+			case string:
+				path = path.WithAttributeName(step)
+			case int:
+				path = path.WithElementKeyInt(step)
+			}
+		}
+		d.Attribute = path
+	}
+	return d
+}
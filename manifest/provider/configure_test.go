@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+)
+
+func TestConfigErrorDiagnostic(t *testing.T) {
+	resolved, err := util.ResolveConfig(util.ProviderConfig{
+		Host:      "https://example.com",
+		ClientKey: "not a key",
+		Retry:     &util.RetryConfig{MinBackoff: "soon"},
+		IgnoreEnv: true,
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	errs := err.(util.ConfigErrors)
+	_, err = resolved.ResolveClusters([]util.ClusterConfig{
+		{Name: "east", ProviderConfig: util.ProviderConfig{ClusterCACertificate: "not a certificate"}},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	errs = append(errs, err.(util.ConfigErrors)...)
+
+	expected := []*tftypes.AttributePath{
+		tftypes.NewAttributePath().WithAttributeName("client_key"),
+		tftypes.NewAttributePath().WithAttributeName("retry").WithElementKeyInt(0).WithAttributeName("min_backoff"),
+		tftypes.NewAttributePath().WithAttributeName("clusters").WithElementKeyInt(0).WithAttributeName("cluster_ca_certificate"),
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %s", len(expected), len(errs), errs)
+	}
+	for i, e := range errs {
+		d := configErrorDiagnostic(e)
+		if !d.Attribute.Equal(expected[i]) {
+			t.Errorf("%s: expected attribute %s, got %s", e, expected[i], d.Attribute)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// This file implements the resource operations of the types generated from CRDs,
+// see crd_types.go. The operations of RawProviderServer hand requests for those
+// types over to the functions below.
+
+func crdDiagnostic(summary string, err error) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  summary,
+		Detail:   err.Error(),
+	}
+}
+
+// metadataName returns the name and namespace in the metadata block of a value of a generated resource.
+func metadataName(v tftypes.Value) (name string, namespace string) {
+	var vals, meta map[string]tftypes.Value
+	if v.As(&vals) != nil || vals["metadata"].As(&meta) != nil {
+		return "", ""
+	}
+	meta["name"].As(&name)
+	if ns, ok := meta["namespace"]; ok {
+		ns.As(&namespace)
+	}
+	return name, namespace
+}
+
+// crdResourceClient returns the client for the objects of a generated resource in a namespace.
+func (s *RawProviderServer) crdResourceClient(r *crdResource, namespace string) (dynamic.ResourceInterface, error) {
+	c, err := s.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	if r.namespaced {
+		return c.Resource(r.gvr).Namespace(namespace), nil
+	}
+	return c.Resource(r.gvr), nil
+}
+
+func (s *RawProviderServer) validateCRDResource(r *crdResource, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
+	config, err := req.Config.Unmarshal(r.schema.ValueType())
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to unmarshal resource configuration", err))
+		return resp, nil
+	}
+	man, diags := r.manifest(config)
+	if len(diags) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, diags...)
+		return resp, nil
+	}
+	vs, err := r.foundry.ValidateByGVK(r.gvk, man)
+	if err != nil {
+		s.logger.Debug("[ValidateResourceTypeConfig]", "failed to validate", r.gvk.String(), "error", err)
+		return resp, nil
+	}
+	for _, v := range vs {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid attribute",
+			Detail:    fmt.Sprintf("%s The resource was validated against the schema of %s.", v.Detail, r.gvk.String()),
+			Attribute: r.attributePath(v.Path),
+		})
+	}
+	return resp, nil
+}
+
+func (s *RawProviderServer) upgradeCRDResourceState(r *crdResource, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	resp := &tfprotov5.UpgradeResourceStateResponse{}
+	rt := r.schema.ValueType()
+	rv, err := req.RawState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to unmarshal old state during upgrade", err))
+		return resp, nil
+	}
+	us, err := tfprotov5.NewDynamicValue(rt, rv)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to encode new state during upgrade", err))
+		return resp, nil
+	}
+	resp.UpgradedState = &us
+	return resp, nil
+}
+
+// planCRDResource plans the configured value as the new state. The generated
+// resources have no computed attributes, so planning needs no API calls.
+func (s *RawProviderServer) planCRDResource(r *crdResource, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp := &tfprotov5.PlanResourceChangeResponse{
+		PlannedState: req.ProposedNewState,
+	}
+	// the identity of the object cannot change in place
+	metaPath := tftypes.NewAttributePath().WithAttributeName("metadata")
+	resp.RequiresReplace = append(resp.RequiresReplace,
+		metaPath.WithAttributeName("name"),
+		tftypes.NewAttributePath().WithAttributeName("cluster"),
+	)
+	if r.namespaced {
+		resp.RequiresReplace = append(resp.RequiresReplace, metaPath.WithAttributeName("namespace"))
+	}
+	return resp, nil
+}
+
+func (s *RawProviderServer) applyCRDResource(ctx context.Context, r *crdResource, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp := &tfprotov5.ApplyResourceChangeResponse{}
+	if execDiag := s.canExecute(); len(execDiag) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
+		return resp, nil
+	}
+	if s.configUnknown {
+		resp.Diagnostics = append(resp.Diagnostics, configUnknownDiagnostic())
+		return resp, nil
+	}
+
+	rt := r.schema.ValueType()
+	planned, err := req.PlannedState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to unmarshal planned resource state", err))
+		return resp, nil
+	}
+	prior, err := req.PriorState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to unmarshal prior resource state", err))
+		return resp, nil
+	}
+
+	target := planned
+	if planned.IsNull() {
+		target = prior
+	}
+	s, err = s.forCluster(clusterName(target))
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+	name, namespace := metadataName(target)
+	rn := types.NamespacedName{Namespace: namespace, Name: name}.String()
+	rs, err := s.crdResourceClient(r, namespace)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to retrieve Kubernetes dynamic client during apply", err))
+		return resp, nil
+	}
+
+	if planned.IsNull() {
+		timeout, _ := time.ParseDuration(defaultDeleteTimeout)
+		ctxDeadline, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if err := rs.Delete(ctxDeadline, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic(fmt.Sprintf("Error deleting resource %s", rn), err))
+			return resp, nil
+		}
+		for {
+			_, err := rs.Get(ctxDeadline, name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				break
+			}
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Error waiting for deletion.",
+					Detail:   fmt.Sprintf("Error when waiting for resource %q to be deleted: %v", rn, err),
+				})
+				return resp, nil
+			}
+			select {
+			case <-ctxDeadline.Done():
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  fmt.Sprintf("Timed out when waiting for resource %q to be deleted", rn),
+					Detail:   "Deletion timed out. This can happen when there is a finalizer on a resource. You may need to delete this resource manually with kubectl.",
+				})
+				return resp, nil
+			case <-time.After(time.Second):
+			}
+		}
+		resp.NewState = req.PlannedState
+		return resp, nil
+	}
+
+	if prior.IsNull() {
+		_, err := rs.Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Cannot create resource that already exists",
+				Detail:   fmt.Sprintf("resource %q already exists", rn),
+			})
+			return resp, nil
+		} else if !apierrors.IsNotFound(err) {
+			resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic(fmt.Sprintf("Failed to determine if resource %q exists", rn), err))
+			return resp, nil
+		}
+	}
+
+	obj, diags := r.object(planned, s.defaultLabels, s.defaultAnnotations)
+	if len(diags) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, diags...)
+		return resp, nil
+	}
+	js, err := json.Marshal(obj)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic(fmt.Sprintf("Failed to marshall resource '%s' to JSON", rn), err))
+		return resp, nil
+	}
+	force := false
+	_, err = rs.Patch(ctx, name, types.ApplyPatchType, js, metav1.PatchOptions{
+		FieldManager: defaultFieldManagerName,
+		Force:        &force,
+	})
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf(`There was a field manager conflict when trying to apply the resource %s`, rn),
+			Detail:   err.Error(),
+		})
+		if !apierrors.IsConflict(err) {
+			resp.Diagnostics[len(resp.Diagnostics)-1].Summary = fmt.Sprintf("PATCH for resource %q failed to apply", rn)
+		}
+		return resp, nil
+	}
+	resp.NewState = req.PlannedState
+	return resp, nil
+}
+
+// readCRDResource refreshes the state of a generated resource from the API.
+// Only the attributes that are set in the state are refreshed, so that the
+// values the API server defaults do not show up as drift.
+func (s *RawProviderServer) readCRDResource(ctx context.Context, r *crdResource, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	resp := &tfprotov5.ReadResourceResponse{Private: req.Private}
+	if execDiag := s.canExecute(); len(execDiag) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
+		return resp, nil
+	}
+	if s.configUnknown {
+		resp.NewState = req.CurrentState
+		return resp, nil
+	}
+
+	rt := r.schema.ValueType()
+	current, err := req.CurrentState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to decode current state", err))
+		return resp, nil
+	}
+	if current.IsNull() {
+		resp.NewState = req.CurrentState
+		return resp, nil
+	}
+	var vals map[string]tftypes.Value
+	if err := current.As(&vals); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to extract resource from current state", err))
+		return resp, nil
+	}
+	s, err = s.forCluster(clusterName(current))
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+
+	name, namespace := metadataName(current)
+	rs, err := s.crdResourceClient(r, namespace)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to retrieve Kubernetes dynamic client during read", err))
+		return resp, nil
+	}
+	ro, err := rs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			nullState, err := tfprotov5.NewDynamicValue(rt, tftypes.NewValue(rt, nil))
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to create Msgpack value", err))
+				return resp, nil
+			}
+			resp.NewState = &nullState
+			return resp, nil
+		}
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic(fmt.Sprintf("Cannot GET resource %s", types.NamespacedName{Namespace: namespace, Name: name}), err))
+		return resp, nil
+	}
+
+	nv, err := r.value(ro.UnstructuredContent(), vals["cluster"])
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to convert API response to resource state", err))
+		return resp, nil
+	}
+	newState, err := tfprotov5.NewDynamicValue(rt, projectValue(nv, current))
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to create Msgpack value", err))
+		return resp, nil
+	}
+	resp.NewState = &newState
+	return resp, nil
+}
+
+// importCRDResource imports an object as a generated resource. The ID is the name
+// of the object, preceded by its namespace and a slash for namespaced resources.
+func (s *RawProviderServer) importCRDResource(ctx context.Context, r *crdResource, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	resp := &tfprotov5.ImportResourceStateResponse{}
+	if execDiag := s.canExecute(); len(execDiag) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
+		return resp, nil
+	}
+	if s.configUnknown {
+		resp.Diagnostics = append(resp.Diagnostics, configUnknownDiagnostic())
+		return resp, nil
+	}
+
+	cluster, id := splitClusterImportID(req.ID)
+	s, err := s.forCluster(cluster)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+	namespace, name, ok := strings.Cut(id, "/")
+	if !ok {
+		namespace, name = "", id
+	}
+	if name == "" || (namespace == "") == r.namespaced {
+		format := "<name>"
+		if r.namespaced {
+			format = "<namespace>/<name>"
+		}
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to parse import ID",
+			Detail:   fmt.Sprintf("The import ID %q of a %s must have the format %q.", req.ID, r.gvk.Kind, format),
+		})
+		return resp, nil
+	}
+
+	rs, err := s.crdResourceClient(r, namespace)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to get Dynamic client", err))
+		return resp, nil
+	}
+	ro, err := rs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic(fmt.Sprintf("Failed to get resource %s from API", id), err))
+		return resp, nil
+	}
+
+	cv := tftypes.NewValue(tftypes.String, nil)
+	if cluster != "" {
+		cv = tftypes.NewValue(tftypes.String, cluster)
+	}
+	nv, err := r.value(RemoveServerSideFields(ro.UnstructuredContent()), cv)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to convert API response to resource state", err))
+		return resp, nil
+	}
+	impState, err := tfprotov5.NewDynamicValue(nv.Type(), nv)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, crdDiagnostic("Failed to construct dynamic value for imported state", err))
+		return resp, nil
+	}
+	resp.ImportedResources = append(resp.ImportedResources, &tfprotov5.ImportedResource{
+		TypeName: req.TypeName,
+		State:    &impState,
+	})
+	return resp, nil
+}
@@ -0,0 +1,718 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// CRDPathsEnvVar lists the CustomResourceDefinition manifests that typed resources
+// are generated from. Terraform requests the provider schema before it configures
+// the provider, so the resource types cannot depend on the provider block.
+const CRDPathsEnvVar = "KUBE_CRD_PATHS"
+
+// crdTypePrefix prefixes the names of the resource types generated from CRDs.
+const crdTypePrefix = "kubernetes_crd_"
+
+// crdRootReserved holds the names of the attributes of a generated resource type
+// that are not taken from the CRD schema, and the meta-arguments of resources.
+var crdRootReserved = []string{"cluster", "metadata", "count", "depends_on", "for_each", "lifecycle", "provider", "provisioner", "connection"}
+
+// crdResource is a resource type generated from a served version of a CRD.
+type crdResource struct {
+	gvk        schema.GroupVersionKind
+	gvr        schema.GroupVersionResource
+	namespaced bool
+	schema     *tfprotov5.Schema
+
+	// manifestType is the type of the object, as built by the OpenAPI foundry
+	// from the CRD schema, without the attributes the resource type manages
+	// itself. hints are the type hints of the foundry for the payload conversion.
+	manifestType tftypes.Object
+	hints        map[string]string
+	foundry      openapi.Foundry
+}
+
+// loadCRDResources generates resource types from the CustomResourceDefinition
+// manifests found in a list of files and directories, in the format of PATH.
+func loadCRDResources(paths string) (map[string]*crdResource, error) {
+	resources := make(map[string]*crdResource)
+	for _, p := range filepath.SplitList(paths) {
+		if p == "" {
+			continue
+		}
+		files := []string{p}
+		if fi, err := os.Stat(p); err != nil {
+			return nil, err
+		} else if fi.IsDir() {
+			files = nil
+			entries, err := os.ReadDir(p)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range entries {
+				switch filepath.Ext(e.Name()) {
+				case ".yaml", ".yml", ".json":
+					if !e.IsDir() {
+						files = append(files, filepath.Join(p, e.Name()))
+					}
+				}
+			}
+		}
+		for _, f := range files {
+			if err := loadCRDFile(f, resources); err != nil {
+				return nil, fmt.Errorf("%s: %s", f, err)
+			}
+		}
+	}
+	return resources, nil
+}
+
+func loadCRDFile(path string, resources map[string]*crdResource) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var doc map[string]interface{}
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if doc["apiVersion"] != "apiextensions.k8s.io/v1" || doc["kind"] != "CustomResourceDefinition" {
+			continue
+		}
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(doc, &crd); err != nil {
+			return err
+		}
+		for _, v := range crd.Spec.Versions {
+			if !v.Served || v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			r, err := newCRDResource(&crd, v.Name, v.Schema.OpenAPIV3Schema)
+			if err != nil {
+				return fmt.Errorf("CRD %s version %s: %s", crd.Name, v.Name, err)
+			}
+			name := crdTypeName(r.gvk)
+			if _, ok := resources[name]; ok {
+				return fmt.Errorf("CRD %s version %s: resource type %s is generated twice", crd.Name, v.Name, name)
+			}
+			resources[name] = r
+		}
+	}
+}
+
+func newCRDResource(crd *apiextensionsv1.CustomResourceDefinition, version string, props *apiextensionsv1.JSONSchemaProps) (*crdResource, error) {
+	r := &crdResource{
+		gvk:        schema.GroupVersionKind{Group: crd.Spec.Group, Version: version, Kind: crd.Spec.Names.Kind},
+		gvr:        schema.GroupVersionResource{Group: crd.Spec.Group, Version: version, Resource: crd.Spec.Names.Plural},
+		namespaced: crd.Spec.Scope == apiextensionsv1.NamespaceScoped,
+	}
+
+	// build the type of the objects with the same foundry as kubernetes_manifest
+	js, err := json.Marshal(props)
+	if err != nil {
+		return nil, err
+	}
+	var crdSchema map[string]interface{}
+	if err := json.Unmarshal(js, &crdSchema); err != nil {
+		return nil, err
+	}
+	js, err = json.Marshal(openapi.SchemaToSpec("", crdSchema))
+	if err != nil {
+		return nil, err
+	}
+	r.foundry, err = openapi.NewFoundryFromSpecV3(js)
+	if err != nil {
+		return nil, err
+	}
+	t, hints, err := r.foundry.GetTypeByGVK(r.gvk)
+	if err != nil {
+		return nil, err
+	}
+	ot, ok := t.(tftypes.Object)
+	if !ok {
+		return nil, errors.New("the schema does not describe an object")
+	}
+	atts := make(map[string]tftypes.Type, len(ot.AttributeTypes))
+	for k, at := range ot.AttributeTypes {
+		switch k {
+		case "apiVersion", "kind", "metadata", "status":
+		default:
+			atts[k] = at
+		}
+	}
+	r.manifestType = tftypes.Object{AttributeTypes: atts}
+	r.hints = hints
+
+	block := crdSchemaBlock(r.manifestType, props, crdRootReserved)
+	block.Description = fmt.Sprintf("A %s (%s) custom resource. %s", r.gvk.Kind, r.gvk.GroupVersion().String(), props.Description)
+	block.Attributes = append([]*tfprotov5.SchemaAttribute{{
+		Name:        "cluster",
+		Type:        tftypes.String,
+		Optional:    true,
+		Description: "Name of the cluster from the clusters block of the provider. Defaults to the cluster configured at the top level of the provider block.",
+	}}, block.Attributes...)
+	block.BlockTypes = append([]*tfprotov5.SchemaNestedBlock{crdMetadataBlock(r.namespaced)}, block.BlockTypes...)
+	r.schema = &tfprotov5.Schema{Version: 0, Block: block}
+	return r, nil
+}
+
+// crdTypeName returns the name of the resource type generated for a GVK,
+// such as kubernetes_crd_cert_manager_io_certificate_v1.
+func crdTypeName(gvk schema.GroupVersionKind) string {
+	name := strings.ToLower(strings.Join([]string{gvk.Group, gvk.Kind, gvk.Version}, "_"))
+	return crdTypePrefix + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func crdMetadataBlock(namespaced bool) *tfprotov5.SchemaNestedBlock {
+	atts := []*tfprotov5.SchemaAttribute{
+		{
+			Name:        "name",
+			Type:        tftypes.String,
+			Required:    true,
+			Description: "Name of the resource.",
+		},
+	}
+	if namespaced {
+		atts = append(atts, &tfprotov5.SchemaAttribute{
+			Name:        "namespace",
+			Type:        tftypes.String,
+			Required:    true,
+			Description: "Namespace of the resource.",
+		})
+	}
+	atts = append(atts,
+		&tfprotov5.SchemaAttribute{
+			Name:        "labels",
+			Type:        tftypes.Map{ElementType: tftypes.String},
+			Optional:    true,
+			Description: "Map of string keys and values that can be used to organize and categorize the resource.",
+		},
+		&tfprotov5.SchemaAttribute{
+			Name:        "annotations",
+			Type:        tftypes.Map{ElementType: tftypes.String},
+			Optional:    true,
+			Description: "An unstructured key value map stored with the resource that may be used to store arbitrary metadata.",
+		},
+	)
+	return &tfprotov5.SchemaNestedBlock{
+		TypeName: "metadata",
+		Nesting:  tfprotov5.SchemaNestedBlockNestingModeSingle,
+		MinItems: 1,
+		MaxItems: 1,
+		Block: &tfprotov5.SchemaBlock{
+			Attributes:  atts,
+			Description: "Standard object metadata.",
+		},
+	}
+}
+
+// crdSchemaBlock builds the schema block of an object of the CRD schema. Objects
+// become nested blocks, and so do lists and maps of objects. Attributes that the
+// foundry cannot type fully, such as the ones preserving unknown fields, are
+// dynamic and take values in the format of the manifest.
+func crdSchemaBlock(t tftypes.Object, props *apiextensionsv1.JSONSchemaProps, reserved []string) *tfprotov5.SchemaBlock {
+	block := &tfprotov5.SchemaBlock{}
+	if props == nil {
+		props = &apiextensionsv1.JSONSchemaProps{}
+	}
+	required := make(map[string]bool, len(props.Required))
+	for _, r := range props.Required {
+		required[r] = true
+	}
+	names := crdAttributeNames(t, reserved)
+	keys := make([]string, 0, len(names))
+	for k := range names {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		name := names[k]
+		at := t.AttributeTypes[k]
+		p := props.Properties[k]
+		var items *apiextensionsv1.JSONSchemaProps
+		if p.Items != nil {
+			items = p.Items.Schema
+		}
+		var values *apiextensionsv1.JSONSchemaProps
+		if p.AdditionalProperties != nil {
+			values = p.AdditionalProperties.Schema
+		}
+
+		nb := &tfprotov5.SchemaNestedBlock{TypeName: name}
+		switch {
+		case at.Is(tftypes.Object{}):
+			nb.Nesting = tfprotov5.SchemaNestedBlockNestingModeSingle
+			nb.Block = crdSchemaBlock(at.(tftypes.Object), &p, nil)
+			if required[k] {
+				nb.MinItems, nb.MaxItems = 1, 1
+			}
+		case at.Is(tftypes.List{}) && isBlockType(at.(tftypes.List).ElementType):
+			nb.Nesting = tfprotov5.SchemaNestedBlockNestingModeList
+			nb.Block = crdSchemaBlock(at.(tftypes.List).ElementType.(tftypes.Object), items, nil)
+		case at.Is(tftypes.Map{}) && isBlockType(at.(tftypes.Map).ElementType):
+			nb.Nesting = tfprotov5.SchemaNestedBlockNestingModeMap
+			nb.Block = crdSchemaBlock(at.(tftypes.Map).ElementType.(tftypes.Object), values, nil)
+		default:
+			a := &tfprotov5.SchemaAttribute{
+				Name:        name,
+				Type:        at,
+				Description: p.Description,
+				Required:    required[k],
+				Optional:    !required[k],
+			}
+			if !isAttributeType(at) {
+				a.Type = tftypes.DynamicPseudoType
+			}
+			block.Attributes = append(block.Attributes, a)
+			continue
+		}
+		nb.Block.Description = p.Description
+		block.BlockTypes = append(block.BlockTypes, nb)
+	}
+	return block
+}
+
+// isBlockType reports whether lists and maps of t can be nested blocks, which
+// is the case for objects that do not contain dynamic attributes at any depth.
+func isBlockType(t tftypes.Type) bool {
+	ot, ok := t.(tftypes.Object)
+	if !ok {
+		return false
+	}
+	for _, at := range ot.AttributeTypes {
+		switch tt := at.(type) {
+		case tftypes.Object:
+			if !isBlockType(tt) {
+				return false
+			}
+		case tftypes.List:
+			if !isAttributeType(tt) && !isBlockType(tt.ElementType) {
+				return false
+			}
+		case tftypes.Map:
+			if !isAttributeType(tt) && !isBlockType(tt.ElementType) {
+				return false
+			}
+		default:
+			if !isAttributeType(tt) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isAttributeType reports whether a type can be used as is for an attribute,
+// which is the case for primitive types and collections of them.
+func isAttributeType(t tftypes.Type) bool {
+	switch {
+	case t.Is(tftypes.String), t.Is(tftypes.Number), t.Is(tftypes.Bool):
+		return true
+	case t.Is(tftypes.List{}):
+		return isAttributeType(t.(tftypes.List).ElementType)
+	case t.Is(tftypes.Map{}):
+		return isAttributeType(t.(tftypes.Map).ElementType)
+	}
+	return false
+}
+
+// crdAttributeNames maps the attributes of an object of the CRD schema to the names
+// of the Terraform attributes, which must be in snake case. Attributes whose names
+// conflict with a reserved name or an earlier attribute are left out.
+func crdAttributeNames(t tftypes.Object, reserved []string) map[string]string {
+	keys := make([]string, 0, len(t.AttributeTypes))
+	for k := range t.AttributeTypes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	taken := make(map[string]bool, len(reserved))
+	for _, r := range reserved {
+		taken[r] = true
+	}
+	names := make(map[string]string, len(keys))
+	for _, k := range keys {
+		n := snakeCase(k)
+		if n == "" || taken[n] || unicode.IsDigit(rune(n[0])) {
+			continue
+		}
+		taken[n] = true
+		names[k] = n
+	}
+	return names
+}
+
+// snakeCase converts a camel case name, such as "podCIDRRange", to snake case, as in "pod_cidr_range".
+func snakeCase(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1]) ||
+				(unicode.IsUpper(rs[i-1]) && i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// toManifest converts the value of an attribute or nested block of a generated
+// resource to the value of type mt of the manifest.
+func toManifest(v tftypes.Value, mt tftypes.Type, ap *tftypes.AttributePath) (tftypes.Value, []*tfprotov5.Diagnostic) {
+	if !v.IsKnown() {
+		return tftypes.NewValue(mt, tftypes.UnknownValue), nil
+	}
+	if v.IsNull() {
+		return tftypes.NewValue(mt, nil), nil
+	}
+	switch tt := mt.(type) {
+	case tftypes.Object:
+		return objectToManifest(v, tt, ap, nil)
+	case tftypes.List:
+		if isBlockType(tt.ElementType) {
+			var elems []tftypes.Value
+			if err := v.As(&elems); err != nil {
+				return tftypes.Value{}, []*tfprotov5.Diagnostic{crdConversionDiagnostic(ap, err)}
+			}
+			out := make([]tftypes.Value, len(elems))
+			for i, e := range elems {
+				mv, d := toManifest(e, tt.ElementType, ap.WithElementKeyInt(i))
+				if len(d) > 0 {
+					return tftypes.Value{}, d
+				}
+				out[i] = mv
+			}
+			return tftypes.NewValue(mt, out), nil
+		}
+	case tftypes.Map:
+		if isBlockType(tt.ElementType) {
+			var elems map[string]tftypes.Value
+			if err := v.As(&elems); err != nil {
+				return tftypes.Value{}, []*tfprotov5.Diagnostic{crdConversionDiagnostic(ap, err)}
+			}
+			out := make(map[string]tftypes.Value, len(elems))
+			for k, e := range elems {
+				mv, d := toManifest(e, tt.ElementType, ap.WithElementKeyString(k))
+				if len(d) > 0 {
+					return tftypes.Value{}, d
+				}
+				out[k] = mv
+			}
+			return tftypes.NewValue(mt, out), nil
+		}
+	}
+	if isAttributeType(mt) || mt.Is(tftypes.DynamicPseudoType) {
+		return v, nil
+	}
+	// dynamic attributes hold values in the format of the manifest
+	return morph.ValueToType(v, mt, ap)
+}
+
+func objectToManifest(v tftypes.Value, mt tftypes.Object, ap *tftypes.AttributePath, reserved []string) (tftypes.Value, []*tfprotov5.Diagnostic) {
+	var vals map[string]tftypes.Value
+	if err := v.As(&vals); err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{crdConversionDiagnostic(ap, err)}
+	}
+	names := crdAttributeNames(mt, reserved)
+	out := make(map[string]tftypes.Value, len(mt.AttributeTypes))
+	for k, at := range mt.AttributeTypes {
+		n, ok := names[k]
+		if !ok {
+			out[k] = tftypes.NewValue(at, nil)
+			continue
+		}
+		mv, d := toManifest(vals[n], at, ap.WithAttributeName(n))
+		if len(d) > 0 {
+			return tftypes.Value{}, d
+		}
+		out[k] = mv
+	}
+	return tftypes.NewValue(mt, out), nil
+}
+
+// fromManifest converts a value of the manifest to the value of type t of an
+// attribute or nested block of a generated resource.
+func fromManifest(v tftypes.Value, t tftypes.Type) tftypes.Value {
+	if v.IsNull() || !v.IsKnown() {
+		return tftypes.NewValue(t, nil)
+	}
+	switch tt := t.(type) {
+	case tftypes.Object:
+		return objectFromManifest(v, tt, nil)
+	case tftypes.List:
+		var elems []tftypes.Value
+		if v.As(&elems) != nil {
+			return tftypes.NewValue(t, nil)
+		}
+		out := make([]tftypes.Value, len(elems))
+		for i, e := range elems {
+			out[i] = fromManifest(e, tt.ElementType)
+		}
+		return tftypes.NewValue(t, out)
+	case tftypes.Map:
+		var elems map[string]tftypes.Value
+		if v.As(&elems) != nil {
+			return tftypes.NewValue(t, nil)
+		}
+		out := make(map[string]tftypes.Value, len(elems))
+		for k, e := range elems {
+			out[k] = fromManifest(e, tt.ElementType)
+		}
+		return tftypes.NewValue(t, out)
+	}
+	return v
+}
+
+func objectFromManifest(v tftypes.Value, t tftypes.Object, reserved []string) tftypes.Value {
+	var vals map[string]tftypes.Value
+	mt, ok := v.Type().(tftypes.Object)
+	if !ok || v.As(&vals) != nil {
+		return tftypes.NewValue(t, nil)
+	}
+	out := make(map[string]tftypes.Value, len(t.AttributeTypes))
+	for n, at := range t.AttributeTypes {
+		out[n] = tftypes.NewValue(at, nil)
+	}
+	for k, n := range crdAttributeNames(mt, reserved) {
+		if at, ok := t.AttributeTypes[n]; ok {
+			out[n] = fromManifest(vals[k], at)
+		}
+	}
+	return tftypes.NewValue(t, out)
+}
+
+func crdConversionDiagnostic(ap *tftypes.AttributePath, err error) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity:  tfprotov5.DiagnosticSeverityError,
+		Summary:   "Failed to convert attribute value",
+		Detail:    err.Error(),
+		Attribute: ap,
+	}
+}
+
+// manifest converts the value of a generated resource to its manifest,
+// with the type of the OpenAPI foundry.
+func (r *crdResource) manifest(v tftypes.Value) (tftypes.Value, []*tfprotov5.Diagnostic) {
+	mv, d := objectToManifest(v, r.manifestType, tftypes.NewAttributePath(), crdRootReserved)
+	if len(d) > 0 {
+		return tftypes.Value{}, d
+	}
+	var vals, atts map[string]tftypes.Value
+	if err := mv.As(&atts); err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{crdConversionDiagnostic(tftypes.NewAttributePath(), err)}
+	}
+	if err := v.As(&vals); err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{crdConversionDiagnostic(tftypes.NewAttributePath(), err)}
+	}
+	types := make(map[string]tftypes.Type, len(atts)+3)
+	for k, a := range atts {
+		types[k] = a.Type()
+	}
+	atts["apiVersion"] = tftypes.NewValue(tftypes.String, r.gvk.GroupVersion().String())
+	atts["kind"] = tftypes.NewValue(tftypes.String, r.gvk.Kind)
+	atts["metadata"] = vals["metadata"]
+	types["apiVersion"], types["kind"], types["metadata"] = tftypes.String, tftypes.String, vals["metadata"].Type()
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, atts), nil
+}
+
+// object converts the value of a generated resource to the object to apply,
+// with the default labels and annotations of the provider merged into its
+// metadata. Refresh only keeps the labels and annotations in the state, so
+// the defaults do not show up as drift.
+func (r *crdResource) object(v tftypes.Value, labels, annotations map[string]string) (map[string]interface{}, []*tfprotov5.Diagnostic) {
+	mv, d := r.manifest(v)
+	if len(d) > 0 {
+		return nil, d
+	}
+	mv, err := mergeDefaultMetadata(mv, labels, annotations)
+	if err != nil {
+		return nil, []*tfprotov5.Diagnostic{crdConversionDiagnostic(tftypes.NewAttributePath().WithAttributeName("metadata"), err)}
+	}
+	o, err := payload.FromTFValue(mv, r.hints, tftypes.NewAttributePath())
+	if err != nil {
+		return nil, []*tfprotov5.Diagnostic{crdConversionDiagnostic(tftypes.NewAttributePath(), err)}
+	}
+	return mapRemoveNulls(o.(map[string]interface{})), nil
+}
+
+// value converts an object read from the API to the value of the generated resource.
+func (r *crdResource) value(obj map[string]interface{}, cluster tftypes.Value) (tftypes.Value, error) {
+	rt := r.schema.Block.ValueType().(tftypes.Object)
+	mv, err := payload.ToTFValue(obj, r.manifestType, r.hints, tftypes.NewAttributePath())
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	v := objectFromManifest(mv, rt, crdRootReserved)
+	var vals map[string]tftypes.Value
+	if err := v.As(&vals); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	metaType := rt.AttributeTypes["metadata"].(tftypes.Object)
+	meta, _ := obj["metadata"].(map[string]interface{})
+	metaAtts := make(map[string]interface{}, len(metaType.AttributeTypes))
+	for k := range metaType.AttributeTypes {
+		if mv, ok := meta[k]; ok {
+			metaAtts[k] = mv
+		}
+	}
+	vals["metadata"], err = payload.ToTFValue(metaAtts, metaType, nil, tftypes.NewAttributePath().WithAttributeName("metadata"))
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	vals["cluster"] = cluster
+	return tftypes.NewValue(rt, vals), nil
+}
+
+// attributePath translates the path of an attribute of the manifest to the path
+// of the attribute of the generated resource, as far as the resource has it.
+func (r *crdResource) attributePath(mp *tftypes.AttributePath) *tftypes.AttributePath {
+	ap := tftypes.NewAttributePath()
+	var t tftypes.Type = r.manifestType
+	for i, step := range mp.Steps() {
+		switch s := step.(type) {
+		case tftypes.AttributeName:
+			if i == 0 && s == "metadata" {
+				return tftypes.NewAttributePathWithSteps(mp.Steps())
+			}
+			ot, ok := t.(tftypes.Object)
+			if !ok {
+				return ap
+			}
+			reserved := []string(nil)
+			if i == 0 {
+				reserved = crdRootReserved
+			}
+			n, ok := crdAttributeNames(ot, reserved)[string(s)]
+			if !ok {
+				return ap
+			}
+			ap, t = ap.WithAttributeName(n), ot.AttributeTypes[string(s)]
+		case tftypes.ElementKeyInt:
+			l, ok := t.(tftypes.List)
+			if !ok {
+				return ap
+			}
+			ap, t = ap.WithElementKeyInt(int(s)), l.ElementType
+		case tftypes.ElementKeyString:
+			m, ok := t.(tftypes.Map)
+			if !ok {
+				return ap
+			}
+			ap, t = ap.WithElementKeyString(string(s)), m.ElementType
+		default:
+			return ap
+		}
+		if !t.Is(tftypes.Object{}) && !t.Is(tftypes.List{}) && !t.Is(tftypes.Map{}) {
+			// the rest of the path is inside an attribute
+			return ap
+		}
+		if (t.Is(tftypes.List{}) || t.Is(tftypes.Map{})) && !isBlockType(elementType(t)) {
+			return ap
+		}
+	}
+	return ap
+}
+
+func elementType(t tftypes.Type) tftypes.Type {
+	switch tt := t.(type) {
+	case tftypes.List:
+		return tt.ElementType
+	case tftypes.Map:
+		return tt.ElementType
+	}
+	return nil
+}
+
+// projectValue returns the value v read from the API, reduced to the attributes
+// that are set in the prior value p, so that attributes left to the API server
+// are not reported as changes outside of Terraform.
+func projectValue(v, p tftypes.Value) tftypes.Value {
+	if p.IsNull() {
+		return p
+	}
+	if v.IsNull() || !v.IsKnown() || !p.IsKnown() {
+		return v
+	}
+	sameType := p.Type().Equal(v.Type())
+	switch {
+	case (p.Type().Is(tftypes.Object{}) || p.Type().Is(tftypes.Map{})) && (v.Type().Is(tftypes.Object{}) || v.Type().Is(tftypes.Map{})):
+		var pv, vv map[string]tftypes.Value
+		if p.As(&pv) != nil || v.As(&vv) != nil {
+			return v
+		}
+		out := make(map[string]tftypes.Value, len(pv))
+		types := make(map[string]tftypes.Type, len(pv))
+		for k, pe := range pv {
+			ve, ok := vv[k]
+			if !ok {
+				if p.Type().Is(tftypes.Map{}) {
+					continue
+				}
+				ve = tftypes.NewValue(pe.Type(), nil)
+			}
+			out[k] = projectValue(ve, pe)
+			types[k] = out[k].Type()
+		}
+		if sameType {
+			return tftypes.NewValue(p.Type(), out)
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, out)
+	case (p.Type().Is(tftypes.List{}) || p.Type().Is(tftypes.Tuple{})) && (v.Type().Is(tftypes.List{}) || v.Type().Is(tftypes.Tuple{})):
+		var pv, vv []tftypes.Value
+		if p.As(&pv) != nil || v.As(&vv) != nil {
+			return v
+		}
+		out := make([]tftypes.Value, len(vv))
+		types := make([]tftypes.Type, len(vv))
+		for i, ve := range vv {
+			out[i] = ve
+			if i < len(pv) {
+				out[i] = projectValue(ve, pv[i])
+			}
+			types[i] = out[i].Type()
+		}
+		if sameType {
+			return tftypes.NewValue(p.Type(), out)
+		}
+		return tftypes.NewValue(tftypes.Tuple{ElementTypes: types}, out)
+	}
+	return v
+}
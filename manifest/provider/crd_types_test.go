@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const widgetCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [size]
+            properties:
+              size:
+                type: string
+                description: Size of the widget.
+              replicaCount:
+                type: integer
+              podCIDR:
+                type: string
+              tags:
+                type: array
+                items:
+                  type: string
+              ports:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    name:
+                      type: string
+                    containerPort:
+                      type: integer
+              extra:
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+          status:
+            type: object
+            properties:
+              ready:
+                type: boolean
+  - name: v1alpha1
+    served: false
+    storage: false
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func loadWidgetResource(t *testing.T) *crdResource {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "widgets.yaml"), []byte(widgetCRD), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	resources, err := loadCRDResources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected one resource type, got %v", resources)
+	}
+	r, ok := resources["kubernetes_crd_example_com_widget_v1"]
+	if !ok {
+		t.Fatalf("expected resource type kubernetes_crd_example_com_widget_v1, got %v", resources)
+	}
+	return r
+}
+
+func TestCRDResourceSchema(t *testing.T) {
+	r := loadWidgetResource(t)
+
+	root := r.schema.Block
+	if len(root.Attributes) != 1 || root.Attributes[0].Name != "cluster" {
+		t.Errorf("expected only the cluster attribute at the root, got %v", root.Attributes)
+	}
+	blocks := make(map[string]*tfprotov5.SchemaNestedBlock)
+	for _, b := range root.BlockTypes {
+		blocks[b.TypeName] = b
+	}
+	if _, ok := blocks["status"]; ok {
+		t.Error("expected no status block")
+	}
+	meta, ok := blocks["metadata"]
+	if !ok || meta.MinItems != 1 || len(meta.Block.Attributes) != 4 {
+		t.Errorf("expected a required metadata block with namespace, got %v", meta)
+	}
+
+	spec, ok := blocks["spec"]
+	if !ok || spec.Nesting != tfprotov5.SchemaNestedBlockNestingModeSingle {
+		t.Fatalf("expected a single spec block, got %v", spec)
+	}
+	atts := make(map[string]*tfprotov5.SchemaAttribute)
+	for _, a := range spec.Block.Attributes {
+		atts[a.Name] = a
+	}
+	expected := map[string]tftypes.Type{
+		"size":          tftypes.String,
+		"replica_count": tftypes.Number,
+		"pod_cidr":      tftypes.String,
+		"tags":          tftypes.List{ElementType: tftypes.String},
+		"extra":         tftypes.DynamicPseudoType,
+	}
+	if len(atts) != len(expected) {
+		t.Errorf("expected attributes %v, got %v", expected, atts)
+	}
+	for n, et := range expected {
+		if a, ok := atts[n]; !ok || !a.Type.Equal(et) {
+			t.Errorf("expected attribute %s of type %s, got %v", n, et, a)
+		}
+	}
+	if !atts["size"].Required || atts["size"].Description != "Size of the widget." {
+		t.Errorf("expected a required and described size attribute, got %v", atts["size"])
+	}
+	if len(spec.Block.BlockTypes) != 1 || spec.Block.BlockTypes[0].TypeName != "ports" ||
+		spec.Block.BlockTypes[0].Nesting != tfprotov5.SchemaNestedBlockNestingModeList {
+		t.Errorf("expected a list of ports blocks, got %v", spec.Block.BlockTypes)
+	}
+}
+
+func TestCRDResourceConversion(t *testing.T) {
+	r := loadWidgetResource(t)
+
+	obj := map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+			"labels":    map[string]interface{}{"app": "test"},
+		},
+		"spec": map[string]interface{}{
+			"size":         "small",
+			"replicaCount": int64(2),
+			"tags":         []interface{}{"a", "b"},
+			"ports": []interface{}{
+				map[string]interface{}{"name": "http", "containerPort": int64(8080)},
+			},
+			"extra": map[string]interface{}{"anything": "goes"},
+		},
+	}
+	v, err := r.value(obj, tftypes.NewValue(tftypes.String, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Type().Equal(r.schema.ValueType()) {
+		t.Fatalf("expected a value of the resource type, got %s", v.Type())
+	}
+	out, diags := r.object(v, nil, nil)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	if !reflect.DeepEqual(obj, out) {
+		t.Errorf("expected the conversion to round trip\nexpected: %v\ngot:      %v", obj, out)
+	}
+
+	// the defaults of the provider are merged into the applied object only
+	out, diags = r.object(v, map[string]string{"app": "default", "team": "platform"}, map[string]string{"owner": "platform"})
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	meta := out["metadata"].(map[string]interface{})
+	if labels := map[string]interface{}{"app": "test", "team": "platform"}; !reflect.DeepEqual(meta["labels"], labels) {
+		t.Errorf("expected labels %v, got %v", labels, meta["labels"])
+	}
+	if annotations := map[string]interface{}{"owner": "platform"}; !reflect.DeepEqual(meta["annotations"], annotations) {
+		t.Errorf("expected annotations %v, got %v", annotations, meta["annotations"])
+	}
+	nv, err := r.value(out, tftypes.NewValue(tftypes.String, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pv := projectValue(nv, v); !pv.Equal(v) {
+		t.Errorf("expected the refreshed value to leave out the default labels, got %v", pv)
+	}
+
+	// attributes left unset in the state are not refreshed
+	obj["spec"].(map[string]interface{})["podCIDR"] = "10.0.0.0/8"
+	nv, err = r.value(obj, tftypes.NewValue(tftypes.String, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pv := projectValue(nv, v); !pv.Equal(v) {
+		t.Errorf("expected the refreshed value to equal the prior value, got %v", pv)
+	}
+}
+
+func TestCRDResourceAttributePath(t *testing.T) {
+	r := loadWidgetResource(t)
+
+	samples := map[string]*tftypes.AttributePath{
+		`AttributeName("spec").AttributeName("ports").ElementKeyInt(0).AttributeName("container_port")`: tftypes.NewAttributePath().WithAttributeName("spec").WithAttributeName("ports").WithElementKeyInt(0).WithAttributeName("containerPort"),
+		`AttributeName("spec").AttributeName("extra")`:                                                  tftypes.NewAttributePath().WithAttributeName("spec").WithAttributeName("extra").WithAttributeName("anything"),
+		`AttributeName("spec").AttributeName("tags")`:                                                   tftypes.NewAttributePath().WithAttributeName("spec").WithAttributeName("tags").WithElementKeyInt(1),
+		`AttributeName("metadata").AttributeName("name")`:                                               tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("name"),
+	}
+	for expected, mp := range samples {
+		if p := r.attributePath(mp).String(); p != expected {
+			t.Errorf("%s: expected %s, got %s", mp, expected, p)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	samples := map[string]string{
+		"name":                 "name",
+		"containerPort":        "container_port",
+		"podCIDR":              "pod_cidr",
+		"podCIDRRange":         "pod_cidr_range",
+		"ipv6Address":          "ipv6_address",
+		"x-kubernetes-foo.bar": "x_kubernetes_foo_bar",
+	}
+	for in, expected := range samples {
+		if out := snakeCase(in); out != expected {
+			t.Errorf("%s: expected %s, got %s", in, expected, out)
+		}
+	}
+}
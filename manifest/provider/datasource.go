@@ -28,6 +28,11 @@ func (s *RawProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.R
 		return resp, nil
 	}
 
+	if s.configUnknown {
+		resp.Diagnostics = append(resp.Diagnostics, configUnknownDiagnostic())
+		return resp, nil
+	}
+
 	rt, err := GetDataSourceType(req.TypeName)
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -59,6 +64,12 @@ func (s *RawProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.R
 		return resp, nil
 	}
 
+	s, err = s.forCluster(clusterName(config))
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+
 	rm, err := s.getRestMapper()
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -129,7 +140,7 @@ func (s *RawProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.R
 		var namespace string
 		metadata["namespace"].As(&namespace)
 		if namespace == "" {
-			namespace = "default"
+			namespace = s.defaultNamespace()
 		}
 		res, err = rcl.Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 	} else {
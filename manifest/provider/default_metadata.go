@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// mergeDefaultMetadata adds the default labels and annotations of the
+// provider to the metadata of manifest. Values set in the manifest take
+// precedence over the defaults.
+func mergeDefaultMetadata(manifest tftypes.Value, labels, annotations map[string]string) (tftypes.Value, error) {
+	if len(labels) == 0 && len(annotations) == 0 {
+		return manifest, nil
+	}
+	if manifest.IsNull() || !manifest.IsKnown() || !manifest.Type().Is(tftypes.Object{}) {
+		return manifest, nil
+	}
+	// As returns the maps backing the values, so they are copied before
+	// they are modified
+	attrs, err := copyValueMap(manifest)
+	if err != nil {
+		return manifest, err
+	}
+	metadata, ok := attrs["metadata"]
+	if !ok || metadata.IsNull() || !metadata.IsKnown() || !metadata.Type().Is(tftypes.Object{}) {
+		return manifest, nil
+	}
+	meta, err := copyValueMap(metadata)
+	if err != nil {
+		return manifest, err
+	}
+
+	for field, defaults := range map[string]map[string]string{
+		"labels":      labels,
+		"annotations": annotations,
+	} {
+		if len(defaults) == 0 {
+			continue
+		}
+		merged, err := mergeDefaultStrings(meta[field], defaults)
+		if err != nil {
+			return manifest, err
+		}
+		meta[field] = merged
+	}
+
+	attrs["metadata"] = newObjectValue(meta)
+	return newObjectValue(attrs), nil
+}
+
+// mergeDefaultStrings adds the defaults to the map or object v, which may
+// be missing or null.
+func mergeDefaultStrings(v tftypes.Value, defaults map[string]string) (tftypes.Value, error) {
+	values := map[string]tftypes.Value{}
+	if v.Type() != nil && !v.IsNull() {
+		if !v.IsKnown() {
+			// the values are not known until apply, so there is nothing
+			// to merge the defaults into yet
+			return v, nil
+		}
+		var err error
+		if values, err = copyValueMap(v); err != nil {
+			return v, err
+		}
+	}
+	for k, dv := range defaults {
+		if _, ok := values[k]; !ok {
+			values[k] = tftypes.NewValue(tftypes.String, dv)
+		}
+	}
+	if v.Type() != nil && v.Type().Is(tftypes.Map{}) && v.Type().(tftypes.Map).ElementType.Is(tftypes.String) {
+		return tftypes.NewValue(v.Type(), values), nil
+	}
+	return newObjectValue(values), nil
+}
+
+// copyValueMap returns a copy of the attributes or elements of the object
+// or map v.
+func copyValueMap(v tftypes.Value) (map[string]tftypes.Value, error) {
+	var values map[string]tftypes.Value
+	if err := v.As(&values); err != nil {
+		return nil, err
+	}
+	out := make(map[string]tftypes.Value, len(values))
+	for k, e := range values {
+		out[k] = e
+	}
+	return out, nil
+}
+
+func newObjectValue(attrs map[string]tftypes.Value) tftypes.Value {
+	types := make(map[string]tftypes.Type, len(attrs))
+	for k, v := range attrs {
+		types[k] = v.Type()
+	}
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, attrs)
+}
+
+// defaultNamespace returns the namespace of namespaced objects that are
+// looked up without one.
+func (s *RawProviderServer) defaultNamespace() string {
+	if s.namespace != "" {
+		return s.namespace
+	}
+	return "default"
+}
+
+// manifestNamespace returns the namespace given to a namespaced manifest
+// without one. Objects that already exist keep the namespace they were created
+// in, so that changing the namespace of the provider does not move them. New
+// objects use the namespace of the provider, if any.
+func (s *RawProviderServer) manifestNamespace(prior tftypes.Value) string {
+	if prior.IsNull() || !prior.IsKnown() || !prior.Type().Is(tftypes.Object{}) {
+		return s.namespace
+	}
+	var state map[string]tftypes.Value
+	if err := prior.As(&state); err != nil {
+		return s.namespace
+	}
+	path := tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("namespace")
+	v, _, err := tftypes.WalkAttributePath(state["object"], path)
+	if err != nil {
+		return s.namespace
+	}
+	ns, ok := v.(tftypes.Value)
+	var namespace string
+	if !ok || !ns.IsKnown() || ns.IsNull() || ns.As(&namespace) != nil || namespace == "" {
+		return s.namespace
+	}
+	return namespace
+}
+
+// setManifestNamespace sets the namespace in the metadata of manifest if
+// it has none.
+func setManifestNamespace(manifest tftypes.Value, namespace string) (tftypes.Value, error) {
+	if manifest.IsNull() || !manifest.IsKnown() || !manifest.Type().Is(tftypes.Object{}) {
+		return manifest, nil
+	}
+	var attrs map[string]tftypes.Value
+	if err := manifest.As(&attrs); err != nil {
+		return manifest, err
+	}
+	metadata, ok := attrs["metadata"]
+	if !ok || metadata.IsNull() || !metadata.IsKnown() || !metadata.Type().Is(tftypes.Object{}) {
+		return manifest, nil
+	}
+	var meta map[string]tftypes.Value
+	if err := metadata.As(&meta); err != nil {
+		return manifest, err
+	}
+	if ns, ok := meta["namespace"]; ok && !ns.IsNull() {
+		var s string
+		if !ns.IsKnown() || ns.As(&s) != nil || s != "" {
+			return manifest, nil
+		}
+	}
+	meta["namespace"] = tftypes.NewValue(tftypes.String, namespace)
+	attrs["metadata"] = newObjectValue(meta)
+	return newObjectValue(attrs), nil
+}
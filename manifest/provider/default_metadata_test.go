@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMergeDefaultMetadata(t *testing.T) {
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": "terraform",
+		"team":                         "platform",
+	}
+	annotations := map[string]string{"owner": "platform@example.com"}
+
+	meta := map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "test"),
+		"labels": tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"team": tftypes.String}},
+			map[string]tftypes.Value{"team": tftypes.NewValue(tftypes.String, "apps")}),
+	}
+	man := newObjectValue(map[string]tftypes.Value{
+		"apiVersion": tftypes.NewValue(tftypes.String, "v1"),
+		"kind":       tftypes.NewValue(tftypes.String, "ConfigMap"),
+		"metadata":   newObjectValue(meta),
+	})
+
+	merged, err := mergeDefaultMetadata(man, labels, annotations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]map[string]string{
+		"labels": {
+			"app.kubernetes.io/managed-by": "terraform",
+			"team":                         "apps",
+		},
+		"annotations": {"owner": "platform@example.com"},
+	}
+	for field, values := range expected {
+		v, _, err := tftypes.WalkAttributePath(merged, tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName(field))
+		if err != nil {
+			t.Fatalf("%s: %s", field, err)
+		}
+		var got map[string]tftypes.Value
+		if err := v.(tftypes.Value).As(&got); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(values) {
+			t.Errorf("%s: expected %d values, got %d", field, len(values), len(got))
+		}
+		for k, ev := range values {
+			var s string
+			if err := got[k].As(&s); err != nil || s != ev {
+				t.Errorf("%s: expected %q to be %q, got %q", field, k, ev, s)
+			}
+		}
+	}
+
+	name, _, err := tftypes.WalkAttributePath(merged, tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("name"))
+	if err != nil || !name.(tftypes.Value).Equal(tftypes.NewValue(tftypes.String, "test")) {
+		t.Errorf("expected the name to be kept, got %v", name)
+	}
+}
+
+func TestMergeDefaultMetadataUnknown(t *testing.T) {
+	labels := map[string]string{"team": "platform"}
+	man := newObjectValue(map[string]tftypes.Value{
+		"metadata": newObjectValue(map[string]tftypes.Value{
+			"labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue),
+		}),
+	})
+	merged, err := mergeDefaultMetadata(man, labels, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !merged.Equal(man) {
+		t.Errorf("expected unknown labels to be left unchanged, got %v", merged)
+	}
+
+	unknown := tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue)
+	if merged, err := mergeDefaultMetadata(unknown, labels, nil); err != nil || !merged.Equal(unknown) {
+		t.Errorf("expected an unknown manifest to be left unchanged, got %v", merged)
+	}
+}
+
+func TestSetManifestNamespace(t *testing.T) {
+	manifest := func(meta map[string]tftypes.Value) tftypes.Value {
+		return newObjectValue(map[string]tftypes.Value{
+			"apiVersion": tftypes.NewValue(tftypes.String, "v1"),
+			"kind":       tftypes.NewValue(tftypes.String, "ConfigMap"),
+			"metadata":   newObjectValue(meta),
+		})
+	}
+	name := tftypes.NewValue(tftypes.String, "test")
+	nsPath := tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("namespace")
+
+	cases := []struct {
+		Manifest tftypes.Value
+		Expected string
+	}{
+		{manifest(map[string]tftypes.Value{"name": name}), "team-a"},
+		{manifest(map[string]tftypes.Value{"name": name, "namespace": tftypes.NewValue(tftypes.String, nil)}), "team-a"},
+		{manifest(map[string]tftypes.Value{"name": name, "namespace": tftypes.NewValue(tftypes.String, "")}), "team-a"},
+		{manifest(map[string]tftypes.Value{"name": name, "namespace": tftypes.NewValue(tftypes.String, "kube-system")}), "kube-system"},
+	}
+	for i, tc := range cases {
+		v, err := setManifestNamespace(tc.Manifest, "team-a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ns, _, err := tftypes.WalkAttributePath(v, nsPath)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		var s string
+		if err := ns.(tftypes.Value).As(&s); err != nil || s != tc.Expected {
+			t.Errorf("%d: expected namespace %q, got %q", i, tc.Expected, s)
+		}
+	}
+}
+
+func TestManifestNamespace(t *testing.T) {
+	state := func(object tftypes.Value) tftypes.Value {
+		return newObjectValue(map[string]tftypes.Value{
+			"manifest": newObjectValue(map[string]tftypes.Value{"kind": tftypes.NewValue(tftypes.String, "ConfigMap")}),
+			"object":   object,
+		})
+	}
+	object := func(namespace tftypes.Value) tftypes.Value {
+		return newObjectValue(map[string]tftypes.Value{
+			"metadata": newObjectValue(map[string]tftypes.Value{
+				"name":      tftypes.NewValue(tftypes.String, "test"),
+				"namespace": namespace,
+			}),
+		})
+	}
+	stateType := state(object(tftypes.NewValue(tftypes.String, "default"))).Type()
+
+	cases := []struct {
+		Prior    tftypes.Value
+		Expected string
+	}{
+		{tftypes.NewValue(stateType, nil), "team-a"},
+		{state(object(tftypes.NewValue(tftypes.String, "default"))), "default"},
+		{state(object(tftypes.NewValue(tftypes.String, "team-b"))), "team-b"},
+		{state(object(tftypes.NewValue(tftypes.String, tftypes.UnknownValue))), "team-a"},
+		{state(object(tftypes.NewValue(tftypes.String, nil))), "team-a"},
+	}
+	s := &RawProviderServer{namespace: "team-a"}
+	for i, tc := range cases {
+		if ns := s.manifestNamespace(tc.Prior); ns != tc.Expected {
+			t.Errorf("%d: expected namespace %q, got %q", i, tc.Expected, ns)
+		}
+	}
+}
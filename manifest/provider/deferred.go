@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
+)
+
+func configUnknownDiagnostic() *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  "Provider configuration is not known yet",
+		Detail:   "The provider configuration depends on values that are not known yet, such as the credentials of a cluster created in the same run. Apply the resources it depends on first, for example with -target.",
+	}
+}
+
+// objectFromManifest builds the object of a resource that was planned while
+// the provider configuration was unknown. It performs the validation and
+// typing that planning does when the API is available.
+func (s *RawProviderServer) objectFromManifest(ctx context.Context, man tftypes.Value, prior tftypes.Value) (tftypes.Value, []*tfprotov5.Diagnostic) {
+	if diags := s.validateResourceOnline(&man); len(diags) > 0 {
+		return tftypes.Value{}, diags
+	}
+	rm, err := s.getRestMapper()
+	if err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to create K8s RESTMapper client",
+			Detail:   err.Error(),
+		}}
+	}
+	gvk, err := GVKFromTftypesObject(&man, rm)
+	if err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine GroupVersionResource for manifest",
+			Detail:   err.Error(),
+		}}
+	}
+	if isNamespaced, err := IsResourceNamespaced(gvk, rm); err == nil && isNamespaced {
+		if namespace := s.manifestNamespace(prior); namespace != "" {
+			man, err = setManifestNamespace(man, namespace)
+			if err != nil {
+				return tftypes.Value{}, []*tfprotov5.Diagnostic{{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Failed to set the namespace of the provider",
+					Detail:   err.Error(),
+				}}
+			}
+		}
+	}
+	objectType, _, err := s.TFTypeFromOpenAPI(ctx, gvk, false)
+	if err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine resource type",
+			Detail:   err.Error(),
+		}}
+	}
+	man, err = mergeDefaultMetadata(man, s.defaultLabels, s.defaultAnnotations)
+	if err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to merge default labels and annotations",
+			Detail:   err.Error(),
+		}}
+	}
+	if !objectType.Is(tftypes.Object{}) {
+		// non-structural resources have no schema so we just use the
+		// type information we can get from the config
+		objectType = man.Type()
+	}
+	morphed, d := morph.ValueToType(man, objectType, tftypes.NewAttributePath().WithAttributeName("object"))
+	if len(d) > 0 {
+		return tftypes.Value{}, append([]*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Manifest configuration incompatible with resource schema",
+			Detail:   "Detailed descriptions of errors will follow below.",
+		}}, d...)
+	}
+	obj, err := morph.DeepUnknown(objectType, morphed, tftypes.NewAttributePath().WithAttributeName("object"))
+	if err != nil {
+		return tftypes.Value{}, []*tfprotov5.Diagnostic{{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Failed to backfill manifest from OpenAPI type",
+			Detail:    fmt.Sprintf("This usually happens when the provider cannot fully process the schema retrieved from cluster. Please report this to the provider maintainers.\nError: %s", err.Error()),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("object"),
+		}}
+	}
+	return obj, nil
+}
+
+// validateManifestOffline checks a manifest against the bundled OpenAPI spec
+// while the cluster cannot be reached. Kinds that are not in the bundled
+// spec, such as custom resources, are only validated during apply. When no
+// spec is bundled for the version of the cluster, the spec of the newest
+// older version is used and violations are only reported as warnings.
+func (s *RawProviderServer) validateManifestOffline(man tftypes.Value) []*tfprotov5.Diagnostic {
+	if !man.IsFullyKnown() {
+		return nil
+	}
+	f, version, exact := s.getBundledSpec()
+	if f == nil {
+		return nil
+	}
+	gvk, ok := manifestGVK(man)
+	if !ok {
+		return nil
+	}
+	vs, err := f.ValidateByGVK(gvk, man)
+	if err != nil {
+		s.logger.Debug("[validateManifestOffline]", "no bundled OpenAPI type for", gvk.String())
+		return nil
+	}
+	return violationDiagnostics(vs, fmt.Sprintf("the OpenAPI schema of %s bundled for Kubernetes %s", gvk.String(), version), !exact)
+}
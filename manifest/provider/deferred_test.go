@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func manifestState(t *testing.T, man, obj tftypes.Value) *tfprotov5.DynamicValue {
+	rt, err := GetResourceType("kubernetes_manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := map[string]tftypes.Value{}
+	for name, ty := range rt.(tftypes.Object).AttributeTypes {
+		vals[name] = tftypes.NewValue(ty, nil)
+	}
+	vals["manifest"] = man
+	vals["object"] = obj
+	dv, err := tfprotov5.NewDynamicValue(rt, tftypes.NewValue(rt, vals))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+func plannedObject(t *testing.T, resp *tfprotov5.PlanResourceChangeResponse) tftypes.Value {
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	rt, _ := GetResourceType("kubernetes_manifest")
+	planned, err := resp.PlannedState.Unmarshal(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vals map[string]tftypes.Value
+	if err := planned.As(&vals); err != nil {
+		t.Fatal(err)
+	}
+	return vals["object"]
+}
+
+func TestPlanResourceChangeConfigUnknown(t *testing.T) {
+	s := &RawProviderServer{
+		logger:          hclog.NewNullLogger(),
+		providerEnabled: true,
+		configUnknown:   true,
+	}
+	manType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"apiVersion": tftypes.String,
+		"kind":       tftypes.String,
+		"data":       tftypes.Map{ElementType: tftypes.String},
+	}}
+	newManifest := func(v string) tftypes.Value {
+		return tftypes.NewValue(manType, map[string]tftypes.Value{
+			"apiVersion": tftypes.NewValue(tftypes.String, "v1"),
+			"kind":       tftypes.NewValue(tftypes.String, "ConfigMap"),
+			"data": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"key": tftypes.NewValue(tftypes.String, v),
+			}),
+		})
+	}
+	priorObj := newManifest("one")
+	rt, _ := GetResourceType("kubernetes_manifest")
+	nullState, err := tfprotov5.NewDynamicValue(rt, tftypes.NewValue(rt, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "kubernetes_manifest",
+			PriorState:       &nullState,
+			ProposedNewState: manifestState(t, newManifest("one"), tftypes.NewValue(tftypes.DynamicPseudoType, nil)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if obj := plannedObject(t, resp); obj.IsKnown() {
+			t.Errorf("expected an unknown object, got %v", obj)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "kubernetes_manifest",
+			PriorState:       manifestState(t, newManifest("one"), priorObj),
+			ProposedNewState: manifestState(t, newManifest("one"), priorObj),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if obj := plannedObject(t, resp); !obj.Equal(priorObj) {
+			t.Errorf("expected the prior object, got %v", obj)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "kubernetes_manifest",
+			PriorState:       manifestState(t, newManifest("one"), priorObj),
+			ProposedNewState: manifestState(t, newManifest("two"), priorObj),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if obj := plannedObject(t, resp); obj.IsKnown() {
+			t.Errorf("expected an unknown object, got %v", obj)
+		}
+	})
+
+	t.Run("destroy", func(t *testing.T) {
+		resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "kubernetes_manifest",
+			PriorState:       manifestState(t, newManifest("one"), priorObj),
+			ProposedNewState: &nullState,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Diagnostics) > 0 || resp.PlannedState != &nullState {
+			t.Errorf("expected the proposed null state to be planned")
+		}
+	})
+}
+
+func TestApplyResourceChangeConfigUnknown(t *testing.T) {
+	s := &RawProviderServer{
+		logger:          hclog.NewNullLogger(),
+		providerEnabled: true,
+		configUnknown:   true,
+	}
+	resp, err := s.ApplyResourceChange(context.Background(), &tfprotov5.ApplyResourceChangeRequest{
+		TypeName: "kubernetes_manifest",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != configUnknownDiagnostic().Summary {
+		t.Errorf("expected an unknown configuration error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestValidateManifestOffline(t *testing.T) {
+	manType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"apiVersion": tftypes.String,
+		"kind":       tftypes.String,
+		"dat":        tftypes.Map{ElementType: tftypes.String},
+	}}
+	man := tftypes.NewValue(manType, map[string]tftypes.Value{
+		"apiVersion": tftypes.NewValue(tftypes.String, "v1"),
+		"kind":       tftypes.NewValue(tftypes.String, "ConfigMap"),
+		"dat": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"key": tftypes.NewValue(tftypes.String, "value"),
+		}),
+	})
+
+	s := &RawProviderServer{
+		logger:            hclog.NewNullLogger(),
+		providerEnabled:   true,
+		configUnknown:     true,
+		kubernetesVersion: "1.23",
+	}
+	if d := s.validateManifestOffline(man); len(d) == 0 {
+		t.Errorf("expected the misspelled attribute to be reported")
+	}
+	valid := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"apiVersion": tftypes.String,
+		"kind":       tftypes.String,
+		"data":       tftypes.Map{ElementType: tftypes.String},
+	}}, map[string]tftypes.Value{
+		"apiVersion": tftypes.NewValue(tftypes.String, "v1"),
+		"kind":       tftypes.NewValue(tftypes.String, "ConfigMap"),
+		"data": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"key": tftypes.NewValue(tftypes.String, "value"),
+		}),
+	})
+	if d := s.validateManifestOffline(valid); len(d) != 0 {
+		t.Errorf("expected a valid manifest to pass, got %v", d)
+	}
+
+	// newer versions are checked against the newest bundled spec, with warnings
+	s = &RawProviderServer{
+		logger:            hclog.NewNullLogger(),
+		providerEnabled:   true,
+		configUnknown:     true,
+		kubernetesVersion: "1.99",
+	}
+	d := s.validateManifestOffline(man)
+	if len(d) == 0 {
+		t.Errorf("expected the misspelled attribute to be reported")
+	}
+	for _, diag := range d {
+		if diag.Severity != tfprotov5.DiagnosticSeverityWarning {
+			t.Errorf("expected a warning without a bundled spec for the version, got %v", diag)
+		}
+	}
+
+	// older versions have no bundled spec to fall back to
+	s = &RawProviderServer{
+		logger:            hclog.NewNullLogger(),
+		providerEnabled:   true,
+		configUnknown:     true,
+		kubernetesVersion: "1.2",
+	}
+	if d := s.validateManifestOffline(man); len(d) != 0 {
+		t.Errorf("expected no validation for a version older than the bundled specs, got %v", d)
+	}
+}
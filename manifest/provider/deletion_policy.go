@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	// deletionPolicyDelete deletes the object when the resource is destroyed.
+	deletionPolicyDelete = "delete"
+	// deletionPolicyOrphan leaves the object in the cluster when the resource
+	// is destroyed, only removing the entries of the field manager of the
+	// resource from its managed fields.
+	deletionPolicyOrphan = "orphan"
+	// deletionPolicyOrphanIfImported orphans objects that were imported or
+	// adopted, and deletes the ones that were created by the resource.
+	deletionPolicyOrphanIfImported = "orphan_if_imported"
+)
+
+var deletionPolicies = []string{
+	deletionPolicyDelete,
+	deletionPolicyOrphan,
+	deletionPolicyOrphanIfImported,
+}
+
+// deletionPolicy returns the value of the "deletion_policy" attribute, which
+// defaults to "delete".
+func deletionPolicy(v map[string]tftypes.Value) string {
+	policy := deletionPolicyDelete
+	if p, ok := v["deletion_policy"]; ok && !p.IsNull() && p.IsKnown() {
+		p.As(&policy)
+	}
+	return policy
+}
+
+// orphanOnDelete returns true when the object should be left in the cluster
+// when the resource is destroyed.
+func orphanOnDelete(policy string, preexisting bool) bool {
+	switch policy {
+	case deletionPolicyOrphan:
+		return true
+	case deletionPolicyOrphanIfImported:
+		return preexisting
+	}
+	return false
+}
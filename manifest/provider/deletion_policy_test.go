@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOrphanOnDelete(t *testing.T) {
+	cases := []struct {
+		Policy      string
+		Preexisting bool
+		Orphan      bool
+	}{
+		{deletionPolicyDelete, false, false},
+		{deletionPolicyDelete, true, false},
+		{deletionPolicyOrphan, false, true},
+		{deletionPolicyOrphan, true, true},
+		{deletionPolicyOrphanIfImported, false, false},
+		{deletionPolicyOrphanIfImported, true, true},
+	}
+	for _, tc := range cases {
+		if orphan := orphanOnDelete(tc.Policy, tc.Preexisting); orphan != tc.Orphan {
+			t.Errorf("policy %q with preexisting=%v: expected orphan=%v, got %v", tc.Policy, tc.Preexisting, tc.Orphan, orphan)
+		}
+	}
+}
+
+func TestDeletionPolicy(t *testing.T) {
+	if p := deletionPolicy(map[string]tftypes.Value{"deletion_policy": tftypes.NewValue(tftypes.String, nil)}); p != deletionPolicyDelete {
+		t.Errorf("expected the default deletion policy to be %q, got %q", deletionPolicyDelete, p)
+	}
+	if p := deletionPolicy(map[string]tftypes.Value{"deletion_policy": tftypes.NewValue(tftypes.String, "orphan")}); p != deletionPolicyOrphan {
+		t.Errorf("expected deletion policy %q, got %q", deletionPolicyOrphan, p)
+	}
+}
+
+func TestIsImportPending(t *testing.T) {
+	man := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"kind": tftypes.String}},
+		map[string]tftypes.Value{"kind": tftypes.NewValue(tftypes.String, "ConfigMap")})
+	obj := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{})
+
+	imported := manifestState(t, tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, nil), obj)
+	if !isImportPending("kubernetes_manifest", imported) {
+		t.Error("expected an import to be pending for a state without manifest")
+	}
+	applied := manifestState(t, man, obj)
+	if isImportPending("kubernetes_manifest", applied) {
+		t.Error("expected no import to be pending for a state with a manifest")
+	}
+}
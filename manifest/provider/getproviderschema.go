@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
@@ -12,9 +13,20 @@ func (s *RawProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov
 	resSchema := GetProviderResourceSchema()
 	dsSchema := GetProviderDataSourceSchema()
 
-	return &tfprotov5.GetProviderSchemaResponse{
+	resp := &tfprotov5.GetProviderSchemaResponse{
 		Provider:          cfgSchema,
 		ResourceSchemas:   resSchema,
 		DataSourceSchemas: dsSchema,
-	}, nil
+	}
+	if s.crdResourcesErr != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to load CustomResourceDefinitions",
+			Detail:   fmt.Sprintf("The resource types of the CRDs listed in %s could not be generated: %s", CRDPathsEnvVar, s.crdResourcesErr),
+		})
+	}
+	for name, r := range s.crdResources {
+		resp.ResourceSchemas[name] = r.schema
+	}
+	return resp, nil
 }
@@ -20,6 +20,9 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 	// The ID should be a combination of a Kubernetes GVK and a namespace/name type of resource identifier.
 	// Without the user supplying the GRV there is no way to fully identify the resource when making the Get API call to K8s.
 	// Presumably the Kubernetes API machinery already has a standard for expressing such a group. We should look there first.
+	if r, ok := s.crdResources[req.TypeName]; ok {
+		return s.importCRDResource(ctx, r, req)
+	}
 	resp := &tfprotov5.ImportResourceStateResponse{}
 
 	execDiag := s.canExecute()
@@ -28,7 +31,19 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 		return resp, nil
 	}
 
-	gvk, name, namespace, err := util.ParseResourceID(req.ID)
+	if s.configUnknown {
+		resp.Diagnostics = append(resp.Diagnostics, configUnknownDiagnostic())
+		return resp, nil
+	}
+
+	cluster, id := splitClusterImportID(req.ID)
+	s, err := s.forCluster(cluster)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+
+	gvk, name, namespace, err := util.ParseResourceIDInNamespace(id, s.defaultNamespace())
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 			Severity: tfprotov5.DiagnosticSeverityError,
@@ -152,6 +167,13 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 	newState["timeouts"] = tftypes.NewValue(timeoutsType, nil)
 	newState["field_manager"] = tftypes.NewValue(fmType, nil)
 	newState["computed_fields"] = tftypes.NewValue(cmpType, nil)
+	newState["adopt_existing"] = tftypes.NewValue(tftypes.Bool, nil)
+	newState["deletion_policy"] = tftypes.NewValue(tftypes.String, nil)
+	if cluster != "" {
+		newState["cluster"] = tftypes.NewValue(tftypes.String, cluster)
+	} else {
+		newState["cluster"] = tftypes.NewValue(tftypes.String, nil)
+	}
 
 	nsVal := tftypes.NewValue(rt, newState)
 
@@ -164,10 +186,7 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 		})
 		return resp, nil
 	}
-	impf := tftypes.NewValue(privateStateSchema,
-		map[string]tftypes.Value{"IsImported": tftypes.NewValue(tftypes.Bool, true)},
-	)
-	fb, err := impf.MarshalMsgPack(privateStateSchema)
+	fb, err := newPrivateStateValue(true, false)
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 			Severity: tfprotov5.DiagnosticSeverityWarning,
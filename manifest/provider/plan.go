@@ -100,6 +100,14 @@ func (s *RawProviderServer) getFieldManagerConfig(v map[string]tftypes.Value) (s
 }
 
 func isImportedFlagFromPrivate(p []byte) (f bool, d []*tfprotov5.Diagnostic) {
+	return flagFromPrivate(p, "IsImported")
+}
+
+func isAdoptedFlagFromPrivate(p []byte) (f bool, d []*tfprotov5.Diagnostic) {
+	return flagFromPrivate(p, "IsAdopted")
+}
+
+func flagFromPrivate(p []byte, name string) (f bool, d []*tfprotov5.Diagnostic) {
 	if p == nil || len(p) == 0 {
 		return
 	}
@@ -110,31 +118,62 @@ func isImportedFlagFromPrivate(p []byte) (f bool, d []*tfprotov5.Diagnostic) {
 			Summary:  "Unexpected format for private state",
 			Detail:   err.Error(),
 		})
+		return
 	}
-	err = ps["IsImported"].As(&f)
+	err = ps[name].As(&f)
 	if err != nil {
 		d = append(d, &tfprotov5.Diagnostic{
 			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "Unexpected format for import flag in private state",
+			Summary:  fmt.Sprintf("Unexpected format for %q flag in private state", name),
 			Detail:   err.Error(),
 		})
 	}
 	return
 }
 
+// isImportPending returns true when the prior state is that of an imported
+// resource that was not applied yet, which has no manifest.
+func isImportPending(typeName string, p *tfprotov5.DynamicValue) bool {
+	if p == nil {
+		return false
+	}
+	rt, err := GetResourceType(typeName)
+	if err != nil {
+		return false
+	}
+	v, err := p.Unmarshal(rt)
+	if err != nil || v.IsNull() {
+		return false
+	}
+	var vals map[string]tftypes.Value
+	if err := v.As(&vals); err != nil {
+		return false
+	}
+	return vals["manifest"].IsNull()
+}
+
 // PlanResourceChange function
 func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	if r, ok := s.crdResources[req.TypeName]; ok {
+		return s.planCRDResource(r, req)
+	}
 	resp := &tfprotov5.PlanResourceChangeResponse{}
 
 	isImported, d := isImportedFlagFromPrivate(req.PriorPrivate)
 	resp.Diagnostics = append(resp.Diagnostics, d...)
-	if !isImported {
+	// imported resources keep their flag to back the "orphan_if_imported"
+	// deletion policy, but are only spared replacement until they are applied
+	if !isImported || !isImportPending(req.TypeName, req.PriorState) {
 		resp.RequiresReplace = append(resp.RequiresReplace,
 			tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("apiVersion"),
 			tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("kind"),
 			tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("metadata").WithAttributeName("name"),
+			tftypes.NewAttributePath().WithAttributeName("cluster"),
 		)
-	} else {
+	}
+	isAdopted, d := isAdoptedFlagFromPrivate(req.PriorPrivate)
+	resp.Diagnostics = append(resp.Diagnostics, d...)
+	if isImported || isAdopted {
 		resp.PlannedPrivate = req.PriorPrivate
 	}
 
@@ -144,12 +183,6 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		return resp, nil
 	}
 
-	// test if credentials are valid - we're going to need them further down
-	resp.Diagnostics = append(resp.Diagnostics, s.checkValidCredentials(ctx)...)
-	if len(resp.Diagnostics) > 0 {
-		return resp, nil
-	}
-
 	rt, err := GetResourceType(req.TypeName)
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -239,6 +272,60 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		return resp, nil
 	}
 
+	if s.configUnknown {
+		// Without access to the API, changes to the manifest are planned with
+		// an unknown object. Built-in kinds are checked against the bundled
+		// OpenAPI spec, and all manifests are validated and typed during apply.
+		if proposedState.IsNull() {
+			resp.PlannedState = req.ProposedNewState
+			return resp, nil
+		}
+		if !proposedVal["manifest"].Equal(priorVal["manifest"]) {
+			resp.Diagnostics = append(resp.Diagnostics, s.validateManifestOffline(proposedVal["manifest"])...)
+			if len(resp.Diagnostics) > 0 {
+				return resp, nil
+			}
+			proposedVal["object"] = tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue)
+		}
+		propStateVal := tftypes.NewValue(proposedState.Type(), proposedVal)
+		plannedState, err := tfprotov5.NewDynamicValue(propStateVal.Type(), propStateVal)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to assemble proposed state during plan",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		resp.PlannedState = &plannedState
+		return resp, nil
+	}
+
+	// from here on, use the clients of the cluster selected by the resource
+	cluster := clusterName(proposedState)
+	if proposedState.IsNull() {
+		cluster = clusterName(priorState)
+	} else if !proposedVal["cluster"].IsKnown() {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid cluster",
+			Detail:    "The 'cluster' attribute must be known during planning.",
+			Attribute: tftypes.NewAttributePath().WithAttributeName("cluster"),
+		})
+		return resp, nil
+	}
+	s, err = s.forCluster(cluster)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+
+	// test if credentials are valid - we're going to need them further down
+	resp.Diagnostics = append(resp.Diagnostics, s.checkValidCredentials(ctx)...)
+	if len(resp.Diagnostics) > 0 {
+		return resp, nil
+	}
+
 	if proposedState.IsNull() {
 		// we plan to delete the resource
 		if _, ok := priorVal["object"]; ok {
@@ -264,6 +351,19 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		return resp, nil
 	}
 
+	// the defaults only end up in the planned object, so the manifest
+	// stays as configured
+	ppMan, err = mergeDefaultMetadata(ppMan, s.defaultLabels, s.defaultAnnotations)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Failed to merge default labels and annotations",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("metadata"),
+		})
+		return resp, nil
+	}
+
 	rm, err := s.getRestMapper()
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -283,6 +383,23 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		return resp, nil
 	}
 
+	// namespaced objects without a namespace are placed in the namespace of
+	// the provider. Like the default metadata, it only ends up in the object.
+	if isNamespaced, err := IsResourceNamespaced(gvk, rm); err == nil && isNamespaced {
+		if namespace := s.manifestNamespace(priorState); namespace != "" {
+			ppMan, err = setManifestNamespace(ppMan, namespace)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "Failed to set the namespace of the provider",
+					Detail:    err.Error(),
+					Attribute: tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("metadata"),
+				})
+				return resp, nil
+			}
+		}
+	}
+
 	vdiags := s.validateResourceOnline(&ppMan)
 	if len(vdiags) > 0 {
 		resp.Diagnostics = append(resp.Diagnostics, vdiags...)
@@ -301,6 +418,7 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 	if ns && !isImported {
 		resp.RequiresReplace = append(resp.RequiresReplace,
 			tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("metadata").WithAttributeName("namespace"),
+			tftypes.NewAttributePath().WithAttributeName("object").WithAttributeName("metadata").WithAttributeName("namespace"),
 		)
 	}
 
@@ -363,6 +481,13 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 	}
 	s.logger.Debug("[PlanResourceChange]", "morphed manifest", dump(morphedManifest))
 
+	// check the values of the manifest against the constraints of the schema,
+	// which the type of the object does not capture
+	if vdiags := s.validateManifestSchema(ctx, gvk, ppMan); len(vdiags) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, vdiags...)
+		return resp, nil
+	}
+
 	completePropMan, err := morph.DeepUnknown(objectType, morphedManifest, tftypes.NewAttributePath().WithAttributeName("object"))
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -36,11 +36,17 @@ func Provider() func() tfprotov5.ProviderServer {
 		logLevel = "off"
 	}
 
+	crdResources, crdResourcesErr := loadCRDResources(os.Getenv(CRDPathsEnvVar))
+
 	return func() tfprotov5.ProviderServer {
-		return &(RawProviderServer{logger: hclog.New(&hclog.LoggerOptions{
-			Level:  hclog.LevelFromString(logLevel),
-			Output: os.Stderr,
-		})})
+		return &(RawProviderServer{
+			logger: hclog.New(&hclog.LoggerOptions{
+				Level:  hclog.LevelFromString(logLevel),
+				Output: os.Stderr,
+			}),
+			crdResources:    crdResources,
+			crdResourcesErr: crdResourcesErr,
+		})
 	}
 }
 
@@ -201,12 +201,30 @@ func GetProviderResourceSchema() map[string]*tfprotov5.Schema {
 						Deprecated:  true,
 						Description: "A map of attribute paths and desired patterns to be matched. After each apply the provider will wait for all attributes listed here to reach a value that matches the desired pattern.",
 					},
+					{
+						Name:        "cluster",
+						Type:        tftypes.String,
+						Optional:    true,
+						Description: "Name of the cluster from the clusters block of the provider. Defaults to the cluster configured at the top level of the provider block.",
+					},
 					{
 						Name:        "computed_fields",
 						Type:        tftypes.List{ElementType: tftypes.String},
 						Description: "List of manifest fields whose values can be altered by the API server during 'apply'. Defaults to: [\"metadata.annotations\", \"metadata.labels\"]",
 						Optional:    true,
 					},
+					{
+						Name:        "adopt_existing",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Adopt the resource when it already exists on create, by applying the manifest over it with the Terraform field manager, instead of failing.",
+					},
+					{
+						Name:        "deletion_policy",
+						Type:        tftypes.String,
+						Optional:    true,
+						Description: "What to do with the object when the resource is destroyed: \"delete\" it, \"orphan\" it by leaving it in the cluster, or orphan it only if it was imported or adopted (\"orphan_if_imported\"). Defaults to \"delete\".",
+					},
 				},
 			},
 		},
@@ -239,6 +257,12 @@ func GetProviderDataSourceSchema() map[string]*tfprotov5.Schema {
 						Computed:    true,
 						Description: "The response from the API server.",
 					},
+					{
+						Name:        "cluster",
+						Type:        tftypes.String,
+						Optional:    true,
+						Description: "Name of the cluster from the clusters block of the provider. Defaults to the cluster configured at the top level of the provider block.",
+					},
 				},
 				BlockTypes: []*tfprotov5.SchemaNestedBlock{
 					{
@@ -109,6 +109,17 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 				DescriptionKind: 0,
 				Deprecated:      false,
 			},
+			{
+				Name:            "config_raw",
+				Type:            tftypes.String,
+				Description:     "Raw kube config file content. Merged with the files in config_path or config_paths, taking precedence over them. Can be set with KUBE_CONFIG_RAW.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       true,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
 			{
 				Name:            "config_context",
 				Type:            tftypes.String,
@@ -142,6 +153,28 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 				DescriptionKind: 0,
 				Deprecated:      false,
 			},
+			{
+				Name:            "namespace",
+				Type:            tftypes.String,
+				Description:     "Namespace of the namespaced resources that do not set one. Can be set with KUBE_NAMESPACE. Defaults to `default`, or to the namespace of the current kubeconfig context when `use_kubeconfig_namespace` is true.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "use_kubeconfig_namespace",
+				Type:            tftypes.Bool,
+				Description:     "Use the namespace of the current kubeconfig context for the namespaced resources that do not set one when `namespace` is not set.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
 			{
 				Name:            "token",
 				Type:            tftypes.String,
@@ -164,6 +197,61 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 				DescriptionKind: 0,
 				Deprecated:      false,
 			},
+			{
+				Name:            "tls_server_name",
+				Type:            tftypes.String,
+				Description:     "Server name used to verify the TLS certificate of the Kubernetes API server. Can be set with KUBE_TLS_SERVER_NAME.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "kubernetes_version",
+				Type:            tftypes.String,
+				Description:     "Kubernetes version of the cluster, such as `1.23`. Selects the OpenAPI schema bundled with the provider that `kubernetes_manifest` uses when the cluster cannot be reached.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "qps",
+				Type:            tftypes.Number,
+				Description:     "Maximum queries per second sent to the Kubernetes API server. Can be set with KUBE_QPS.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "burst",
+				Type:            tftypes.Number,
+				Description:     "Maximum burst of queries sent to the Kubernetes API server on top of qps. Can be set with KUBE_BURST.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "request_timeout",
+				Type:            tftypes.String,
+				Description:     "Timeout for a single request to the Kubernetes API server, as a duration such as \"30s\". Can be set with KUBE_REQUEST_TIMEOUT.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
 			{
 				Name:            "ignore_annotations",
 				Type:            tftypes.List{ElementType: tftypes.String},
@@ -186,8 +274,217 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 				DescriptionKind: 0,
 				Deprecated:      false,
 			},
+			{
+				Name:            "default_annotations",
+				Type:            tftypes.Map{ElementType: tftypes.String},
+				Description:     "Map of Kubernetes metadata annotations to add to all resources handled by this provider. Annotations set on a resource take precedence.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "default_labels",
+				Type:            tftypes.Map{ElementType: tftypes.String},
+				Description:     "Map of Kubernetes metadata labels to add to all resources handled by this provider. Labels set on a resource take precedence.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
 		},
 		BlockTypes: []*tfprotov5.SchemaNestedBlock{
+			{
+				TypeName: "retry",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				MaxItems: 1,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "Retry requests that fail with a transient error.",
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:            "max_attempts",
+							Type:            tftypes.Number,
+							Description:     "Maximum number of attempts for a request, including the first one. Defaults to 5. The Kubernetes client retries responses with a Retry-After header up to 10 times by itself, within each attempt, so a request can be sent more often than max_attempts.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "min_backoff",
+							Type:            tftypes.String,
+							Description:     "Time to wait before the first retry, doubled on every further retry. Defaults to \"500ms\".",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "max_backoff",
+							Type:            tftypes.String,
+							Description:     "Maximum time to wait between retries. Defaults to \"10s\".",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "status_codes",
+							Type:            tftypes.List{ElementType: tftypes.Number},
+							Description:     "HTTP status codes that are retried for idempotent requests. Defaults to 429, 500, 502, 503 and 504.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+					},
+				},
+			},
+			{
+				TypeName: "eks",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				MaxItems: 1,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "Authenticate to an EKS cluster with a token generated from the AWS credentials in the environment, without an exec plugin.",
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:            "cluster_name",
+							Type:            tftypes.String,
+							Description:     "Name of the EKS cluster.",
+							Required:        true,
+							Optional:        false,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "region",
+							Type:            tftypes.String,
+							Description:     "AWS region of the EKS cluster. Defaults to AWS_REGION or AWS_DEFAULT_REGION.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "role_arn",
+							Type:            tftypes.String,
+							Description:     "ARN of an IAM role to assume before generating the token.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "sts_endpoint",
+							Type:            tftypes.String,
+							Description:     "Custom STS endpoint URL. Defaults to the regional STS endpoint.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+					},
+				},
+			},
+			{
+				TypeName: "oidc",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				MaxItems: 1,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "Authenticate with a token obtained from an OIDC provider using the client credentials or refresh token flow, without an exec plugin.",
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:            "token_url",
+							Type:            tftypes.String,
+							Description:     "Token endpoint of the OIDC provider.",
+							Required:        true,
+							Optional:        false,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "client_id",
+							Type:            tftypes.String,
+							Description:     "OIDC client ID.",
+							Required:        true,
+							Optional:        false,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "client_secret",
+							Type:            tftypes.String,
+							Description:     "OIDC client secret.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       true,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "refresh_token",
+							Type:            tftypes.String,
+							Description:     "Refresh token to use the refresh token flow instead of the client credentials flow.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       true,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "scopes",
+							Type:            tftypes.List{ElementType: tftypes.String},
+							Description:     "Scopes to request.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "use_id_token",
+							Type:            tftypes.Bool,
+							Description:     "Send the ID token instead of the access token to the Kubernetes API server.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+					},
+				},
+			},
 			{
 				TypeName: "exec",
 				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
@@ -238,6 +535,182 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 					},
 				},
 			},
+			{
+				TypeName: "clusters",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				MaxItems: 0,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "Additional clusters that resources can select with their `cluster` attribute. Environment variables and the exec, eks and oidc blocks only apply to the cluster configured at the top level of the provider block.",
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:            "name",
+							Type:            tftypes.String,
+							Description:     "Name used to select the cluster in the `cluster` attribute of resources and data sources.",
+							Required:        true,
+							Optional:        false,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "host",
+							Type:            tftypes.String,
+							Description:     "The hostname (in form of URI) of Kubernetes master.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "username",
+							Type:            tftypes.String,
+							Description:     "The username to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "password",
+							Type:            tftypes.String,
+							Description:     "The password to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       true,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "insecure",
+							Type:            tftypes.Bool,
+							Description:     "Whether server should be accessed without verifying the TLS certificate.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "client_certificate",
+							Type:            tftypes.String,
+							Description:     "PEM-encoded client certificate for TLS authentication.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "client_key",
+							Type:            tftypes.String,
+							Description:     "PEM-encoded client certificate key for TLS authentication.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       true,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "cluster_ca_certificate",
+							Type:            tftypes.String,
+							Description:     "PEM-encoded root certificates bundle for TLS authentication.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "config_path",
+							Type:            tftypes.String,
+							Description:     "Path to the kube config file.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "config_context",
+							Type:            tftypes.String,
+							Description:     "Context to use from the kube config file.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "config_context_auth_info",
+							Type:            tftypes.String,
+							Description:     "User to use from the kube config file.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "config_context_cluster",
+							Type:            tftypes.String,
+							Description:     "Cluster to use from the kube config file.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "token",
+							Type:            tftypes.String,
+							Description:     "Token to authenticate an service account",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       true,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "proxy_url",
+							Type:            tftypes.String,
+							Description:     "URL to the proxy to be used for all API requests",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "tls_server_name",
+							Type:            tftypes.String,
+							Description:     "Server name used to verify the TLS certificate of the Kubernetes API server.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+					},
+				},
+			},
 			{
 				TypeName: "experiments",
 				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
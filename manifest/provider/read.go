@@ -15,6 +15,9 @@ import (
 
 // ReadResource function
 func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	if r, ok := s.crdResources[req.TypeName]; ok {
+		return s.readCRDResource(ctx, r, req)
+	}
 	resp := &tfprotov5.ReadResourceResponse{}
 
 	// loop private state back in - ATM it's not needed here
@@ -26,6 +29,12 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 		return resp, nil
 	}
 
+	if s.configUnknown {
+		// nothing can be read before the provider configuration is known
+		resp.NewState = req.CurrentState
+		return resp, nil
+	}
+
 	var resState map[string]tftypes.Value
 	var err error
 	rt, err := GetResourceType(req.TypeName)
@@ -65,6 +74,12 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 		return resp, nil
 	}
 
+	s, err = s.forCluster(clusterName(currentState))
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+		return resp, nil
+	}
+
 	co, hasOb := resState["object"]
 	if !hasOb || co.IsNull() {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
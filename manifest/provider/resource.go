@@ -83,25 +83,17 @@ func (ps *RawProviderServer) TFTypeFromOpenAPI(ctx context.Context, gvk schema.G
 	var tsch tftypes.Type
 	var hints map[string]string
 
-	oapi, err := ps.getOAPIv2Foundry()
+	oapi, err := ps.builtinFoundry(gvk)
 	if err != nil {
-		return nil, hints, fmt.Errorf("cannot get OpenAPI foundry: %s", err)
+		return nil, hints, err
 	}
 	// check if GVK is from a CRD
-	crdSchema, err := ps.lookUpGVKinCRDs(ctx, gvk)
+	crdFoundry, err := ps.crdFoundry(ctx, gvk)
 	if err != nil {
-		return nil, hints, fmt.Errorf("failed to look up GVK [%s] among available CRDs: %s", gvk.String(), err)
+		return nil, hints, err
 	}
-	if crdSchema != nil {
-		js, err := json.Marshal(openapi.SchemaToSpec("", crdSchema.(map[string]interface{})))
-		if err != nil {
-			return nil, hints, fmt.Errorf("CRD schema fails to marshal into JSON: %s", err)
-		}
-		oapiv3, err := openapi.NewFoundryFromSpecV3(js)
-		if err != nil {
-			return nil, hints, err
-		}
-		tsch, hints, err = oapiv3.GetTypeByGVK(gvk)
+	if crdFoundry != nil {
+		tsch, hints, err = crdFoundry.GetTypeByGVK(gvk)
 		if err != nil {
 			return nil, hints, fmt.Errorf("failed to generate tftypes for GVK [%s] from CRD schema: %s", gvk.String(), err)
 		}
@@ -142,6 +134,39 @@ func (ps *RawProviderServer) TFTypeFromOpenAPI(ctx context.Context, gvk schema.G
 	return tsch, hints, nil
 }
 
+// builtinFoundry returns the foundry of the built-in types of the cluster. Types are
+// looked up in the bundled OpenAPI spec when it matches the cluster, which saves
+// downloading the OpenAPI spec of the cluster.
+func (ps *RawProviderServer) builtinFoundry(gvk schema.GroupVersionKind) (openapi.Foundry, error) {
+	if oapi := ps.getBundledFoundry(); oapi != nil {
+		if _, _, err := oapi.GetTypeByGVK(gvk); err == nil {
+			return oapi, nil
+		}
+	}
+	oapi, err := ps.getOAPIv2Foundry()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get OpenAPI foundry: %s", err)
+	}
+	return oapi, nil
+}
+
+// crdFoundry returns a foundry built from the schema of the CRD that defines the GVK,
+// or nil if the GVK is not defined by a structural CRD.
+func (ps *RawProviderServer) crdFoundry(ctx context.Context, gvk schema.GroupVersionKind) (openapi.Foundry, error) {
+	crdSchema, err := ps.lookUpGVKinCRDs(ctx, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up GVK [%s] among available CRDs: %s", gvk.String(), err)
+	}
+	if crdSchema == nil {
+		return nil, nil
+	}
+	js, err := json.Marshal(openapi.SchemaToSpec("", crdSchema.(map[string]interface{})))
+	if err != nil {
+		return nil, fmt.Errorf("CRD schema fails to marshal into JSON: %s", err)
+	}
+	return openapi.NewFoundryFromSpecV3(js)
+}
+
 func mapRemoveNulls(in map[string]interface{}) map[string]interface{} {
 	for k, v := range in {
 		switch tv := v.(type) {
@@ -266,6 +291,13 @@ func (ps *RawProviderServer) lookUpGVKinCRDs(ctx context.Context, gvk schema.Gro
 // Terraform can store along with the "regular" resource state state.
 var privateStateSchema tftypes.Object = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
 	"IsImported": tftypes.Bool,
+	"IsAdopted":  tftypes.Bool,
+}}
+
+// legacyPrivateStateSchema is the structure of the private state payload
+// stored by versions of the provider that did not record adoption.
+var legacyPrivateStateSchema tftypes.Object = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"IsImported": tftypes.Bool,
 }}
 
 func getPrivateStateValue(p []byte) (ps map[string]tftypes.Value, err error) {
@@ -274,6 +306,27 @@ func getPrivateStateValue(p []byte) (ps map[string]tftypes.Value, err error) {
 		return
 	}
 	pv, err := tftypes.ValueFromMsgPack(p, privateStateSchema)
+	if err != nil {
+		pv, err = tftypes.ValueFromMsgPack(p, legacyPrivateStateSchema)
+		if err != nil {
+			return
+		}
+	}
 	err = pv.As(&ps)
+	if err != nil {
+		return
+	}
+	if _, ok := ps["IsAdopted"]; !ok {
+		ps["IsAdopted"] = tftypes.NewValue(tftypes.Bool, false)
+	}
 	return
 }
+
+// newPrivateStateValue encodes the private state payload of a resource.
+func newPrivateStateValue(isImported, isAdopted bool) ([]byte, error) {
+	v := tftypes.NewValue(privateStateSchema, map[string]tftypes.Value{
+		"IsImported": tftypes.NewValue(tftypes.Bool, isImported),
+		"IsAdopted":  tftypes.NewValue(tftypes.Bool, isAdopted),
+	})
+	return v.MarshalMsgPack(privateStateSchema)
+}
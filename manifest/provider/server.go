@@ -33,8 +33,32 @@ type RawProviderServer struct {
 	restClient      rest.Interface
 	OAPIFoundry     openapi.Foundry
 
+	// bundledFoundry types built-in resources from the OpenAPI spec bundled
+	// for the version of the cluster, see getBundledSpec.
+	bundledFoundry        openapi.Foundry
+	bundledVersion        string
+	bundledExact          bool
+	bundledFoundryChecked bool
+	kubernetesVersion     string
+
+	// defaultLabels and defaultAnnotations are merged into the metadata of
+	// every manifest, see mergeDefaultMetadata.
+	defaultLabels      map[string]string
+	defaultAnnotations map[string]string
+	// namespace is used for namespaced objects that do not set one. It is
+	// empty when neither the provider nor the kubeconfig context set one.
+	namespace string
+
 	providerEnabled bool
 	hostTFVersion   string
+	configUnknown   bool
+
+	clusters *clusterServers
+
+	// crdResources are the resource types generated from the CRDs listed in
+	// the environment variable named by CRDPathsEnvVar, by type name.
+	crdResources    map[string]*crdResource
+	crdResourcesErr error
 }
 
 func dump(v interface{}) hclog.Format {
@@ -11,6 +11,9 @@ import (
 
 // UpgradeResourceState isn't really useful in this provider, but we have to loop the state back through to keep Terraform happy.
 func (s *RawProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	if r, ok := s.crdResources[req.TypeName]; ok {
+		return s.upgradeCRDResourceState(r, req)
+	}
 	resp := &tfprotov5.UpgradeResourceStateResponse{}
 	resp.Diagnostics = []*tfprotov5.Diagnostic{}
 
@@ -28,9 +31,20 @@ func (s *RawProviderServer) UpgradeResourceState(ctx context.Context, req *tfpro
 	}
 
 	// test if credentials are valid - we're going to need them further down
-	// if no credentials found, just loop the current state back in
+	// if no credentials found, or the provider configuration is not known yet,
+	// just loop the current state back in
 	// we do this to work around https://github.com/hashicorp/terraform/issues/30460
-	cd := s.checkValidCredentials(ctx)
+	var cd []*tfprotov5.Diagnostic
+	if s.configUnknown {
+		cd = append(cd, configUnknownDiagnostic())
+	} else {
+		s, err = s.forCluster(clusterName(rv))
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, clusterDiagnostic(err))
+			return resp, nil
+		}
+		cd = s.checkValidCredentials(ctx)
+	}
 	if len(cd) > 0 {
 		us, err := tfprotov5.NewDynamicValue(rt, rv)
 		if err != nil {
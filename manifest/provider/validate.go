@@ -8,10 +8,15 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // ValidateResourceTypeConfig function
 func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	if r, ok := s.crdResources[req.TypeName]; ok {
+		return s.validateCRDResource(r, req)
+	}
 	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
 	requiredKeys := []string{"apiVersion", "kind", "metadata"}
 	forbiddenKeys := []string{"status"}
@@ -103,6 +108,12 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 		}
 	}
 
+	// the cluster is not known during validation, so built-in kinds
+	// are checked against the OpenAPI spec bundled with the provider
+	if len(resp.Diagnostics) == 0 {
+		resp.Diagnostics = append(resp.Diagnostics, validateManifestBundled(manifest)...)
+	}
+
 	// validate timeouts block
 	timeouts := s.getTimeouts(configVal)
 	path := tftypes.NewAttributePath().WithAttributeName("timeouts")
@@ -118,6 +129,27 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 		}
 	}
 
+	// validate deletion policy
+	if dp, ok := configVal["deletion_policy"]; ok && !dp.IsNull() && dp.IsKnown() {
+		var policy string
+		dp.As(&policy)
+		valid := false
+		for _, p := range deletionPolicies {
+			if policy == p {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid deletion policy",
+				Detail:    fmt.Sprintf(`The deletion policy must be one of "%s", got %q.`, strings.Join(deletionPolicies, "\", \""), policy),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("deletion_policy"),
+			})
+		}
+	}
+
 	// validate wait block
 	if wait, ok := configVal["wait"]; ok && !wait.IsNull() {
 		var waitBlock []tftypes.Value
@@ -236,3 +268,80 @@ func (s *RawProviderServer) validateResourceOnline(manifest *tftypes.Value) (dia
 	}
 	return
 }
+
+// validateManifestBundled checks a manifest of a built-in kind against the newest
+// OpenAPI spec bundled with the provider that contains the kind. The version of the
+// cluster is not known yet, and may have added, relaxed or removed constraints of
+// the kind, so violations are reported as warnings. They become errors during plan,
+// when the manifest is validated against the schema served by the cluster.
+func validateManifestBundled(manifest tftypes.Value) []*tfprotov5.Diagnostic {
+	gvk, ok := manifestGVK(manifest)
+	if !ok {
+		return nil
+	}
+	f, version := getBundledFoundryByGVK(gvk)
+	if f == nil {
+		return nil
+	}
+	vs, err := f.ValidateByGVK(gvk, manifest)
+	if err != nil {
+		return nil
+	}
+	return violationDiagnostics(vs, fmt.Sprintf("the OpenAPI schema of %s bundled for Kubernetes %s", gvk.String(), version), true)
+}
+
+// validateManifestSchema checks a manifest against the schema of its resource,
+// and evaluates the validation rules of the CRD for custom resources.
+func (s *RawProviderServer) validateManifestSchema(ctx context.Context, gvk schema.GroupVersionKind, manifest tftypes.Value) []*tfprotov5.Diagnostic {
+	f, err := s.crdFoundry(ctx, gvk)
+	if err == nil && f == nil {
+		f, err = s.builtinFoundry(gvk)
+	}
+	if err != nil {
+		s.logger.Debug("[validateManifestSchema]", "no OpenAPI schema for", gvk.String(), "error", err)
+		return nil
+	}
+	vs, err := f.ValidateByGVK(gvk, manifest)
+	if err != nil {
+		s.logger.Debug("[validateManifestSchema]", "failed to validate", gvk.String(), "error", err)
+		return nil
+	}
+	return violationDiagnostics(vs, fmt.Sprintf("the schema of %s", gvk.String()), false)
+}
+
+// manifestGVK returns the GVK of a manifest when its apiVersion and kind are known.
+func manifestGVK(manifest tftypes.Value) (schema.GroupVersionKind, bool) {
+	var obj map[string]tftypes.Value
+	var apiVersion, kind string
+	if manifest.As(&obj) != nil || obj["apiVersion"].As(&apiVersion) != nil || obj["kind"].As(&kind) != nil {
+		return schema.GroupVersionKind{}, false
+	}
+	return schema.FromAPIVersionAndKind(apiVersion, kind), true
+}
+
+// violationDiagnostics turns schema violations of a manifest into diagnostics
+// attached to the offending attributes of the "manifest" attribute. Violations
+// of a schema that may not match the version of the cluster are warnings.
+func violationDiagnostics(vs []openapi.Violation, source string, asWarnings bool) (diags []*tfprotov5.Diagnostic) {
+	for _, v := range vs {
+		steps := []tftypes.AttributePathStep{tftypes.AttributeName("manifest")}
+		steps = append(steps, v.Path.Steps()...)
+		d := &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid manifest attribute",
+			Detail:    fmt.Sprintf("%s The manifest was validated against %s.", v.Detail, source),
+			Attribute: tftypes.NewAttributePathWithSteps(steps),
+		}
+		if asWarnings {
+			d.Severity = tfprotov5.DiagnosticSeverityWarning
+			d.Summary = "Possibly invalid manifest attribute"
+			d.Detail = fmt.Sprintf("%s The manifest was validated against %s, which may not match the Kubernetes version of the cluster.", v.Detail, source)
+			if v.UnknownAttribute {
+				d.Summary = "Unknown manifest attribute"
+				d.Detail = fmt.Sprintf("%s The manifest was validated against %s. The attribute may have been added by a newer Kubernetes version, otherwise it is rejected by the API server.", v.Detail, source)
+			}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateManifestBundled(t *testing.T) {
+	newManifest := func(kind string, data map[string]tftypes.Value) tftypes.Value {
+		dataType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}
+		for k, v := range data {
+			dataType.AttributeTypes[k] = v.Type()
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"apiVersion": tftypes.String,
+			"kind":       tftypes.String,
+			"spec":       dataType,
+		}}, map[string]tftypes.Value{
+			"apiVersion": tftypes.NewValue(tftypes.String, "v1"),
+			"kind":       tftypes.NewValue(tftypes.String, kind),
+			"spec":       tftypes.NewValue(dataType, data),
+		})
+	}
+
+	// a Service port requires the port number, and unknown attributes may
+	// have been added by a Kubernetes version newer than the bundled one.
+	// The version of the cluster is not known, so both are warnings.
+	ports := tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}}}
+	man := newManifest("Service", map[string]tftypes.Value{
+		"ports": tftypes.NewValue(ports, []tftypes.Value{
+			tftypes.NewValue(ports.ElementTypes[0], map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "http")}),
+		}),
+		"newAttribute": tftypes.NewValue(tftypes.String, "value"),
+	})
+	diags := validateManifestBundled(man)
+	if len(diags) != 2 {
+		t.Fatalf("expected two diagnostics, got %v", diags)
+	}
+	for _, d := range diags {
+		switch d.Attribute.String() {
+		case `AttributeName("manifest").AttributeName("spec").AttributeName("newAttribute")`:
+			if d.Severity != tfprotov5.DiagnosticSeverityWarning {
+				t.Errorf("expected a warning for the unknown attribute, got %v", d)
+			}
+		case `AttributeName("manifest").AttributeName("spec").AttributeName("ports").ElementKeyInt(0).AttributeName("port")`:
+			if d.Severity != tfprotov5.DiagnosticSeverityWarning {
+				t.Errorf("expected a warning for the missing port, got %v", d)
+			}
+		default:
+			t.Errorf("unexpected diagnostic %v", d)
+		}
+	}
+
+	// custom resources are not in the bundled spec
+	if diags := validateManifestBundled(newManifest("Widget", nil)); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for an unknown kind, got %v", diags)
+	}
+}
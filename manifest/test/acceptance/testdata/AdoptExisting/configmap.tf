@@ -0,0 +1,19 @@
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+    }
+    data = {
+      foo = "baz"
+    }
+  }
+
+  adopt_existing = true
+
+  field_manager {
+    force_conflicts = true
+  }
+}
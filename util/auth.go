@@ -0,0 +1,104 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"k8s.io/client-go/transport"
+)
+
+// OIDCAuthConfig holds the settings of the oidc block of the provider. When
+// RefreshToken is set the refresh token grant is used, otherwise the client
+// credentials grant.
+type OIDCAuthConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	Scopes       []string
+	// UseIDToken sends the id_token returned by the token endpoint to the
+	// API server instead of the access_token.
+	UseIDToken bool
+}
+
+func (c OIDCAuthConfig) tokenSource(ctx context.Context) oauth2.TokenSource {
+	var ts oauth2.TokenSource
+	if c.RefreshToken != "" {
+		cfg := &oauth2.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: c.TokenURL},
+			Scopes:       c.Scopes,
+		}
+		ts = cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: c.RefreshToken})
+	} else {
+		cfg := &clientcredentials.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			TokenURL:     c.TokenURL,
+			Scopes:       c.Scopes,
+		}
+		ts = cfg.TokenSource(ctx)
+	}
+	if c.UseIDToken {
+		ts = idTokenSource{ts}
+	}
+	return oauth2.ReuseTokenSource(nil, ts)
+}
+
+// idTokenSource replaces the access token with the id_token of the response.
+type idTokenSource struct {
+	ts oauth2.TokenSource
+}
+
+func (s idTokenSource) Token() (*oauth2.Token, error) {
+	t, err := s.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	idToken, ok := t.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return nil, errors.New("the OIDC token endpoint did not return an id_token")
+	}
+	return &oauth2.Token{
+		AccessToken: idToken,
+		TokenType:   "Bearer",
+		Expiry:      t.Expiry,
+	}, nil
+}
+
+// tokenWrapper returns a transport wrapper that authenticates every request
+// with a bearer token from ts. The token takes precedence over any
+// credentials found in kubeconfig files.
+func tokenWrapper(ts oauth2.TokenSource) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &oauth2.Transport{Source: ts, Base: rt}
+	}
+}
+
+func (r *ResolvedConfig) authWrapper(c ProviderConfig) (transport.WrapperFunc, error) {
+	switch {
+	case c.EKS != nil:
+		eks := *c.EKS
+		if eks.Region == "" {
+			eks.Region = os.Getenv("AWS_REGION")
+		}
+		if eks.Region == "" {
+			eks.Region = os.Getenv("AWS_DEFAULT_REGION")
+		}
+		if eks.Region == "" {
+			return nil, attributeError(errors.New("'eks.region' must be set when neither AWS_REGION nor AWS_DEFAULT_REGION are"), "eks", 0, "region")
+		}
+		r.Report.add("eks", eks.ClusterName+" in "+eks.Region, ConfigSourceAttribute, "", false)
+		ts := &eksTokenSource{config: eks}
+		return tokenWrapper(oauth2.ReuseTokenSource(nil, ts)), nil
+	case c.OIDC != nil:
+		r.Report.add("oidc", c.OIDC.TokenURL, ConfigSourceAttribute, "", false)
+		return tokenWrapper(c.OIDC.tokenSource(context.Background())), nil
+	}
+	return nil, nil
+}
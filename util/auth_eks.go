@@ -0,0 +1,102 @@
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"golang.org/x/oauth2"
+)
+
+const (
+	eksTokenPrefix   = "k8s-aws-v1."
+	eksClusterHeader = "x-k8s-aws-id"
+	// EKS accepts tokens for 15 minutes after they were signed.
+	eksTokenLifetime = 14 * time.Minute
+	eksSessionName   = "terraform-provider-kubernetes"
+)
+
+// EKSAuthConfig holds the settings of the eks block of the provider. The
+// token is derived from the AWS credentials of the default credential chain
+// of the AWS SDK, like the `aws eks get-token` command does.
+type EKSAuthConfig struct {
+	ClusterName string
+	Region      string
+	RoleARN     string
+	// STSEndpoint overrides the regional STS endpoint.
+	STSEndpoint string
+}
+
+type eksTokenSource struct {
+	config EKSAuthConfig
+
+	// the AWS configuration is loaded for the first token
+	once    sync.Once
+	presign *sts.PresignClient
+	err     error
+}
+
+// Token returns a bearer token for the EKS cluster. It is a presigned STS
+// GetCallerIdentity URL which the cluster uses to establish the caller's identity.
+func (s *eksTokenSource) Token() (*oauth2.Token, error) {
+	ctx := context.Background()
+	presign, err := s.presignClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	req, err := presign.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}, func(o *sts.PresignOptions) {
+		o.ClientOptions = append(o.ClientOptions, sts.WithAPIOptions(
+			smithyhttp.SetHeaderValue(eksClusterHeader, s.config.ClusterName),
+			smithyhttp.SetHeaderValue("X-Amz-Expires", "60"),
+		))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the EKS token: %s", err)
+	}
+	return &oauth2.Token{
+		AccessToken: eksTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(req.URL)),
+		TokenType:   "Bearer",
+		Expiry:      now.Add(eksTokenLifetime),
+	}, nil
+}
+
+// presignClient returns the STS client that signs the tokens. Its credentials
+// come from the default credential chain of the AWS SDK, which covers the
+// environment, the shared config and credentials files with their profiles,
+// web identity tokens, and the container and instance metadata endpoints.
+// With a role ARN, they are exchanged for credentials of the role.
+func (s *eksTokenSource) presignClient(ctx context.Context) (*sts.PresignClient, error) {
+	s.once.Do(func() {
+		cfg, err := config.LoadDefaultConfig(ctx,
+			config.WithRegion(s.config.Region),
+			config.WithHTTPClient(awshttp.NewBuildableClient().WithTimeout(30*time.Second)),
+		)
+		if err != nil {
+			s.err = fmt.Errorf("failed to load the AWS configuration: %s", err)
+			return
+		}
+		endpoint := func(o *sts.Options) {
+			if s.config.STSEndpoint != "" {
+				o.BaseEndpoint = aws.String(s.config.STSEndpoint)
+			}
+		}
+		client := sts.NewFromConfig(cfg, endpoint)
+		if s.config.RoleARN != "" {
+			cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(client, s.config.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+				o.RoleSessionName = eksSessionName
+			}))
+			client = sts.NewFromConfig(cfg, endpoint)
+		}
+		s.presign = sts.NewPresignClient(client)
+	})
+	return s.presign, s.err
+}
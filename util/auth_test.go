@@ -0,0 +1,215 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+// fakeAPIServer records the Authorization header of every request it receives.
+func fakeAPIServer(t *testing.T) (*httptest.Server, *[]string) {
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"major":"1","minor":"25"}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &auth
+}
+
+func doRequest(t *testing.T, c ProviderConfig) {
+	resolved, err := ResolveConfig(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := resolved.ClientConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(cfg.Host + "/version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+// clearAWSEnv isolates the test from the AWS configuration of the machine.
+func clearAWSEnv(t *testing.T) {
+	dir := t.TempDir()
+	for _, e := range []string{
+		"AWS_PROFILE", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN",
+		"AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN", "AWS_ROLE_SESSION_NAME",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI",
+		"AWS_CONTAINER_AUTHORIZATION_TOKEN", "AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE",
+		"AWS_EC2_METADATA_SERVICE_ENDPOINT", "AWS_CA_BUNDLE",
+	} {
+		t.Setenv(e, "")
+	}
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
+
+func TestEKSAuth(t *testing.T) {
+	clearConfigEnv(t)
+	clearAWSEnv(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if r.Form.Get("Action") != "AssumeRole" || r.Form.Get("RoleArn") != "arn:aws:iam::123456789012:role/deployer" {
+			t.Errorf("unexpected STS request: %v", r.Form)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIDEXAMPLE/") {
+			t.Errorf("STS request is not signed: %s", r.Header.Get("Authorization"))
+		}
+		fmt.Fprint(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASIAASSUMED</AccessKeyId>
+      <SecretAccessKey>assumed-secret</SecretAccessKey>
+      <SessionToken>assumed-session-token</SessionToken>
+      <Expiration>2030-01-01T00:00:00Z</Expiration>
+    </Credentials>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`)
+	}))
+	defer sts.Close()
+	api, auth := fakeAPIServer(t)
+
+	doRequest(t, ProviderConfig{
+		Host: api.URL,
+		EKS: &EKSAuthConfig{
+			ClusterName: "my-cluster",
+			Region:      "eu-west-1",
+			RoleARN:     "arn:aws:iam::123456789012:role/deployer",
+			STSEndpoint: sts.URL,
+		},
+	})
+
+	if len(*auth) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(*auth))
+	}
+	token := strings.TrimPrefix((*auth)[0], "Bearer ")
+	if !strings.HasPrefix(token, "k8s-aws-v1.") {
+		t.Fatalf("unexpected token %q", token)
+	}
+	presigned, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, "k8s-aws-v1."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(string(presigned))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	expected := map[string]string{
+		"Action":               "GetCallerIdentity",
+		"X-Amz-SignedHeaders":  "host;x-k8s-aws-id",
+		"X-Amz-Security-Token": "assumed-session-token",
+	}
+	for k, v := range expected {
+		if q.Get(k) != v {
+			t.Errorf("expected %s=%q in presigned URL, got %q", k, v, q.Get(k))
+		}
+	}
+	if !strings.HasPrefix(q.Get("X-Amz-Credential"), "ASIAASSUMED/") || !strings.HasSuffix(q.Get("X-Amz-Credential"), "/eu-west-1/sts/aws4_request") {
+		t.Errorf("unexpected credential scope %q", q.Get("X-Amz-Credential"))
+	}
+}
+
+func TestEKSAuthRequiresRegion(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	_, err := ResolveConfig(ProviderConfig{Host: "https://example.com", EKS: &EKSAuthConfig{ClusterName: "my-cluster"}})
+	if err == nil {
+		t.Fatal("expected an error when no region is set")
+	}
+}
+
+func TestOIDCAuth(t *testing.T) {
+	cases := map[string]struct {
+		config    OIDCAuthConfig
+		grantType string
+		header    string
+	}{
+		"client credentials": {
+			config:    OIDCAuthConfig{ClientID: "terraform", ClientSecret: "secret", Scopes: []string{"kubernetes"}},
+			grantType: "client_credentials",
+			header:    "Bearer access-token",
+		},
+		"refresh token with id token": {
+			config:    OIDCAuthConfig{ClientID: "terraform", RefreshToken: "refresh-token", UseIDToken: true},
+			grantType: "refresh_token",
+			header:    "Bearer id-token",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			var tokenRequests int
+			idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tokenRequests++
+				if err := r.ParseForm(); err != nil {
+					t.Fatal(err)
+				}
+				if r.Form.Get("grant_type") != tc.grantType {
+					t.Errorf("expected grant_type %q, got %q", tc.grantType, r.Form.Get("grant_type"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"access_token": "access-token",
+					"id_token":     "id-token",
+					"token_type":   "bearer",
+					"expires_in":   3600,
+				})
+			}))
+			defer idp.Close()
+			api, auth := fakeAPIServer(t)
+
+			tc.config.TokenURL = idp.URL
+			c := ProviderConfig{Host: api.URL, OIDC: &tc.config}
+			doRequest(t, c)
+
+			if len(*auth) != 1 || (*auth)[0] != tc.header {
+				t.Errorf("expected Authorization %q, got %v", tc.header, *auth)
+			}
+			if tokenRequests != 1 {
+				t.Errorf("expected 1 token request, got %d", tokenRequests)
+			}
+		})
+	}
+}
+
+func TestAuthMethodsAreExclusive(t *testing.T) {
+	clearConfigEnv(t)
+
+	_, err := ResolveConfig(ProviderConfig{
+		Host: "https://example.com",
+		EKS:  &EKSAuthConfig{ClusterName: "my-cluster", Region: "us-east-1"},
+		OIDC: &OIDCAuthConfig{TokenURL: "https://idp.example.com/token", ClientID: "terraform"},
+	})
+	if err == nil {
+		t.Fatal("expected an error when several authentication methods are set")
+	}
+}
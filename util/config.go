@@ -0,0 +1,645 @@
+package util
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/transport"
+)
+
+// ConfigSource describes where a resolved provider configuration value came from.
+type ConfigSource string
+
+const (
+	ConfigSourceAttribute  ConfigSource = "attribute"
+	ConfigSourceEnv        ConfigSource = "env"
+	ConfigSourceKubeconfig ConfigSource = "kubeconfig"
+)
+
+// ProviderConfig holds the client settings of the provider block as they
+// were set by the user. Empty values are treated as not set.
+type ProviderConfig struct {
+	Host                  string
+	Username              string
+	Password              string
+	Insecure              *bool
+	ClientCertificate     string
+	ClientKey             string
+	ClusterCACertificate  string
+	ConfigPath            string
+	ConfigPaths           []string
+	ConfigRaw             string
+	ConfigContext         string
+	ConfigContextAuthInfo string
+	ConfigContextCluster  string
+	Namespace             string
+	// UseKubeconfigNamespace falls back to the namespace of the current
+	// kubeconfig context when Namespace and KUBE_NAMESPACE are not set.
+	UseKubeconfigNamespace bool
+	Token                  string
+	ProxyURL               string
+	TLSServerName          string
+	Exec                   *clientcmdapi.ExecConfig
+	EKS                    *EKSAuthConfig
+	OIDC                   *OIDCAuthConfig
+	QPS                    float64
+	Burst                  int
+	RequestTimeout         string
+	Retry                  *RetryConfig
+	// IgnoreEnv disables the fallback to environment variables for the
+	// values that are not set.
+	IgnoreEnv bool
+}
+
+// RetryConfig holds the settings of the retry block of the provider.
+// Zero values are replaced with defaults.
+type RetryConfig struct {
+	MaxAttempts int
+	MinBackoff  string
+	MaxBackoff  string
+	StatusCodes []int
+}
+
+// ResolvedValue is a single entry of the resolved configuration report.
+type ResolvedValue struct {
+	Name   string
+	Value  string
+	Source ConfigSource
+	// EnvVar is the environment variable the value was read from, if any.
+	EnvVar string
+}
+
+// ConfigReport records the source of every client setting in use.
+type ConfigReport []ResolvedValue
+
+// Get returns the resolved value for the named setting.
+func (r ConfigReport) Get(name string) (ResolvedValue, bool) {
+	for _, v := range r {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return ResolvedValue{}, false
+}
+
+func (r ConfigReport) String() string {
+	lines := make([]string, len(r))
+	for i, v := range r {
+		source := string(v.Source)
+		if v.EnvVar != "" {
+			source += " " + v.EnvVar
+		}
+		lines[i] = fmt.Sprintf("%s = %q (%s)", v.Name, v.Value, source)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *ConfigReport) add(name, value string, source ConfigSource, envVar string, sensitive bool) {
+	if sensitive {
+		value = "(sensitive)"
+	}
+	*r = append(*r, ResolvedValue{Name: name, Value: value, Source: source, EnvVar: envVar})
+}
+
+// ConfigErrors collects all invalid values found in a provider configuration.
+type ConfigErrors []error
+
+func (e ConfigErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AttributeError is an entry of ConfigErrors caused by the value of an
+// attribute of the provider block, or of the environment variable read in
+// its place.
+type AttributeError struct {
+	// Path leads to the attribute. Its elements are attribute names and,
+	// within blocks, list indices, as in "retry", 0, "min_backoff".
+	Path []interface{}
+	Err  error
+}
+
+func (e *AttributeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AttributeError) Unwrap() error {
+	return e.Err
+}
+
+func attributeError(err error, path ...interface{}) error {
+	return &AttributeError{Path: path, Err: err}
+}
+
+// ResolvedConfig is the outcome of merging the provider block with the environment.
+type ResolvedConfig struct {
+	Loader    *clientcmd.ClientConfigLoadingRules
+	Overrides *clientcmd.ConfigOverrides
+	// Raw is the inline kubeconfig content, if any. It takes precedence over
+	// the kubeconfig files selected by Loader.
+	Raw            *clientcmdapi.Config
+	QPS            float32
+	Burst          int
+	RequestTimeout time.Duration
+	Retry          *RetryPolicy
+	Report         ConfigReport
+
+	auth transport.WrapperFunc
+	// useKubeconfigNamespace is set from ProviderConfig.UseKubeconfigNamespace.
+	useKubeconfigNamespace bool
+	// insecure is set when the insecure attribute or KUBE_INSECURE is set.
+	// The overrides cannot turn off insecure-skip-tls-verify of a kubeconfig.
+	insecure *bool
+}
+
+// ResolveConfig merges the provider block with the environment. Attributes
+// always take precedence over environment variables, and both take precedence
+// over values found in kubeconfig files. Context overrides are only applied
+// when at least one kubeconfig file or inline kubeconfig is in use.
+func ResolveConfig(c ProviderConfig) (*ResolvedConfig, error) {
+	r := &ResolvedConfig{
+		Loader:    &clientcmd.ClientConfigLoadingRules{},
+		Overrides: &clientcmd.ConfigOverrides{},
+	}
+	var errs ConfigErrors
+
+	configPaths, source, envVar := c.resolveConfigPaths()
+	if len(configPaths) > 0 {
+		attr := "config_paths"
+		if c.ConfigPath != "" || envVar == "KUBE_CONFIG_PATH" {
+			attr = "config_path"
+		}
+		expandedPaths := make([]string, 0, len(configPaths))
+		for _, p := range configPaths {
+			path, err := homedir.Expand(p)
+			if err != nil {
+				errs = append(errs, attributeError(fmt.Errorf("invalid kubeconfig path %q: %s", p, err), attr))
+				continue
+			}
+			expandedPaths = append(expandedPaths, path)
+		}
+		if len(expandedPaths) == 1 {
+			r.Loader.ExplicitPath = expandedPaths[0]
+		} else {
+			r.Loader.Precedence = expandedPaths
+		}
+		r.Report.add("config_paths", strings.Join(expandedPaths, string(os.PathListSeparator)), source, envVar, false)
+	}
+
+	if v, source, envVar := c.resolveString(c.ConfigRaw, "KUBE_CONFIG_RAW"); v != "" {
+		raw, err := clientcmd.Load([]byte(v))
+		if err != nil {
+			errs = append(errs, attributeError(fmt.Errorf("'config_raw' is not a valid kubeconfig: %s", err), "config_raw"))
+		} else {
+			r.Raw = raw
+		}
+		r.Report.add("config_raw", v, source, envVar, true)
+	}
+
+	if r.hasKubeconfig() {
+		if v, source, envVar := c.resolveString(c.ConfigContext, "KUBE_CTX"); v != "" {
+			r.Overrides.CurrentContext = v
+			r.Report.add("config_context", v, source, envVar, false)
+		}
+		if v, source, envVar := c.resolveString(c.ConfigContextAuthInfo, "KUBE_CTX_AUTH_INFO"); v != "" {
+			r.Overrides.Context.AuthInfo = v
+			r.Report.add("config_context_auth_info", v, source, envVar, false)
+		}
+		if v, source, envVar := c.resolveString(c.ConfigContextCluster, "KUBE_CTX_CLUSTER"); v != "" {
+			r.Overrides.Context.Cluster = v
+			r.Report.add("config_context_cluster", v, source, envVar, false)
+		}
+	}
+
+	r.useKubeconfigNamespace = c.UseKubeconfigNamespace
+	if v, source, envVar := c.resolveString(c.Namespace, "KUBE_NAMESPACE"); v != "" {
+		r.Overrides.Context.Namespace = v
+		r.Report.add("namespace", v, source, envVar, false)
+	}
+
+	if c.Insecure != nil {
+		r.Overrides.ClusterInfo.InsecureSkipTLSVerify = *c.Insecure
+		r.insecure = c.Insecure
+		r.Report.add("insecure", strconv.FormatBool(*c.Insecure), ConfigSourceAttribute, "", false)
+	} else if v := c.getenv("KUBE_INSECURE"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, attributeError(fmt.Errorf("environment variable KUBE_INSECURE contains invalid value: %s", err), "insecure"))
+		}
+		r.Overrides.ClusterInfo.InsecureSkipTLSVerify = insecure
+		r.insecure = &insecure
+		r.Report.add("insecure", strconv.FormatBool(insecure), ConfigSourceEnv, "KUBE_INSECURE", false)
+	}
+
+	if v, source, envVar := c.resolveString(c.ClusterCACertificate, "KUBE_CLUSTER_CA_CERT_DATA"); v != "" {
+		if pemBlockType(v) != "CERTIFICATE" {
+			errs = append(errs, attributeError(errors.New("'cluster_ca_certificate' is not a valid PEM encoded certificate"), "cluster_ca_certificate"))
+		}
+		r.Overrides.ClusterInfo.CertificateAuthorityData = []byte(v)
+		r.Report.add("cluster_ca_certificate", v, source, envVar, true)
+	}
+	if v, source, envVar := c.resolveString(c.ClientCertificate, "KUBE_CLIENT_CERT_DATA"); v != "" {
+		if pemBlockType(v) != "CERTIFICATE" {
+			errs = append(errs, attributeError(errors.New("'client_certificate' is not a valid PEM encoded certificate"), "client_certificate"))
+		}
+		r.Overrides.AuthInfo.ClientCertificateData = []byte(v)
+		r.Report.add("client_certificate", v, source, envVar, true)
+	}
+	if v, source, envVar := c.resolveString(c.ClientKey, "KUBE_CLIENT_KEY_DATA"); v != "" {
+		if !strings.Contains(pemBlockType(v), "PRIVATE KEY") {
+			errs = append(errs, attributeError(errors.New("'client_key' is not a valid PEM encoded private key"), "client_key"))
+		}
+		r.Overrides.AuthInfo.ClientKeyData = []byte(v)
+		r.Report.add("client_key", v, source, envVar, true)
+	}
+
+	if v, source, envVar := c.resolveString(c.Host, "KUBE_HOST"); v != "" {
+		// Server has to be the complete address of the kubernetes cluster (scheme://hostname:port), not just the hostname,
+		// because `overrides` are processed too late to be taken into account by `defaultServerUrlFor()`.
+		// This basically replicates what defaultServerUrlFor() does with config but for overrides,
+		// see https://github.com/kubernetes/client-go/blob/v12.0.0/rest/url_utils.go#L85-L87
+		hasCA := len(r.Overrides.ClusterInfo.CertificateAuthorityData) != 0
+		hasCert := len(r.Overrides.AuthInfo.ClientCertificateData) != 0
+		defaultTLS := hasCA || hasCert || r.Overrides.ClusterInfo.InsecureSkipTLSVerify
+		host, _, err := rest.DefaultServerURL(v, "", apimachineryschema.GroupVersion{}, defaultTLS)
+		if err != nil {
+			errs = append(errs, attributeError(fmt.Errorf("invalid value for 'host': %s", err), "host"))
+		} else {
+			r.Overrides.ClusterInfo.Server = host.String()
+			r.Report.add("host", r.Overrides.ClusterInfo.Server, source, envVar, false)
+		}
+	}
+
+	if v, source, envVar := c.resolveString(c.Username, "KUBE_USER", "KUBE_USERNAME"); v != "" {
+		r.Overrides.AuthInfo.Username = v
+		r.Report.add("username", v, source, envVar, false)
+	}
+	if v, source, envVar := c.resolveString(c.Password, "KUBE_PASSWORD"); v != "" {
+		r.Overrides.AuthInfo.Password = v
+		r.Report.add("password", v, source, envVar, true)
+	}
+	if v, source, envVar := c.resolveString(c.Token, "KUBE_TOKEN"); v != "" {
+		r.Overrides.AuthInfo.Token = v
+		r.Report.add("token", v, source, envVar, true)
+	}
+	if v, source, envVar := c.resolveString(c.ProxyURL, "KUBE_PROXY_URL"); v != "" {
+		r.Overrides.ClusterDefaults.ProxyURL = v
+		r.Report.add("proxy_url", v, source, envVar, false)
+	}
+	if v, source, envVar := c.resolveString(c.TLSServerName, "KUBE_TLS_SERVER_NAME"); v != "" {
+		r.Overrides.ClusterInfo.TLSServerName = v
+		r.Report.add("tls_server_name", v, source, envVar, false)
+	}
+	if c.QPS != 0 {
+		r.QPS = float32(c.QPS)
+		r.Report.add("qps", strconv.FormatFloat(c.QPS, 'f', -1, 64), ConfigSourceAttribute, "", false)
+	} else if v := c.getenv("KUBE_QPS"); v != "" {
+		qps, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			errs = append(errs, attributeError(fmt.Errorf("environment variable KUBE_QPS contains invalid value: %s", err), "qps"))
+		}
+		r.QPS = float32(qps)
+		r.Report.add("qps", v, ConfigSourceEnv, "KUBE_QPS", false)
+	}
+	if c.Burst != 0 {
+		r.Burst = c.Burst
+		r.Report.add("burst", strconv.Itoa(c.Burst), ConfigSourceAttribute, "", false)
+	} else if v := c.getenv("KUBE_BURST"); v != "" {
+		burst, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, attributeError(fmt.Errorf("environment variable KUBE_BURST contains invalid value: %s", err), "burst"))
+		}
+		r.Burst = burst
+		r.Report.add("burst", v, ConfigSourceEnv, "KUBE_BURST", false)
+	}
+	if r.QPS < 0 {
+		errs = append(errs, attributeError(errors.New("'qps' must not be negative"), "qps"))
+	}
+	if r.Burst < 0 {
+		errs = append(errs, attributeError(errors.New("'burst' must not be negative"), "burst"))
+	}
+	if v, source, envVar := c.resolveString(c.RequestTimeout, "KUBE_REQUEST_TIMEOUT"); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, attributeError(fmt.Errorf("invalid value for 'request_timeout': %s", err), "request_timeout"))
+		}
+		r.RequestTimeout = timeout
+		r.Report.add("request_timeout", v, source, envVar, false)
+	}
+	if c.Retry != nil {
+		policy, err := c.Retry.policy()
+		if err != nil {
+			errs = append(errs, err)
+		}
+		r.Retry = &policy
+		r.Report.add("retry", fmt.Sprintf("max_attempts=%d min_backoff=%s max_backoff=%s status_codes=%v",
+			policy.MaxAttempts, policy.MinBackoff, policy.MaxBackoff, policy.StatusCodes), ConfigSourceAttribute, "", false)
+	}
+
+	authMethods := 0
+	for _, set := range []bool{c.Exec != nil, c.EKS != nil, c.OIDC != nil} {
+		if set {
+			authMethods++
+		}
+	}
+	if authMethods > 1 {
+		errs = append(errs, fmt.Errorf("only one of 'exec', 'eks' and 'oidc' can be set"))
+	}
+	auth, err := r.authWrapper(c)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	r.auth = auth
+
+	if c.Exec != nil {
+		exec := *c.Exec
+		exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
+		r.Overrides.AuthInfo.Exec = &exec
+		r.Report.add("exec", exec.Command, ConfigSourceAttribute, "", false)
+	}
+
+	if len(errs) > 0 {
+		return r, errs
+	}
+	return r, nil
+}
+
+// ClusterConfig is an entry of the clusters block of the provider.
+type ClusterConfig struct {
+	Name string
+	ProviderConfig
+}
+
+// ResolveClusters resolves the entries of the clusters block, keyed by name.
+// Entries only use their own attributes, without falling back to environment
+// variables, and share the namespace, qps, burst, request_timeout and retry
+// settings of r.
+func (r *ResolvedConfig) ResolveClusters(clusters []ClusterConfig) (map[string]*ResolvedConfig, error) {
+	resolved := make(map[string]*ResolvedConfig, len(clusters))
+	var errs ConfigErrors
+	for i, c := range clusters {
+		if _, ok := resolved[c.Name]; ok {
+			errs = append(errs, attributeError(fmt.Errorf("cluster %q is defined more than once", c.Name), "clusters", i, "name"))
+			continue
+		}
+		c.IgnoreEnv = true
+		cr, err := ResolveConfig(c.ProviderConfig)
+		if err != nil {
+			for _, e := range err.(ConfigErrors) {
+				path := []interface{}{"clusters", i}
+				if ae, ok := e.(*AttributeError); ok {
+					path = append(path, ae.Path...)
+				}
+				errs = append(errs, attributeError(fmt.Errorf("cluster %q: %s", c.Name, e), path...))
+			}
+		}
+		if r.Overrides.Context.Namespace != "" {
+			cr.Overrides.Context.Namespace = r.Overrides.Context.Namespace
+		}
+		cr.useKubeconfigNamespace = r.useKubeconfigNamespace
+		cr.QPS = r.QPS
+		cr.Burst = r.Burst
+		cr.RequestTimeout = r.RequestTimeout
+		cr.Retry = r.Retry
+		resolved[c.Name] = cr
+	}
+	if len(errs) > 0 {
+		return resolved, errs
+	}
+	return resolved, nil
+}
+
+// ClientConfig loads the kubeconfig files, applies the overrides on top of
+// them and records the values taken from the kubeconfig in the report.
+// The given wrappers are installed between the eks or oidc authentication
+// and the retry transport, so they see every attempt of a retried request.
+func (r *ResolvedConfig) ClientConfig(wrappers ...transport.WrapperFunc) (*rest.Config, error) {
+	cc, err := r.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := cc.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.insecure != nil && !*r.insecure {
+		cfg.Insecure = false
+	}
+	if r.QPS > 0 {
+		cfg.QPS = r.QPS
+	}
+	if r.Burst > 0 {
+		cfg.Burst = r.Burst
+	}
+	if r.RequestTimeout > 0 {
+		cfg.Timeout = r.RequestTimeout
+	}
+	if r.auth != nil {
+		cfg.Wrap(r.auth)
+	}
+	for _, w := range wrappers {
+		cfg.Wrap(w)
+	}
+	if r.Retry != nil {
+		cfg.Wrap(r.Retry.Wrap)
+	}
+
+	if !r.hasKubeconfig() {
+		return cfg, nil
+	}
+	if raw, err := cc.RawConfig(); err == nil {
+		if _, ok := r.Report.Get("config_context"); !ok {
+			r.Report.add("config_context", raw.CurrentContext, ConfigSourceKubeconfig, "", false)
+		}
+	}
+	if _, ok := r.Report.Get("host"); !ok {
+		r.Report.add("host", cfg.Host, ConfigSourceKubeconfig, "", false)
+	}
+	if _, ok := r.Report.Get("token"); !ok && (cfg.BearerToken != "" || cfg.BearerTokenFile != "") {
+		r.Report.add("token", "", ConfigSourceKubeconfig, "", true)
+	}
+	if _, ok := r.Report.Get("client_certificate"); !ok && (len(cfg.CertData) > 0 || cfg.CertFile != "") {
+		r.Report.add("client_certificate", "", ConfigSourceKubeconfig, "", true)
+	}
+	if _, ok := r.Report.Get("exec"); !ok && cfg.ExecProvider != nil {
+		r.Report.add("exec", cfg.ExecProvider.Command, ConfigSourceKubeconfig, "", false)
+	}
+	return cfg, nil
+}
+
+// Namespace returns the namespace set with the namespace attribute or
+// KUBE_NAMESPACE, or else, when UseKubeconfigNamespace is set, the namespace
+// of the current kubeconfig context. It is empty when neither is set.
+func (r *ResolvedConfig) Namespace() string {
+	if r.Overrides.Context.Namespace != "" {
+		return r.Overrides.Context.Namespace
+	}
+	if !r.useKubeconfigNamespace || !r.hasKubeconfig() {
+		return ""
+	}
+	cc, err := r.clientConfig()
+	if err != nil {
+		return ""
+	}
+	raw, err := cc.RawConfig()
+	if err != nil {
+		return ""
+	}
+	name := raw.CurrentContext
+	if r.Overrides.CurrentContext != "" {
+		name = r.Overrides.CurrentContext
+	}
+	if ctx, ok := raw.Contexts[name]; ok {
+		return ctx.Namespace
+	}
+	return ""
+}
+
+func (c RetryConfig) policy() (RetryPolicy, error) {
+	p := RetryPolicy{
+		MaxAttempts: c.MaxAttempts,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		StatusCodes: c.StatusCodes,
+	}
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = 5
+	}
+	if len(p.StatusCodes) == 0 {
+		p.StatusCodes = DefaultRetryStatusCodes
+	}
+	var err error
+	if c.MinBackoff != "" {
+		if p.MinBackoff, err = time.ParseDuration(c.MinBackoff); err != nil {
+			return p, attributeError(fmt.Errorf("invalid value for 'retry.min_backoff': %s", err), "retry", 0, "min_backoff")
+		}
+	}
+	if c.MaxBackoff != "" {
+		if p.MaxBackoff, err = time.ParseDuration(c.MaxBackoff); err != nil {
+			return p, attributeError(fmt.Errorf("invalid value for 'retry.max_backoff': %s", err), "retry", 0, "max_backoff")
+		}
+	}
+	if p.MaxAttempts < 1 {
+		return p, attributeError(errors.New("'retry.max_attempts' must be at least 1"), "retry", 0, "max_attempts")
+	}
+	if p.MinBackoff <= 0 {
+		return p, attributeError(errors.New("'retry.min_backoff' must be positive"), "retry", 0, "min_backoff")
+	}
+	if p.MaxBackoff <= 0 {
+		return p, attributeError(errors.New("'retry.max_backoff' must be positive"), "retry", 0, "max_backoff")
+	}
+	if p.MinBackoff > p.MaxBackoff {
+		return p, attributeError(fmt.Errorf("'retry.max_backoff' (%s) must not be less than 'retry.min_backoff' (%s)", p.MaxBackoff, p.MinBackoff), "retry", 0, "max_backoff")
+	}
+	return p, nil
+}
+
+func (r *ResolvedConfig) hasKubeconfig() bool {
+	return r.Raw != nil || r.Loader.ExplicitPath != "" || len(r.Loader.Precedence) > 0
+}
+
+// clientConfig merges the inline kubeconfig with the kubeconfig files. Like
+// with multiple files, the first definition of a cluster, user or context wins.
+func (r *ResolvedConfig) clientConfig() (clientcmd.ClientConfig, error) {
+	if r.Raw == nil {
+		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(r.Loader, r.Overrides), nil
+	}
+	merged := r.Raw.DeepCopy()
+	if r.Loader.ExplicitPath != "" || len(r.Loader.Precedence) > 0 {
+		files, err := r.Loader.Load()
+		if err != nil {
+			return nil, err
+		}
+		if merged.Clusters == nil {
+			merged.Clusters = map[string]*clientcmdapi.Cluster{}
+		}
+		if merged.AuthInfos == nil {
+			merged.AuthInfos = map[string]*clientcmdapi.AuthInfo{}
+		}
+		if merged.Contexts == nil {
+			merged.Contexts = map[string]*clientcmdapi.Context{}
+		}
+		for k, v := range files.Clusters {
+			if _, ok := merged.Clusters[k]; !ok {
+				merged.Clusters[k] = v
+			}
+		}
+		for k, v := range files.AuthInfos {
+			if _, ok := merged.AuthInfos[k]; !ok {
+				merged.AuthInfos[k] = v
+			}
+		}
+		for k, v := range files.Contexts {
+			if _, ok := merged.Contexts[k]; !ok {
+				merged.Contexts[k] = v
+			}
+		}
+		if merged.CurrentContext == "" {
+			merged.CurrentContext = files.CurrentContext
+		}
+	}
+	return clientcmd.NewNonInteractiveClientConfig(*merged, "", r.Overrides, nil), nil
+}
+
+// resolveConfigPaths returns the kubeconfig files to load. KUBE_CONFIG_PATH
+// is the default of config_path, so both take precedence over config_paths,
+// which takes precedence over KUBE_CONFIG_PATHS.
+func (c ProviderConfig) resolveConfigPaths() ([]string, ConfigSource, string) {
+	if c.ConfigPath != "" {
+		return []string{c.ConfigPath}, ConfigSourceAttribute, ""
+	}
+	if v := c.getenv("KUBE_CONFIG_PATH"); v != "" {
+		return []string{v}, ConfigSourceEnv, "KUBE_CONFIG_PATH"
+	}
+	if len(c.ConfigPaths) > 0 {
+		return c.ConfigPaths, ConfigSourceAttribute, ""
+	}
+	if v := c.getenv("KUBE_CONFIG_PATHS"); v != "" {
+		return filepath.SplitList(v), ConfigSourceEnv, "KUBE_CONFIG_PATHS"
+	}
+	return nil, "", ""
+}
+
+// resolveString returns the attribute value if set, otherwise the value of
+// the first non-empty environment variable out of envVars.
+func (c ProviderConfig) resolveString(attr string, envVars ...string) (string, ConfigSource, string) {
+	if attr != "" {
+		return attr, ConfigSourceAttribute, ""
+	}
+	for _, e := range envVars {
+		if v := c.getenv(e); v != "" {
+			return v, ConfigSourceEnv, e
+		}
+	}
+	return "", "", ""
+}
+
+func (c ProviderConfig) getenv(name string) string {
+	if c.IgnoreEnv {
+		return ""
+	}
+	return os.Getenv(name)
+}
+
+func pemBlockType(s string) string {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return ""
+	}
+	return block.Type
+}
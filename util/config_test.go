@@ -0,0 +1,425 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://primary.example.com
+  name: primary
+- cluster:
+    server: https://secondary.example.com
+  name: secondary
+contexts:
+- context:
+    cluster: primary
+    user: admin
+  name: primary
+- context:
+    cluster: secondary
+    namespace: team-a
+    user: admin
+  name: secondary
+current-context: primary
+users:
+- name: admin
+  user:
+    token: kubeconfig-token
+`
+
+func writeTestKubeconfig(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func clearConfigEnv(t *testing.T) {
+	for _, e := range []string{
+		"KUBE_CONFIG_PATH", "KUBE_CONFIG_PATHS", "KUBE_CTX", "KUBE_CTX_AUTH_INFO", "KUBE_CTX_CLUSTER",
+		"KUBE_HOST", "KUBE_USER", "KUBE_USERNAME", "KUBE_PASSWORD", "KUBE_INSECURE", "KUBE_TOKEN",
+		"KUBE_CLIENT_CERT_DATA", "KUBE_CLIENT_KEY_DATA", "KUBE_CLUSTER_CA_CERT_DATA", "KUBE_PROXY_URL", "KUBE_CONFIG_RAW", "KUBE_TLS_SERVER_NAME",
+		"KUBE_QPS", "KUBE_BURST", "KUBE_REQUEST_TIMEOUT", "KUBE_NAMESPACE",
+	} {
+		t.Setenv(e, "")
+	}
+}
+
+func TestResolveConfig(t *testing.T) {
+	kubeconfig := writeTestKubeconfig(t)
+
+	cases := map[string]struct {
+		config  ProviderConfig
+		env     map[string]string
+		host    string
+		sources map[string]ConfigSource
+	}{
+		"kubeconfig current context": {
+			config: ProviderConfig{ConfigPath: kubeconfig},
+			host:   "https://primary.example.com",
+			sources: map[string]ConfigSource{
+				"config_paths":   ConfigSourceAttribute,
+				"config_context": ConfigSourceKubeconfig,
+				"host":           ConfigSourceKubeconfig,
+				"token":          ConfigSourceKubeconfig,
+			},
+		},
+		"context from env": {
+			config: ProviderConfig{ConfigPath: kubeconfig},
+			env:    map[string]string{"KUBE_CTX": "secondary"},
+			host:   "https://secondary.example.com",
+			sources: map[string]ConfigSource{
+				"config_context": ConfigSourceEnv,
+				"host":           ConfigSourceKubeconfig,
+			},
+		},
+		"attribute takes precedence over env": {
+			config: ProviderConfig{ConfigPath: kubeconfig, ConfigContext: "primary"},
+			env: map[string]string{
+				"KUBE_CTX":          "secondary",
+				"KUBE_CONFIG_PATHS": "/does/not/exist",
+			},
+			host: "https://primary.example.com",
+			sources: map[string]ConfigSource{
+				"config_paths":   ConfigSourceAttribute,
+				"config_context": ConfigSourceAttribute,
+			},
+		},
+		"host overrides kubeconfig": {
+			config: ProviderConfig{Host: "https://override.example.com"},
+			env:    map[string]string{"KUBE_CONFIG_PATH": kubeconfig, "KUBE_TOKEN": "env-token"},
+			host:   "https://override.example.com",
+			sources: map[string]ConfigSource{
+				"config_paths": ConfigSourceEnv,
+				"host":         ConfigSourceAttribute,
+				"token":        ConfigSourceEnv,
+			},
+		},
+		"inline kubeconfig": {
+			env:  map[string]string{"KUBE_CONFIG_RAW": testKubeconfig, "KUBE_CTX": "secondary"},
+			host: "https://secondary.example.com",
+			sources: map[string]ConfigSource{
+				"config_raw":     ConfigSourceEnv,
+				"config_context": ConfigSourceEnv,
+				"host":           ConfigSourceKubeconfig,
+			},
+		},
+		"inline kubeconfig takes precedence over files": {
+			config: ProviderConfig{
+				ConfigPath: kubeconfig,
+				ConfigRaw: `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://inline.example.com
+  name: primary
+`,
+			},
+			host: "https://inline.example.com",
+			sources: map[string]ConfigSource{
+				"config_raw":     ConfigSourceAttribute,
+				"config_context": ConfigSourceKubeconfig,
+			},
+		},
+		"context ignored without kubeconfig": {
+			config: ProviderConfig{Host: "https://static.example.com", ConfigContext: "primary"},
+			env:    map[string]string{"KUBE_USERNAME": "admin"},
+			host:   "https://static.example.com",
+			sources: map[string]ConfigSource{
+				"host":     ConfigSourceAttribute,
+				"username": ConfigSourceEnv,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			resolved, err := ResolveConfig(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := resolved.ClientConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Host != tc.host {
+				t.Errorf("expected host %q, got %q", tc.host, cfg.Host)
+			}
+			for attr, source := range tc.sources {
+				v, ok := resolved.Report.Get(attr)
+				if !ok {
+					t.Errorf("%s missing from report:\n%s", attr, resolved.Report)
+					continue
+				}
+				if v.Source != source {
+					t.Errorf("expected %s to come from %s, got %s", attr, source, v.Source)
+				}
+			}
+			if _, ok := resolved.Report.Get("config_context"); ok && tc.config.ConfigPath == "" && tc.env["KUBE_CONFIG_PATH"] == "" && tc.env["KUBE_CONFIG_RAW"] == "" {
+				t.Errorf("config_context should be ignored without kubeconfig")
+			}
+		})
+	}
+}
+
+func TestResolveConfigPaths(t *testing.T) {
+	cases := map[string]struct {
+		config ProviderConfig
+		env    map[string]string
+		paths  []string
+		source ConfigSource
+	}{
+		"config_path over KUBE_CONFIG_PATH": {
+			config: ProviderConfig{ConfigPath: "/attr/config"},
+			env:    map[string]string{"KUBE_CONFIG_PATH": "/env/config"},
+			paths:  []string{"/attr/config"},
+			source: ConfigSourceAttribute,
+		},
+		"KUBE_CONFIG_PATH over config_paths": {
+			config: ProviderConfig{ConfigPaths: []string{"/attr/a", "/attr/b"}},
+			env:    map[string]string{"KUBE_CONFIG_PATH": "/env/config"},
+			paths:  []string{"/env/config"},
+			source: ConfigSourceEnv,
+		},
+		"config_paths over KUBE_CONFIG_PATHS": {
+			config: ProviderConfig{ConfigPaths: []string{"/attr/a", "/attr/b"}},
+			env:    map[string]string{"KUBE_CONFIG_PATHS": "/env/a" + string(os.PathListSeparator) + "/env/b"},
+			paths:  []string{"/attr/a", "/attr/b"},
+			source: ConfigSourceAttribute,
+		},
+		"KUBE_CONFIG_PATH over KUBE_CONFIG_PATHS": {
+			env: map[string]string{
+				"KUBE_CONFIG_PATH":  "/env/config",
+				"KUBE_CONFIG_PATHS": "/env/a" + string(os.PathListSeparator) + "/env/b",
+			},
+			paths:  []string{"/env/config"},
+			source: ConfigSourceEnv,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			paths, source, _ := tc.config.resolveConfigPaths()
+			if strings.Join(paths, ",") != strings.Join(tc.paths, ",") || source != tc.source {
+				t.Errorf("expected %v from %s, got %v from %s", tc.paths, tc.source, paths, source)
+			}
+		})
+	}
+}
+
+func TestResolveConfigTLSServerName(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("KUBE_TLS_SERVER_NAME", "kubernetes.default.svc")
+
+	resolved, err := ResolveConfig(ProviderConfig{Host: "https://10.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := resolved.ClientConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TLSClientConfig.ServerName != "kubernetes.default.svc" {
+		t.Errorf("expected TLS server name to be set from env, got %q", cfg.TLSClientConfig.ServerName)
+	}
+}
+
+func TestResolveConfigClientSettings(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("KUBE_BURST", "200")
+
+	resolved, err := ResolveConfig(ProviderConfig{
+		Host:           "https://example.com",
+		QPS:            50,
+		RequestTimeout: "45s",
+		Retry:          &RetryConfig{MaxBackoff: "5s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := resolved.ClientConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.QPS != 50 || cfg.Burst != 200 || cfg.Timeout != 45*time.Second {
+		t.Errorf("unexpected client settings: qps=%v burst=%d timeout=%s", cfg.QPS, cfg.Burst, cfg.Timeout)
+	}
+	if cfg.WrapTransport == nil {
+		t.Errorf("expected retry transport to be installed")
+	}
+	if resolved.Retry.MaxAttempts != 5 || resolved.Retry.MinBackoff != 500*time.Millisecond || resolved.Retry.MaxBackoff != 5*time.Second {
+		t.Errorf("unexpected retry policy: %#v", resolved.Retry)
+	}
+}
+
+func TestResolveConfigNamespace(t *testing.T) {
+	kubeconfig := writeTestKubeconfig(t)
+
+	cases := map[string]struct {
+		config    ProviderConfig
+		env       map[string]string
+		namespace string
+	}{
+		"none": {
+			config:    ProviderConfig{Host: "https://example.com"},
+			namespace: "",
+		},
+		"context without namespace": {
+			config:    ProviderConfig{ConfigPath: kubeconfig},
+			namespace: "",
+		},
+		"context namespace is ignored by default": {
+			config:    ProviderConfig{ConfigPath: kubeconfig, ConfigContext: "secondary"},
+			namespace: "",
+		},
+		"context namespace": {
+			config:    ProviderConfig{ConfigPath: kubeconfig, ConfigContext: "secondary", UseKubeconfigNamespace: true},
+			namespace: "team-a",
+		},
+		"env overrides context": {
+			config:    ProviderConfig{ConfigPath: kubeconfig, ConfigContext: "secondary", UseKubeconfigNamespace: true},
+			env:       map[string]string{"KUBE_NAMESPACE": "team-b"},
+			namespace: "team-b",
+		},
+		"attribute overrides env": {
+			config:    ProviderConfig{Host: "https://example.com", Namespace: "team-c"},
+			env:       map[string]string{"KUBE_NAMESPACE": "team-b"},
+			namespace: "team-c",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			resolved, err := ResolveConfig(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ns := resolved.Namespace(); ns != tc.namespace {
+				t.Errorf("expected namespace %q, got %q", tc.namespace, ns)
+			}
+		})
+	}
+}
+
+func TestResolveConfigInvalid(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("KUBE_INSECURE", "maybe")
+
+	_, err := ResolveConfig(ProviderConfig{
+		ClientCertificate: "not a certificate",
+		ClientKey:         "not a key",
+		ConfigRaw:         "clusters: {",
+		RequestTimeout:    "soon",
+		Retry:             &RetryConfig{MinBackoff: "1 second"},
+	})
+	errs, ok := err.(ConfigErrors)
+	if !ok {
+		t.Fatalf("expected ConfigErrors, got %#v", err)
+	}
+	if len(errs) != 6 {
+		t.Errorf("expected 6 errors, got %d: %s", len(errs), err)
+	}
+}
+
+func TestResolveConfigInvalidRetry(t *testing.T) {
+	cases := map[string]struct {
+		retry RetryConfig
+		attr  string
+	}{
+		"zero min_backoff":     {RetryConfig{MinBackoff: "0s"}, "min_backoff"},
+		"negative max_backoff": {RetryConfig{MaxBackoff: "-1s"}, "max_backoff"},
+		"min above max":        {RetryConfig{MinBackoff: "20s", MaxBackoff: "10s"}, "max_backoff"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			retry := tc.retry
+			_, err := ResolveConfig(ProviderConfig{Host: "https://example.com", Retry: &retry})
+			errs, ok := err.(ConfigErrors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("expected a single error, got %#v", err)
+			}
+			var attrErr *AttributeError
+			if !errors.As(errs[0], &attrErr) {
+				t.Fatalf("expected an AttributeError, got %#v", errs[0])
+			}
+			if !reflect.DeepEqual(attrErr.Path, []interface{}{"retry", 0, tc.attr}) {
+				t.Errorf("unexpected path %v", attrErr.Path)
+			}
+		})
+	}
+}
+
+func TestConfigReportRedactsSensitiveValues(t *testing.T) {
+	clearConfigEnv(t)
+
+	resolved, err := ResolveConfig(ProviderConfig{Host: "https://example.com", Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ := resolved.Report.Get("token")
+	if v.Value == "secret" {
+		t.Errorf("token value should not appear in the report")
+	}
+}
+
+func TestResolveClusters(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("KUBE_TOKEN", "top-level-token")
+	t.Setenv("KUBE_TLS_SERVER_NAME", "kubernetes.default.svc")
+
+	resolved, err := ResolveConfig(ProviderConfig{Host: "https://default.example.com", QPS: 50, RequestTimeout: "45s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusters, err := resolved.ResolveClusters([]ClusterConfig{
+		{Name: "east", ProviderConfig: ProviderConfig{Host: "https://east.example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := clusters["east"].ClientConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://east.example.com" {
+		t.Errorf("unexpected host %q", cfg.Host)
+	}
+	if cfg.BearerToken != "" || cfg.TLSClientConfig.ServerName != "" {
+		t.Errorf("environment variables should not apply to clusters")
+	}
+	if cfg.QPS != 50 || cfg.Timeout != 45*time.Second {
+		t.Errorf("client settings were not inherited: qps=%v timeout=%s", cfg.QPS, cfg.Timeout)
+	}
+
+	_, err = resolved.ResolveClusters([]ClusterConfig{
+		{Name: "east", ProviderConfig: ProviderConfig{Host: "https://east.example.com"}},
+		{Name: "east", ProviderConfig: ProviderConfig{Host: "https://west.example.com"}},
+		{Name: "west", ProviderConfig: ProviderConfig{ClientKey: "not a key"}},
+	})
+	errs, ok := err.(ConfigErrors)
+	if !ok || len(errs) != 2 {
+		t.Errorf("expected 2 errors, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// RemoveFieldManager removes the managed fields entries of the given field
+// manager from an object, so that the object no longer records the fields
+// set by that manager. This is used to orphan an object instead of deleting
+// it: the fields are left to the other managers of the object, and a later
+// server-side apply by someone else does not conflict with a manager that
+// is gone.
+func RemoveFieldManager(ctx context.Context, rs dynamic.ResourceInterface, name string, manager string) error {
+	obj, err := rs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	entries := obj.GetManagedFields()
+	kept := make([]metav1.ManagedFieldsEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Manager != manager {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(entries) {
+		return nil
+	}
+	return replaceManagedFields(ctx, rs, obj, kept)
+}
+
+// ReleaseFields removes the given fields from the managed fields entries of
+// a field manager, leaving their values in place. Entries left without any
+// field are removed. This gives up the ownership of some fields of an object
+// without changing the object itself.
+func ReleaseFields(ctx context.Context, rs dynamic.ResourceInterface, name string, manager string, fields *fieldpath.Set) error {
+	obj, err := rs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	entries := obj.GetManagedFields()
+	kept := make([]metav1.ManagedFieldsEntry, 0, len(entries))
+	changed := false
+	for _, e := range entries {
+		if e.Manager != manager || e.FieldsV1 == nil {
+			kept = append(kept, e)
+			continue
+		}
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(e.FieldsV1.Raw)); err != nil {
+			return err
+		}
+		remaining := set.Difference(fields)
+		if remaining.Equals(set) {
+			kept = append(kept, e)
+			continue
+		}
+		changed = true
+		if remaining.Empty() {
+			continue
+		}
+		raw, err := remaining.ToJSON()
+		if err != nil {
+			return err
+		}
+		e.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+		kept = append(kept, e)
+	}
+	if !changed {
+		return nil
+	}
+	return replaceManagedFields(ctx, rs, obj, kept)
+}
+
+func replaceManagedFields(ctx context.Context, rs dynamic.ResourceInterface, obj *unstructured.Unstructured, entries []metav1.ManagedFieldsEntry) error {
+	var managedFields interface{} = entries
+	if len(entries) == 0 {
+		// The API server ignores an empty list of managed fields, while a
+		// list holding a single empty entry clears them.
+		managedFields = []map[string]interface{}{{}}
+	}
+	ops := []map[string]interface{}{}
+	if rv := obj.GetResourceVersion(); rv != "" {
+		// fail rather than drop the entries of a concurrent update
+		ops = append(ops, map[string]interface{}{"op": "test", "path": "/metadata/resourceVersion", "value": rv})
+	}
+	ops = append(ops, map[string]interface{}{"op": "replace", "path": "/metadata/managedFields", "value": managedFields})
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	_, err = rs.Patch(ctx, obj.GetName(), types.JSONPatchType, patch, metav1.PatchOptions{})
+	return err
+}
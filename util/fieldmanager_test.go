@@ -0,0 +1,164 @@
+package util
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+func TestRemoveFieldManager(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	entry := func(manager string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:foo":{}}}`)},
+		}
+	}
+
+	cases := map[string]struct {
+		Managers []string
+		Expected []string
+	}{
+		"other managers are kept": {
+			Managers: []string{"Terraform", "kubectl"},
+			Expected: []string{"kubectl"},
+		},
+		"only manager": {
+			Managers: []string{"Terraform"},
+			Expected: []string{},
+		},
+		"not a manager": {
+			Managers: []string{"kubectl"},
+			Expected: []string{"kubectl"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cm := &unstructured.Unstructured{}
+			cm.SetAPIVersion("v1")
+			cm.SetKind("ConfigMap")
+			cm.SetNamespace("default")
+			cm.SetName("test")
+			var entries []metav1.ManagedFieldsEntry
+			for _, m := range tc.Managers {
+				entries = append(entries, entry(m))
+			}
+			cm.SetManagedFields(entries)
+
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), cm)
+			rs := client.Resource(gvr).Namespace("default")
+			if err := RemoveFieldManager(context.Background(), rs, "test", "Terraform"); err != nil {
+				t.Fatal(err)
+			}
+
+			obj, err := rs.Get(context.Background(), "test", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			managers := []string{}
+			for _, e := range obj.GetManagedFields() {
+				if e.Manager != "" {
+					managers = append(managers, e.Manager)
+				}
+			}
+			if len(managers) != len(tc.Expected) {
+				t.Fatalf("expected managers %v, got %v", tc.Expected, managers)
+			}
+			for i := range managers {
+				if managers[i] != tc.Expected[i] {
+					t.Fatalf("expected managers %v, got %v", tc.Expected, managers)
+				}
+			}
+		})
+	}
+}
+
+func TestReleaseFields(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	entry := func(manager, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+	image := fieldpath.MakePathOrDie("spec", "template", "spec", "containers",
+		fieldpath.KeyByFields("name", "app"), "image")
+
+	cases := map[string]struct {
+		Entries  []metav1.ManagedFieldsEntry
+		Expected map[string]string
+	}{
+		"other fields are kept": {
+			Entries: []metav1.ManagedFieldsEntry{
+				entry("Terraform", `{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{"f:image":{}}}}}}}`),
+				entry("kubectl", `{"f:spec":{"f:replicas":{}}}`),
+			},
+			Expected: map[string]string{
+				"Terraform": `{"f:metadata":{"f:labels":{"f:app":{}}}}`,
+				"kubectl":   `{"f:spec":{"f:replicas":{}}}`,
+			},
+		},
+		"empty entries are removed": {
+			Entries: []metav1.ManagedFieldsEntry{
+				entry("Terraform", `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{"f:image":{}}}}}}}`),
+				entry("kubectl", `{"f:spec":{"f:replicas":{}}}`),
+			},
+			Expected: map[string]string{
+				"kubectl": `{"f:spec":{"f:replicas":{}}}`,
+			},
+		},
+		"other managers are not changed": {
+			Entries: []metav1.ManagedFieldsEntry{
+				entry("kubectl", `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{"f:image":{}}}}}}}`),
+			},
+			Expected: map[string]string{
+				"kubectl": `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{"f:image":{}}}}}}}`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deploy := &unstructured.Unstructured{}
+			deploy.SetAPIVersion("apps/v1")
+			deploy.SetKind("Deployment")
+			deploy.SetNamespace("default")
+			deploy.SetName("test")
+			deploy.SetManagedFields(tc.Entries)
+
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), deploy)
+			rs := client.Resource(gvr).Namespace("default")
+			if err := ReleaseFields(context.Background(), rs, "test", "Terraform", fieldpath.NewSet(image)); err != nil {
+				t.Fatal(err)
+			}
+
+			obj, err := rs.Get(context.Background(), "test", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields := map[string]string{}
+			for _, e := range obj.GetManagedFields() {
+				if e.Manager != "" {
+					fields[e.Manager] = string(e.FieldsV1.Raw)
+				}
+			}
+			if !reflect.DeepEqual(tc.Expected, fields) {
+				t.Fatalf("expected managed fields %v, got %v", tc.Expected, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package util
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryStatusCodes are the HTTP status codes retried when a retry
+// policy does not list any.
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how requests to the Kubernetes API are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles on every
+	// further retry, up to MaxBackoff.
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	StatusCodes []int
+}
+
+// Wrap returns a RoundTripper that retries requests sent through rt according
+// to the policy. It has the signature of a transport.WrapperFunc so it can be
+// chained with other wrappers on a rest.Config.
+func (p RetryPolicy) Wrap(rt http.RoundTripper) http.RoundTripper {
+	return &retryTransport{policy: p, rt: rt}
+}
+
+// retriable tells whether req can be sent again after it got resp or failed
+// with a transport error. Idempotent requests are retried on any of the
+// status codes of the policy and on transport errors. Other requests may have
+// been processed already, so they are only retried when the server rejected
+// them with 429 or 503 and a Retry-After header.
+func (p RetryPolicy) retriable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return idempotent(req) && req.Context().Err() == nil
+	}
+	if !p.retriableStatus(resp.StatusCode) {
+		return false
+	}
+	if idempotent(req) {
+		return true
+	}
+	return (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) &&
+		resp.Header.Get("Retry-After") != ""
+}
+
+func (p RetryPolicy) retriableStatus(code int) bool {
+	for _, c := range p.StatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry, honouring the
+// Retry-After header of the previous response when present. resp is nil
+// when the previous attempt failed with a transport error.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > 0 {
+			d = time.Duration(s) * time.Second
+		}
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+type retryTransport struct {
+	policy RetryPolicy
+	rt     http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.rt.RoundTrip(req)
+	for attempt := 1; attempt < t.policy.MaxAttempts; attempt++ {
+		if !t.policy.retriable(req, resp, err) {
+			return resp, err
+		}
+		// Requests with a body can only be replayed when it can be re-read.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		delay := t.policy.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[DEBUG] Retrying %s %s in %s after error: %s (attempt %d of %d)",
+				req.Method, req.URL, delay, err, attempt+1, t.policy.MaxAttempts)
+		} else {
+			log.Printf("[DEBUG] Retrying %s %s in %s after receiving status %d (attempt %d of %d)",
+				req.Method, req.URL, delay, resp.StatusCode, attempt+1, t.policy.MaxAttempts)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+
+		r := req.Clone(req.Context())
+		if req.GetBody != nil {
+			r.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp, err = t.rt.RoundTrip(r)
+	}
+	return resp, err
+}
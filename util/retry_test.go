@@ -0,0 +1,172 @@
+package util
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		method     string
+		statuses   []int
+		retryAfter bool
+		policy     RetryPolicy
+		attempts   int
+		status     int
+	}{
+		"success": {
+			statuses: []int{200},
+			policy:   RetryPolicy{MaxAttempts: 3, StatusCodes: DefaultRetryStatusCodes},
+			attempts: 1,
+			status:   200,
+		},
+		"retried until success": {
+			statuses: []int{503, 429, 200},
+			policy:   RetryPolicy{MaxAttempts: 3, StatusCodes: DefaultRetryStatusCodes},
+			attempts: 3,
+			status:   200,
+		},
+		"attempts exhausted": {
+			statuses: []int{500, 500, 500, 500},
+			policy:   RetryPolicy{MaxAttempts: 2, StatusCodes: DefaultRetryStatusCodes},
+			attempts: 2,
+			status:   500,
+		},
+		"status not retriable": {
+			statuses: []int{404, 200},
+			policy:   RetryPolicy{MaxAttempts: 3, StatusCodes: DefaultRetryStatusCodes},
+			attempts: 1,
+			status:   404,
+		},
+		"custom status codes": {
+			statuses: []int{409, 200},
+			policy:   RetryPolicy{MaxAttempts: 3, StatusCodes: []int{409}},
+			attempts: 2,
+			status:   200,
+		},
+		"not idempotent": {
+			method:   http.MethodPost,
+			statuses: []int{500, 200},
+			policy:   RetryPolicy{MaxAttempts: 3, StatusCodes: DefaultRetryStatusCodes},
+			attempts: 1,
+			status:   500,
+		},
+		"not idempotent without Retry-After": {
+			method:   http.MethodPatch,
+			statuses: []int{429, 200},
+			policy:   RetryPolicy{MaxAttempts: 3, StatusCodes: DefaultRetryStatusCodes},
+			attempts: 1,
+			status:   429,
+		},
+		"not idempotent with Retry-After": {
+			method:     http.MethodPost,
+			statuses:   []int{429, 503, 200},
+			retryAfter: true,
+			policy:     RetryPolicy{MaxAttempts: 3, StatusCodes: DefaultRetryStatusCodes},
+			attempts:   3,
+			status:     200,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("attempt %d received body %q", attempts+1, body)
+				}
+				if tc.retryAfter {
+					w.Header().Set("Retry-After", "1")
+				}
+				w.WriteHeader(tc.statuses[attempts])
+				attempts++
+			}))
+			defer srv.Close()
+
+			tc.policy.MinBackoff = time.Millisecond
+			tc.policy.MaxBackoff = time.Millisecond
+			client := &http.Client{Transport: tc.policy.Wrap(http.DefaultTransport)}
+			method := tc.method
+			if method == "" {
+				method = http.MethodPut
+			}
+			req, err := http.NewRequest(method, srv.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if attempts != tc.attempts {
+				t.Errorf("expected %d attempts, got %d", tc.attempts, attempts)
+			}
+			if resp.StatusCode != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// failingTransport fails the first requests with a transport error.
+type failingTransport struct {
+	failures int
+	attempts int
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts++
+	if t.attempts <= t.failures {
+		return nil, errors.New("connection reset by peer")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, StatusCodes: DefaultRetryStatusCodes}
+
+	rt := &failingTransport{failures: 2}
+	req, _ := http.NewRequest(http.MethodGet, "http://cluster.example.com/api", nil)
+	resp, err := policy.Wrap(rt).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected the GET request to succeed after retries, got %s", err)
+	}
+	if resp.StatusCode != http.StatusOK || rt.attempts != 3 {
+		t.Errorf("expected status 200 after 3 attempts, got %d after %d", resp.StatusCode, rt.attempts)
+	}
+
+	rt = &failingTransport{failures: 1}
+	req, _ = http.NewRequest(http.MethodPost, "http://cluster.example.com/api", nil)
+	if _, err := policy.Wrap(rt).RoundTrip(req); err == nil || rt.attempts != 1 {
+		t.Errorf("expected the POST request to fail without retries, got %v after %d attempts", err, rt.attempts)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	resp := &http.Response{Header: http.Header{}}
+
+	for retry, expected := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+	} {
+		if d := p.backoff(retry, resp); d != expected {
+			t.Errorf("retry %d: expected backoff %s, got %s", retry, expected, d)
+		}
+	}
+
+	resp.Header.Set("Retry-After", "3")
+	if d := p.backoff(1, resp); d != time.Second {
+		t.Errorf("Retry-After should be capped at max backoff, got %s", d)
+	}
+}
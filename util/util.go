@@ -19,13 +19,19 @@ import (
 // where 'namespace' is only required for resources that expect a namespace.
 // Example: "apiVersion=v1,kind=Secret,namespace=default,name=default-token-qgm6s"
 func ParseResourceID(id string) (schema.GroupVersionKind, string, string, error) {
+	return ParseResourceIDInNamespace(id, "default")
+}
+
+// ParseResourceIDInNamespace is like ParseResourceID, but returns
+// defaultNamespace for IDs without a namespace.
+func ParseResourceIDInNamespace(id, defaultNamespace string) (schema.GroupVersionKind, string, string, error) {
 	parts := strings.Split(id, ",")
 	if len(parts) < 3 || len(parts) > 4 {
 		return schema.GroupVersionKind{}, "", "",
 			fmt.Errorf("could not parse ID: %q. ID must contain apiVersion, kind, and name", id)
 	}
 
-	namespace := "default"
+	namespace := defaultNamespace
 	var apiVersion, kind, name string
 	for _, p := range parts {
 		pp := strings.Split(p, "=")
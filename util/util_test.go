@@ -65,3 +65,20 @@ func TestParseResourceID(t *testing.T) {
 		})
 	}
 }
+
+func TestParseResourceIDInNamespace(t *testing.T) {
+	_, _, ns, err := ParseResourceIDInNamespace("apiVersion=v1,kind=ConfigMap,name=test", "team-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ns != "team-a" {
+		t.Errorf("expected namespace %q got %q", "team-a", ns)
+	}
+	_, _, ns, err = ParseResourceIDInNamespace("apiVersion=v1,kind=ConfigMap,name=test,namespace=kube-system", "team-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ns != "kube-system" {
+		t.Errorf("expected namespace %q got %q", "kube-system", ns)
+	}
+}